@@ -74,9 +74,26 @@ func (g *ActionProvider) meleeAttack(source *core.Actor, item *core.Item, target
 
 func (g *ActionProvider) toolUsage(source *core.Actor, item *core.Item, target geometry.Point) {
 	m := g.engine.GetGame()
+	if riggable, ok := g.riggableAt(item, target); ok {
+		riggable.Rig(g.engine, source, item)
+		return
+	}
 	m.SendTriggerStimuli(source, item, target, core.TriggerOnToolUsage)
 }
 
+// riggableAt returns the appliance at target, if it can be rigged with the item.
+func (g *ActionProvider) riggableAt(item *core.Item, target geometry.Point) (services.Riggable, bool) {
+	object, isObjectAt := g.engine.GetGame().GetMap().TryGetObjectAt(target)
+	if !isObjectAt {
+		return nil, false
+	}
+	riggable, ok := object.(services.Riggable)
+	if !ok || !riggable.CanBeRiggedWith(item) {
+		return nil, false
+	}
+	return riggable, true
+}
+
 var gunLightSource = &gridmap.LightSource{
 	Pos:          geometry.Point{},
 	Radius:       10,
@@ -209,6 +226,7 @@ func (g *ActionProvider) rangedThrow(source *core.Actor, item *core.Item, target
 	bullet := NewThrownItem(g.engine, source, item)
 	bullet.StartTravel(los)
 	g.activeProjectiles = append(g.activeProjectiles, bullet)
+	g.engine.PublishEvent(services.ItemThrownEvent{Thrower: source, Item: item})
 	// start animation
 	audio.PlayCue("throw")
 }
@@ -240,7 +258,7 @@ func (g *ActionProvider) UseEquippedItemForMelee(person *core.Actor, target geom
 	m := g.engine.GetGame()
 	item := person.EquippedItem
 	println(fmt.Sprintf("%s used %s for a melee attack", person.Name, item.Name))
-	if item.Type.IsMeleeTool() {
+	if _, isRiggable := g.riggableAt(item, target); isRiggable || item.Type.IsMeleeTool() {
 		g.toolUsage(person, item, target)
 	} else {
 		g.meleeAttack(person, item, target)
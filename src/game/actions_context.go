@@ -64,17 +64,13 @@ func (a ExitAction) Action(m services.Engine, person *core.Actor, position geome
 }
 
 func (a ExitAction) IsActionPossible(m services.Engine, person *core.Actor, actionAt geometry.Point) bool {
-	for _, inLevel := range m.GetGame().GetMap().Actors() {
-		if inLevel.IsTarget && !inLevel.Dead {
+	if contract := m.GetGame().GetContract(); contract != nil && contract.RequiredExit != "" {
+		if zone := m.GetGame().GetMap().ZoneAt(actionAt); zone == nil || zone.Name != contract.RequiredExit {
 			return false
 		}
 	}
-	for _, inLevel := range m.GetGame().GetMap().DownedActors() {
-		if inLevel.IsTarget && !inLevel.Dead {
-			return false
-		}
-	}
-	return true
+	objectives := m.GetGame().GetObjectives()
+	return objectives == nil || objectives.RequiredCompleted()
 }
 
 type ExposeElectricityAction struct {
@@ -85,11 +81,16 @@ func (e ExposeElectricityAction) Description(services.Engine, *core.Actor, geome
 	return e.Icon, common.DefaultStyle.WithBg(core.CurrentTheme.IllegalActionBackground)
 }
 
+// IsActionPossible is only true while the circuit of the outlet is live.
 func (e ExposeElectricityAction) IsActionPossible(m services.Engine, person *core.Actor, actionAt geometry.Point) bool {
-	return !m.GetGame().GetMap().IsStimulusOnTile(actionAt, stimuli.StimulusHighVoltage)
+	return !m.GetGame().GetMap().IsStimulusOnTile(actionAt, stimuli.StimulusHighVoltage) && m.GetGame().IsPoweredAt(actionAt)
 }
 
 func (e ExposeElectricityAction) Action(m services.Engine, person *core.Actor, position geometry.Point) {
+	if !m.GetGame().IsPoweredAt(position) {
+		m.GetGame().PrintMessage("The outlet has no power.")
+		return
+	}
 	stim := stimuli.Stim{StimType: stimuli.StimulusHighVoltage, StimForce: 100}
 	m.GetGame().GetMap().AddStimulusToTile(position, stim)
 	m.GetGame().IllegalActionAt(position, core.ObservationIllegalAction)
@@ -589,3 +590,45 @@ func (d DialogueAction) IsActionPossible(m services.Engine, person *core.Actor,
 	hasCurrentDialogueSet := person.Dialogue.CurrentDialogue != ""
 	return !wasRecentlyActive && !hasCurrentDialogueSet
 }
+
+// ChangeFloorAction takes the stairs or the elevator. With more than one
+// floor to go to, the player picks one from a menu.
+type ChangeFloorAction struct {
+	Icon rune
+}
+
+func (c ChangeFloorAction) Description(services.Engine, *core.Actor, geometry.Point) (rune, common.Style) {
+	return c.Icon, common.DefaultStyle.WithBg(core.CurrentTheme.LegalActionBackground)
+}
+
+func (c ChangeFloorAction) IsActionPossible(m services.Engine, person *core.Actor, actionAt geometry.Point) bool {
+	return len(m.GetGame().GetMap().FloorLinksAt(actionAt)) > 0
+}
+
+func (c ChangeFloorAction) Action(m services.Engine, person *core.Actor, position geometry.Point) {
+	currentMap := m.GetGame().GetMap()
+	links := currentMap.FloorLinksAt(position)
+	if len(links) == 1 {
+		changeFloor(m, person, links[0])
+		return
+	}
+	menuItems := make([]services.MenuItem, 0, len(links))
+	for _, link := range links {
+		target := link
+		menuItems = append(menuItems, services.MenuItem{
+			Label:   currentMap.FloorAt(target).Name,
+			Handler: func() { changeFloor(m, person, target) },
+		})
+	}
+	m.GetUI().OpenFixedWidthAutoCloseMenu("Go to", menuItems)
+}
+
+func changeFloor(m services.Engine, person *core.Actor, target geometry.Point) {
+	game := m.GetGame()
+	if game.GetMap().IsActorAt(target) {
+		game.PrintMessage("Someone is in the way.")
+		return
+	}
+	game.MoveActor(person, target)
+	game.PrintMessage("You are now on " + game.GetMap().FloorAt(target).Name + ".")
+}
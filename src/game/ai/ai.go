@@ -458,10 +458,29 @@ func (a *AIController) TryContextActionAtTaskLocation(person *core.Actor, finish
 			a.ConsumeFoodAt(person, neighbor, finishedCallback)
 			return true
 		}
+		if riggable, ok := currentMap.ObjectAt(neighbor).(services.Riggable); ok {
+			a.UseApplianceAt(person, riggable, finishedCallback)
+			return true
+		}
 	}
 	return false
 }
 
+// UseApplianceAt lets the person use an appliance for a while.
+// A rigged appliance goes off when the person is done.
+func (a *AIController) UseApplianceAt(person *core.Actor, appliance services.Riggable, finishedCallback func()) {
+	usageCompleted := false
+	until := func() bool { return usageCompleted }
+	a.SetEngrossed(person, until)
+	a.engine.ScheduleGameTime(appliance.UseDurationInSeconds(), func() {
+		usageCompleted = true
+		if person.IsAlive() && !person.IsDowned() {
+			appliance.UseAppliance(a.engine, person)
+		}
+		finishedCallback()
+	})
+}
+
 func (a *AIController) ConsumeFoodAt(person *core.Actor, foodPos geometry.Point, finishedCallback func()) {
 	animator := a.engine.GetAnimator()
 	game := a.engine.GetGame()
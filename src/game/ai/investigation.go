@@ -8,11 +8,17 @@ import (
 	"github.com/memmaker/terminal-assassin/geometry"
 )
 
+// maxFollowedBloodDrops limits how far investigators follow a blood trail.
+const maxFollowedBloodDrops = 40
+
 type InvestigationMovement struct {
 	AIContext
 	LookAroundCounter   int
 	Incident            core.IncidentReport
 	ReactionTimeAwaited bool
+	// trailPosition is the last drop of blood reached while following a trail
+	trailPosition geometry.Point
+	followedDrops int
 }
 
 func (i *InvestigationMovement) Status() core.ActorState { return core.ActorStatusInvestigating }
@@ -32,7 +38,15 @@ func (i *InvestigationMovement) NextAction() core.AIUpdate {
 			return NextUpdateIn(0.3)
 		}
 	}
-	return person.AI.Movement.Action(i.Incident.Location, i)
+	return person.AI.Movement.Action(i.destination(), i)
+}
+
+// destination is the incident location, or the end of the blood trail that has been followed from there.
+func (i *InvestigationMovement) destination() geometry.Point {
+	if i.followedDrops > 0 {
+		return i.trailPosition
+	}
+	return i.Incident.Location
 }
 
 func (i *InvestigationMovement) OnDestinationReached() core.AIUpdate {
@@ -40,6 +54,14 @@ func (i *InvestigationMovement) OnDestinationReached() core.AIUpdate {
 	engine := i.AIContext.Engine
 	aic := engine.GetAI()
 
+	if i.Incident.Type == core.ObservationBloodFound && i.followedDrops < maxFollowedBloodDrops {
+		if next, found := engine.GetGame().GetMap().NextBloodDrop(i.destination()); found {
+			i.trailPosition = next
+			i.followedDrops++
+			return person.AI.Movement.Action(next, i)
+		}
+	}
+
 	person.TurnLeft(45)
 	aic.UpdateVision(person)
 	i.LookAroundCounter++
@@ -0,0 +1,12 @@
+package ai
+
+import "github.com/memmaker/terminal-assassin/game/core"
+
+// SlippedState is pushed onto the AI stack when an NPC slips on a liquid.
+// Like SleepingState it does nothing, the actor gets up again when it is popped.
+type SlippedState struct {
+	AIContext
+}
+
+func (s *SlippedState) NextAction() core.AIUpdate { return core.AIUpdate{DelayInSeconds: 10} }
+func (s *SlippedState) Status() core.ActorState   { return core.ActorStatusSlipped }
@@ -3,8 +3,6 @@ package game
 import (
 	"path"
 
-	"github.com/memmaker/terminal-assassin/rng"
-
 	"github.com/hajimehoshi/ebiten/v2"
 
 	"github.com/memmaker/terminal-assassin/common"
@@ -688,68 +686,41 @@ func (a *Animator) LiquidDistribution(location geometry.Point, source core.Effec
 	a.addAnimation(animation)
 }
 
-// GasDistribution deploys a gas cloud that marks all reachable tiles within radius
-// with the given stimulus. Any actor already on a covered tile is affected immediately;
-// any actor who later walks into the cloud is affected via the normal ReEmitStimuliOnTileToThings
-// hook in ActorEnteredCell. Each tile dissipates independently at a randomised time so
-// the cloud dissolves gradually rather than all at once.
+// GasDistribution releases a gas cloud that fills all reachable tiles within radius
+// with the given stimuli. Any actor already on a covered tile is affected immediately.
+// Afterwards the gas simulation lets the cloud drift through open doors and thin out
+// within about durationSecs, affecting whoever it reaches on the way.
 func (a *Animator) GasDistribution(location geometry.Point, source core.EffectSource, stims []stimuli.Stimulus, radius int, durationSecs int) {
 	game := a.engine.GetGame()
 	gmap := game.GetMap()
 
-	// Collect tiles grouped by wave distance so outer tiles can bias towards
-	// clearing sooner, giving a natural outward-dissipation feel.
-	waveMap := gmap.WavePropagationFrom(location, radius, 0)
-
 	directEffect := stimuli.StimEffect{Stimuli: stims}
-
-	base := float64(durationSecs)
-	// Jitter window: each tile's removal is scheduled anywhere between
-	// 0.5× and 1.5× the base duration.
-	jitter := base * 0.5
-
-	scheduleTileRemoval := func(p geometry.Point, distanceBias float64) {
-		// distanceBias nudges outer tiles to clear sooner (max −25% at full radius).
-		delay := base - distanceBias + (rng.R.Float64()*jitter*2 - jitter)
-		if delay < base*0.25 {
-			delay = base * 0.25 // never clear in less than a quarter of base time
-		}
-		a.engine.Schedule(delay, func() {
-			for _, s := range stims {
-				gmap.RemoveStimulusFromTile(p, s.Type())
-			}
-		})
-	}
-
-	// Apply gas to the origin tile.
+	covered := make([]geometry.Point, 0)
+	isCovered := make(map[geometry.Point]bool)
 	for _, s := range stims {
-		gmap.AddStimulusToTile(location, s)
-	}
-	game.Apply(location, source, directEffect)
-	scheduleTileRemoval(location, 0)
-
-	// Apply gas to all wave tiles; outer tiles get a small bias to clear first.
-	maxBias := base * 0.25
-	for dist, tiles := range waveMap {
-		distanceBias := float64(dist) / float64(radius) * maxBias
-		for _, p := range tiles {
-			for _, s := range stims {
-				gmap.AddStimulusToTile(p, s)
+		for _, p := range gmap.ReleaseGas(location, s, radius, float64(durationSecs)) {
+			if !isCovered[p] {
+				isCovered[p] = true
+				covered = append(covered, p)
 			}
-			game.Apply(p, source, directEffect)
-			scheduleTileRemoval(p, distanceBias)
 		}
 	}
+	for _, p := range covered {
+		game.Apply(p, source, directEffect)
+	}
 }
 
 func (a *Animator) BriefingAnimation(script *core.BriefingAnimation, finish func()) {
 	audio := a.engine.GetAudio()
 	files := a.engine.GetFiles()
-	currentMap := a.engine.GetGame().GetMap()
+	assetPath := script.AssetPath
+	if assetPath == "" {
+		assetPath = path.Join(a.engine.GetGame().GetMap().MapFileName(), "briefing")
+	}
 	audioFileList := make([]string, 0)
 	for _, slide := range script.Slides {
 		if slide.AudioFile != "" {
-			audioFilePath := path.Join(currentMap.MapFileName(), "briefing", slide.AudioFile+".ogg")
+			audioFilePath := path.Join(assetPath, slide.AudioFile+".ogg")
 			audioFileList = append(audioFileList, audioFilePath)
 		}
 	}
@@ -769,9 +740,10 @@ func (a *Animator) BriefingAnimation(script *core.BriefingAnimation, finish func
 		currentFrame := script.Slides[frameIndex]
 
 		// draw image
-		imagePath := path.Join(currentMap.MapFileName(), "briefing", currentFrame.ImageFile+".cmg")
-		image := utils.LoadCellImageFromDisk(files, imagePath)
-		image.DrawCentered(con)
+		imagePath := path.Join(assetPath, currentFrame.ImageFile+".cmg")
+		if image := utils.LoadCellImageFromDisk(files, imagePath); image != nil {
+			image.DrawCentered(con)
+		}
 
 		if frameIndex == 0 {
 			core.DrawStyledTextAlignedInsideRect(con, geometry.NewRect(0, 1, gridWidth*2, 2), core.AlignCenter, currentFrame.Text)
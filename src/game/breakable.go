@@ -0,0 +1,113 @@
+package game
+
+import (
+	"github.com/memmaker/terminal-assassin/game/core"
+	"github.com/memmaker/terminal-assassin/game/stimuli"
+	"github.com/memmaker/terminal-assassin/geometry"
+	"github.com/memmaker/terminal-assassin/gridmap"
+)
+
+const (
+	tileBreakNoiseRadius = 10
+	fallDamage           = 50
+)
+
+// DamageTile breaks glass and other breakable tiles once their health is used up.
+func (m *Model) DamageTile(p geometry.Point, force int) {
+	if m.GetMap().DamageTileAt(p, force) {
+		m.breakTile(p)
+	}
+}
+
+// breakTile replaces the tile at p with its broken version. Breaking is loud
+// and everything on a glass floor falls down to the floor below.
+func (m *Model) breakTile(p geometry.Point) {
+	currentMap := m.GetMap()
+	data := m.engine.GetData()
+	tile := currentMap.GetCell(p).TileType
+	broken := data.GroundTile()
+	if tile.BreaksInto != 0 {
+		broken = data.TileFromIcon(tile.BreaksInto)
+	}
+	currentMap.SetTile(p, broken)
+	m.SoundEventAt(p, core.ObservationStrangeNoiseHeard, tileBreakNoiseRadius)
+	if tile.Special == gridmap.SpecialTileGlassFloor {
+		m.dropThroughFloor(p, tile)
+	}
+	m.UpdateAllFoVsFrom(p)
+}
+
+// dropThroughFloor moves the item and the persons at p to the nearest named
+// location below. Persons other than the player are knocked out by the fall.
+// Without such a location everything stays where it is.
+func (m *Model) dropThroughFloor(p geometry.Point, floor gridmap.Tile) {
+	currentMap := m.GetMap()
+	target, hasTarget := currentMap.FallTargetFor(p)
+	if !hasTarget {
+		return
+	}
+	source := core.NewEffectSourceFromTile(floor)
+	if currentMap.IsItemAt(p) {
+		item := currentMap.ItemAt(p)
+		isFree := func(q geometry.Point) bool { return currentMap.IsTileWalkable(q) && !currentMap.IsItemAt(q) }
+		if landing, found := m.freeCellNear(target, isFree); found {
+			m.MoveItemTo(landing, item)
+		}
+	}
+	if currentMap.IsActorAt(p) {
+		person := currentMap.ActorAt(p)
+		if landing, found := m.freeCellNear(target, currentMap.IsCurrentlyPassable); found {
+			m.MoveActor(person, landing)
+			if person != currentMap.Player {
+				m.TakeBluntDamage(person, source, fallDamage)
+			}
+		}
+	}
+	if currentMap.IsDownedActorAt(p) {
+		body := currentMap.DownedActorAt(p)
+		isFree := func(q geometry.Point) bool { return currentMap.IsTileWalkable(q) && !currentMap.IsDownedActorAt(q) }
+		if landing, found := m.freeCellNear(target, isFree); found {
+			m.MoveActor(body, landing)
+		}
+	}
+}
+
+// freeCellNear searches the square rings around center for the nearest cell that is free.
+func (m *Model) freeCellNear(center geometry.Point, isFree func(geometry.Point) bool) (geometry.Point, bool) {
+	const maxRadius = 5
+	for radius := 0; radius <= maxRadius; radius++ {
+		for y := center.Y - radius; y <= center.Y+radius; y++ {
+			for x := center.X - radius; x <= center.X+radius; x++ {
+				p := geometry.Point{X: x, Y: y}
+				if geometry.DistanceChebyshev(p, center) == radius && m.GetMap().Contains(p) && isFree(p) {
+					return p, true
+				}
+			}
+		}
+	}
+	return center, false
+}
+
+// stepOnShards makes noise when the player walks over broken glass.
+// Sneaking keeps it down, running makes it worse and the Light Feet skill halves it.
+func (m *Model) stepOnShards(person *core.Actor, p geometry.Point) {
+	if person != m.GetMap().Player || person.IsDowned() {
+		return
+	}
+	radius := 4
+	switch person.MovementMode {
+	case core.MovementModeSneaking:
+		radius = 1
+	case core.MovementModeRunning:
+		radius = 7
+	}
+	m.SoundEventAt(p, core.ObservationStrangeNoiseHeard, m.movementNoiseRadius(person, radius))
+}
+
+func isTileDamage(stimType stimuli.StimulusType) bool {
+	switch stimType {
+	case stimuli.StimulusBluntDamage, stimuli.StimulusPiercingDamage, stimuli.StimulusExplosionDamage:
+		return true
+	}
+	return false
+}
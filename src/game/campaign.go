@@ -0,0 +1,38 @@
+package game
+
+import "slices"
+
+// SetCampaignFlags sets the campaign flags the mission starts with. Flags that scripts set during
+// a live mission are queued and only saved to the career when the mission succeeds, replays never queue them.
+func (m *Model) SetCampaignFlags(flags []string, isLive bool) {
+	m.campaignFlags = slices.Clone(flags)
+	m.scriptCampaignFlags = nil
+	m.queuedCampaignFlags = nil
+	m.queueCampaignFlags = isLive
+}
+
+// GetCampaignFlags returns the campaign flags the mission started with.
+func (m *Model) GetCampaignFlags() []string {
+	return m.campaignFlags
+}
+
+// IsCampaignFlagSet is true when the mission started with the flag or a script set it since.
+func (m *Model) IsCampaignFlagSet(flag string) bool {
+	return slices.Contains(m.campaignFlags, flag) || slices.Contains(m.scriptCampaignFlags, flag)
+}
+
+// SetCampaignFlag sets the flag for the rest of the mission and queues it for the career.
+func (m *Model) SetCampaignFlag(flag string) {
+	if m.IsCampaignFlagSet(flag) {
+		return
+	}
+	m.scriptCampaignFlags = append(m.scriptCampaignFlags, flag)
+	if m.queueCampaignFlags {
+		m.queuedCampaignFlags = append(m.queuedCampaignFlags, flag)
+	}
+}
+
+// QueuedCampaignFlags returns the flags scripts set during a live mission.
+func (m *Model) QueuedCampaignFlags() []string {
+	return m.queuedCampaignFlags
+}
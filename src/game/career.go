@@ -1,33 +1,37 @@
 package game
 
 import (
-	"encoding/gob"
-	"os"
-
 	"github.com/memmaker/terminal-assassin/game/services"
 )
 
-func NewCareerFromFile() *services.CareerData {
-	fileReader, fileErr := os.Open("career.gob")
-	if fileErr != nil {
-		println("Error opening career.gob file: ", fileErr.Error())
-		return NewEmptyCareer()
+// LoadCareer loads the profile that was played last. A career.gob from the time
+// before profiles is imported as a profile once, when there are no profiles yet.
+func LoadCareer(lastProfile string) *services.CareerData {
+	profiles := services.ListProfiles()
+	if len(profiles) == 0 {
+		legacyCareer, err := services.ImportLegacyCareer(services.LegacyCareerFile)
+		if err != nil {
+			println("No profiles found and no career to import: ", err.Error())
+			return NewEmptyCareer()
+		}
+		if !services.IsValidProfileName(legacyCareer.PlayerName) {
+			legacyCareer.PlayerName = "agent"
+		}
+		println("Imported " + services.LegacyCareerFile + " as profile " + legacyCareer.PlayerName)
+		legacyCareer.SaveToFile()
+		return legacyCareer
 	}
-	defer fileReader.Close()
-	decoder := gob.NewDecoder(fileReader)
-	careerData := &services.CareerData{}
-	err := decoder.Decode(careerData)
+	if lastProfile == "" {
+		lastProfile = profiles[0]
+	}
+	career, err := services.LoadProfile(lastProfile)
 	if err != nil {
-		println("Error decoding career.gob file: ", err.Error())
+		println("Error loading profile "+lastProfile+": ", err.Error())
 		return NewEmptyCareer()
 	}
-	return careerData
+	return career
 }
 
 func NewEmptyCareer() *services.CareerData {
-	return &services.CareerData{
-		ExperiencePoints: 0,
-		MapStatistics:    make(map[string]*services.MapStatistics),
-		UnlockedSkills:   services.PlayerSkills{DoubleAssassination: true},
-	}
+	return services.NewCareer("")
 }
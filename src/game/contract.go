@@ -0,0 +1,15 @@
+package game
+
+import (
+	"github.com/memmaker/terminal-assassin/game/services"
+)
+
+// SetContract marks the current mission as a generated contract.
+func (m *Model) SetContract(contract *services.Contract) {
+	m.contract = contract
+}
+
+// GetContract returns the generated contract that is played or nil for the missions of the map.
+func (m *Model) GetContract() *services.Contract {
+	return m.contract
+}
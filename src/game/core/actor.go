@@ -26,12 +26,19 @@ type CoDDescription string
 type CauseOfDeath struct {
 	Description CoDDescription
 	Source      EffectSource
+	// Stimulus is the kind of damage that killed, it is empty for deaths that weren't caused by a stimulus.
+	Stimulus stimuli.StimulusType
 }
 
 func (d CauseOfDeath) IsPlayer() bool {
 	return d.Source.Actor != nil && d.Source.Actor.IsPlayer()
 }
 
+// IsAccident is true if the victim died from an accidental source, see EffectSource.IsAccidental.
+func (d CauseOfDeath) IsAccident() bool {
+	return d.Source.IsAccidental()
+}
+
 // IsBodyDisappearing returns false for causes of death where the body (and
 // therefore the inventory) is physically unreachable — e.g. drowned or fallen
 // into a pit.
@@ -82,6 +89,12 @@ const (
 	CoDKatana            CoDDescription = "cut down by %s"
 	CoDPenetrated        CoDDescription = "deadly penetration with %s"
 	CoDFalling           CoDDescription = "fell to his death"
+	CoDGasExplosion      CoDDescription = "died in a gas explosion"
+	CoDSuffocated        CoDDescription = "suffocated"
+	CoDContractSpotted   CoDDescription = "was spotted and broke the contract"
+	CoDContractBodyFound CoDDescription = "let a body be found and broke the contract"
+	CoDContractTimeout   CoDDescription = "ran out of time"
+	CoDContractMethod    CoDDescription = "killed a target against the contract terms"
 )
 
 func NewCauseOfDeath(description CoDDescription, killer *Actor) CauseOfDeath {
@@ -92,7 +105,7 @@ func NewCauseOfDeathFromEnvironment(description CoDDescription, killingTile grid
 	return CauseOfDeath{Description: description, Source: EffectSource{Tile: killingTile}}
 }
 func NewCauseOfDeathFromStim(stim stimuli.StimulusType, source EffectSource) CauseOfDeath {
-	return CauseOfDeath{Description: source.ToCoDFromStim(stim), Source: source}
+	return CauseOfDeath{Description: source.ToCoDFromStim(stim), Source: source, Stimulus: stim}
 }
 
 type ActorState string
@@ -105,6 +118,7 @@ const (
 	ActorStatusEngagedIllegal     ActorState = "engaged illegal"
 	ActorStatusVictimOfEngagement ActorState = "victim of engagement"
 	ActorStatusSleeping           ActorState = "sleeping"
+	ActorStatusSlipped            ActorState = "slipped"
 	ActorStatusInvestigating      ActorState = "investigating"
 	ActorStatusCombat             ActorState = "combat"
 	ActorStatusVomiting           ActorState = "vomiting"
@@ -164,6 +178,7 @@ type Actor struct {
 	Fov              *geometry.FOV    // field of vision
 	FovMode          gridmap.FoVMode  // field of vision mode
 	FoVShift         geometry.Point   // field of vision shift for peeking
+	PeekDistance     int              // tiles the vision is shifted in the FoVShift direction, one if zero
 	InteractionShift geometry.Point   // shift to apply to position for interactions (talking, using items, etc)
 	Name             string
 	EquippedItem     *Item
@@ -172,6 +187,7 @@ type Actor struct {
 	AutoMoveSpeed    int
 	Dead             bool
 	Sleeping         bool // player only; NPCs use SleepingState on the AI stack
+	Slipped          bool // player only; NPCs use SlippedState on the AI stack
 	IsInCloset       bool
 	Engrossed        bool
 	Health           int
@@ -423,11 +439,11 @@ func (a *Actor) IsDead() bool          { return a.Dead }
 func (a *Actor) IsAlive() bool         { return !a.Dead }
 
 func (a *Actor) IsActive() bool {
-	return !a.Dead && !a.IsSleeping()
+	return !a.Dead && !a.IsSleeping() && !a.IsSlipped()
 }
 
 func (a *Actor) IsDowned() bool {
-	return a.Dead || a.IsSleeping()
+	return a.Dead || a.IsSleeping() || a.IsSlipped()
 }
 
 func (a *Actor) HasIllegalItemEquipped() bool {
@@ -462,6 +478,15 @@ func (a *Actor) FoVSource() geometry.Point {
 	return a.Pos().Add(a.FoVShift)
 }
 
+// VisionSource returns the point from which the field of vision is calculated,
+// it is further away than FoVSource when the actor peeks more than one tile.
+func (a *Actor) VisionSource() geometry.Point {
+	if a.PeekDistance <= 1 {
+		return a.FoVSource()
+	}
+	return a.Pos().Add(a.FoVShift.Mul(a.PeekDistance))
+}
+
 // InteractSource returns the point from which this actor's interactions (talking, using items, etc) should be calculated.
 // Can be different from FoVSource if, for example, the actor is right next to a wall.
 func (a *Actor) InteractSource() geometry.Point {
@@ -503,6 +528,9 @@ func (a *Actor) Symbol() rune {
 	if a.Dead {
 		return GlyphCorpse
 	}
+	if a.IsSlipped() {
+		return GlyphSlipped
+	}
 	if a.IsFence() {
 		return 'F'
 	}
@@ -597,25 +625,27 @@ func (a *Actor) TryRespondToSpeech(currentTick uint64, speechCode string) {
 	}
 }
 func (a *Actor) canSeeInScope(p geometry.Point) bool {
-	if a.EquippedItem == nil || !a.EquippedItem.Type.HasScope() {
+	if a.EquippedItem == nil || !a.EquippedItem.HasScope() {
 		return false
 	}
-	left, right := geometry.GetLeftAndRightBorderOfVisionCone(a.FoVSource(), a.LookDirection, a.EquippedItem.Type.ScopeFoV())
-	inCone := geometry.InVisionCone(a.FoVSource(), p, left, right)
+	left, right := geometry.GetLeftAndRightBorderOfVisionCone(a.VisionSource(), a.LookDirection, a.EquippedItem.ScopeFoV())
+	inCone := geometry.InVisionCone(a.VisionSource(), p, left, right)
 	visible := a.Fov.Visible(p)
 	return inCone && visible
 }
 
+// CanSeeInVisionCone is true if p is within the vision cone and the field of view.
+// Smoke thins the field of view, see GridMap.UpdateFieldOfView.
 func (a *Actor) CanSeeInVisionCone(p geometry.Point) bool {
-	left, right := geometry.GetLeftAndRightBorderOfVisionCone(a.FoVSource(), a.LookDirection, a.FoVinDegrees)
-	inCone := geometry.InVisionCone(a.FoVSource(), p, left, right)
+	left, right := geometry.GetLeftAndRightBorderOfVisionCone(a.VisionSource(), a.LookDirection, a.FoVinDegrees)
+	inCone := geometry.InVisionCone(a.VisionSource(), p, left, right)
 	visible := a.Fov.Visible(p)
 	return visible && inCone
 }
 func (a *Actor) VisionCone(f func(p geometry.Point)) {
-	left, right := geometry.GetLeftAndRightBorderOfVisionCone(a.FoVSource(), a.LookDirection, a.FoVinDegrees)
+	left, right := geometry.GetLeftAndRightBorderOfVisionCone(a.VisionSource(), a.LookDirection, a.FoVinDegrees)
 	a.Fov.IterSSC(func(p geometry.Point) {
-		if geometry.DistanceSquared(a.FoVSource(), p) <= (a.VisionRange()*a.VisionRange()) && geometry.InVisionCone(a.FoVSource(), p, left, right) {
+		if geometry.DistanceSquared(a.VisionSource(), p) <= (a.VisionRange()*a.VisionRange()) && geometry.InVisionCone(a.VisionSource(), p, left, right) {
 			f(p)
 		}
 	})
@@ -908,7 +938,7 @@ func (a *Actor) HasScopedItemEquipped() bool {
 	if a.EquippedItem == nil {
 		return false
 	}
-	return a.EquippedItem.Type.HasScope()
+	return a.EquippedItem.HasScope()
 }
 
 func (a *Actor) DiesFromDamage(damage int) bool {
@@ -960,6 +990,19 @@ func (a *Actor) IsSleeping() bool {
 	return a.Sleeping || a.Status() == ActorStatusSleeping
 }
 
+// IsSlipped returns true while this actor lies on the floor after slipping on a liquid.
+func (a *Actor) IsSlipped() bool {
+	return a.Slipped || a.Status() == ActorStatusSlipped
+}
+
+// IsRunning returns true when the player runs or an NPC moves at running speed.
+func (a *Actor) IsRunning() bool {
+	if a.AI != nil {
+		return a.MoveDelay() == MoveDelayRunning
+	}
+	return a.MovementMode == MovementModeRunning
+}
+
 func (a *Actor) GetTeam() string {
 	return a.Team
 }
@@ -0,0 +1,130 @@
+package core
+
+import "github.com/memmaker/terminal-assassin/game/stimuli"
+
+// AttachmentSlot is the place on a firearm that a part is fitted to.
+// Every slot holds one part at most.
+type AttachmentSlot string
+
+const (
+	AttachmentSlotNone     AttachmentSlot = ""
+	AttachmentSlotMuzzle   AttachmentSlot = "muzzle"
+	AttachmentSlotOptic    AttachmentSlot = "optic"
+	AttachmentSlotMagazine AttachmentSlot = "magazine"
+	AttachmentSlotAmmo     AttachmentSlot = "ammo"
+)
+
+// weaponStock remembers what a firearm was like before any part was fitted.
+type weaponStock struct {
+	noiseRadius    int
+	audioCue       string
+	triggerEffects map[ItemEffectTrigger]stimuli.StimEffect
+}
+
+// IsWeaponPart is true for attachments and ammo, which can be fitted to firearms.
+func (i *Item) IsWeaponPart() bool {
+	return i.Type == ItemTypeAttachment || i.Type == ItemTypeAmmo
+}
+
+// Slot returns the slot the part is fitted to. Ammo always goes into the ammo slot.
+func (i *Item) Slot() AttachmentSlot {
+	if i.Type == ItemTypeAmmo {
+		return AttachmentSlotAmmo
+	}
+	return i.AttachmentSlot
+}
+
+// Fits is true if the part can be fitted to the weapon.
+func (i *Item) Fits(weapon *Item) bool {
+	if !i.IsWeaponPart() || !weapon.IsRangedWeapon() {
+		return false
+	}
+	for _, t := range i.FitsTypes {
+		if t == weapon.Type {
+			return true
+		}
+	}
+	return false
+}
+
+// FittedPart returns the part in the given slot or nil.
+func (i *Item) FittedPart(slot AttachmentSlot) *Item {
+	for _, part := range i.Attachments {
+		if part.Slot() == slot {
+			return part
+		}
+	}
+	return nil
+}
+
+// Fit puts the part on the weapon. A part that was in the same slot before is
+// taken off and returned.
+func (i *Item) Fit(part *Item) *Item {
+	if i.stock == nil {
+		i.stock = &weaponStock{noiseRadius: i.NoiseRadius, audioCue: i.AudioCue, triggerEffects: i.TriggerEffects}
+	}
+	replaced := i.FittedPart(part.Slot())
+	if replaced != nil {
+		i.Unfit(replaced)
+	}
+	part.HeldBy = nil
+	i.Attachments = append(i.Attachments, part)
+	if part.Slot() == AttachmentSlotMagazine && i.Uses != UnlimitedUses {
+		i.Uses += part.ExtraUses
+	}
+	i.refit()
+	return replaced
+}
+
+// Unfit takes the part off the weapon. Rounds that were in an extended magazine go with it.
+func (i *Item) Unfit(part *Item) bool {
+	for index, fitted := range i.Attachments {
+		if fitted != part {
+			continue
+		}
+		i.Attachments = append(i.Attachments[:index], i.Attachments[index+1:]...)
+		if part.Slot() == AttachmentSlotMagazine && i.Uses != UnlimitedUses {
+			i.Uses = max(0, i.Uses-part.ExtraUses)
+		}
+		i.refit()
+		return true
+	}
+	return false
+}
+
+// refit derives noise, sound and the effect of a shot from the stock weapon and its parts.
+// Silencers can only lower the noise, ammo replaces the trigger effects.
+func (i *Item) refit() {
+	if i.stock == nil {
+		return
+	}
+	i.NoiseRadius = i.stock.noiseRadius
+	i.AudioCue = i.stock.audioCue
+	i.TriggerEffects = i.stock.triggerEffects
+	for _, part := range i.Attachments {
+		switch part.Slot() {
+		case AttachmentSlotMuzzle:
+			i.NoiseRadius = min(i.NoiseRadius, part.NoiseRadius)
+			if part.AudioCue != "" {
+				i.AudioCue = part.AudioCue
+			}
+		case AttachmentSlotAmmo:
+			i.TriggerEffects = part.TriggerEffects
+		}
+	}
+}
+
+// HasScope returns true when equipping the item activates scoped mode,
+// either because of its type or because of a fitted optic.
+func (i *Item) HasScope() bool {
+	return i.ScopeFoV() > 0
+}
+
+// ScopeFoV returns the vision cone angle in degrees used in scoped mode.
+// A fitted optic takes precedence over the scope of the item type.
+func (i *Item) ScopeFoV() float64 {
+	if optic := i.FittedPart(AttachmentSlotOptic); optic != nil && optic.ScopeDegrees > 0 {
+		return optic.ScopeDegrees
+	}
+	return i.Type.ScopeFoV()
+}
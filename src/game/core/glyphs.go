@@ -91,4 +91,7 @@ const (
 	GlyphAlarm                = '!'
 	GlyphKatana               = 'Ɨ'
 	GlyphFourPointStar        = 'ж'
+	GlyphStairs               = '>'
+	GlyphElevator             = '#'
+	GlyphSlipped              = '_'
 )
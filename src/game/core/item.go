@@ -3,6 +3,7 @@ package core
 import (
     "fmt"
     "strconv"
+    "strings"
 
     "github.com/memmaker/terminal-assassin/common"
     "github.com/memmaker/terminal-assassin/game/stimuli"
@@ -55,6 +56,8 @@ var itemTypeNames = map[ItemType]string{
     ItemTypeShovel:              "shovel",
     ItemTypeFlashlight:          "flashlight",
     ItemTypeBow:                 "bow",
+    ItemTypeAttachment:          "attachment",
+    ItemTypeAmmo:                "ammo",
 }
 
 var itemTypeByName map[string]ItemType
@@ -121,6 +124,8 @@ const (
     ItemTypeShovel
     ItemTypeFlashlight
     ItemTypeBow
+    ItemTypeAttachment
+    ItemTypeAmmo
 )
 
 // itemTypeTraits holds every per-type capability flag and value in one place.
@@ -178,8 +183,8 @@ var itemTraitsTable = map[ItemType]itemTypeTraits{
     ItemTypeFlashlight:          {hasMeleeAction: true, scopeFoV: 60.0},
     ItemTypeBow:                 {hasRangedAction: true, isRangedWeapon: true, cooldownSecs: 1.5},
     // ItemTypeMechanicalLockpick, ItemTypeElectronicLockpick, ItemTypeClothing,
-    // ItemTypeKey, ItemTypeKeyCard, ItemTypeMessage, ItemTypeCamera, ItemTypeShovel
-    // use all-false / zero defaults.
+    // ItemTypeKey, ItemTypeKeyCard, ItemTypeMessage, ItemTypeCamera, ItemTypeShovel,
+    // ItemTypeAttachment, ItemTypeAmmo use all-false / zero defaults.
 }
 
 // HasMeleeAction returns true when the item can be used at melee range.
@@ -365,6 +370,17 @@ type Item struct {
     NoiseRadius     int
     StartPosition   geometry.Point
     LootValue       int
+
+    // FitsTypes lists the firearms an attachment or ammo can be fitted to.
+    FitsTypes      []ItemType
+    AttachmentSlot AttachmentSlot
+    // ScopeDegrees is the vision cone of a scope attachment.
+    ScopeDegrees float64
+    // ExtraUses are the additional rounds of an extended magazine.
+    ExtraUses int
+    // Attachments are the parts fitted to a firearm, see Fit.
+    Attachments []*Item
+    stock       *weaponStock
 }
 
 func (i *Item) Icon() rune {
@@ -380,6 +396,13 @@ func (i *Item) Description() string {
     if (i.Type == ItemTypeKey || i.Type == ItemTypeKeyCard) && i.KeyString != "" {
         return fmt.Sprintf("%s (%s)", i.Name, i.KeyString)
     }
+    if len(i.Attachments) > 0 {
+        partNames := make([]string, len(i.Attachments))
+        for index, part := range i.Attachments {
+            partNames[index] = part.Name
+        }
+        return fmt.Sprintf("%s [%s]", i.Name, strings.Join(partNames, ", "))
+    }
     return i.Name
 }
 
@@ -451,6 +474,10 @@ func (i *Item) DeepCopy() *Item {
     for k, v := range i.ReactionEffects {
         newItem.ReactionEffects[k] = v
     }
+    newItem.Attachments = make([]*Item, len(i.Attachments))
+    for index, part := range i.Attachments {
+        newItem.Attachments[index] = part.DeepCopy()
+    }
     newItem.InsteadOfUse = i.InsteadOfUse
     newItem.InsteadOfPickup = i.InsteadOfPickup
     newItem.HeldBy = i.HeldBy
@@ -520,6 +547,22 @@ func (i *Item) ToRecord() []rec_files.Field {
     if i.KeyString != "" {
         fields = append(fields, rec_files.Field{Name: "key_string", Value: i.KeyString})
     }
+    if len(i.FitsTypes) > 0 {
+        typeNames := make([]string, len(i.FitsTypes))
+        for index, t := range i.FitsTypes {
+            typeNames[index] = t.ToString()
+        }
+        fields = append(fields, rec_files.Field{Name: "fits", Value: strings.Join(typeNames, ", ")})
+    }
+    if i.AttachmentSlot != AttachmentSlotNone {
+        fields = append(fields, rec_files.Field{Name: "slot", Value: string(i.AttachmentSlot)})
+    }
+    if i.ScopeDegrees > 0 {
+        fields = append(fields, rec_files.Field{Name: "scope_fov", Value: strconv.FormatFloat(i.ScopeDegrees, 'f', -1, 64)})
+    }
+    if i.ExtraUses != 0 {
+        fields = append(fields, rec_files.Field{Name: "extra_uses", Value: strconv.Itoa(i.ExtraUses)})
+    }
     return fields
 }
 
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/memmaker/terminal-assassin/common"
+	"github.com/memmaker/terminal-assassin/game/stimuli"
 	rec_files "github.com/memmaker/terminal-assassin/rec-files"
 
 	"github.com/memmaker/terminal-assassin/geometry"
@@ -63,6 +64,17 @@ type MissionStats struct {
 	BodiesFound    bool
 	BeenSpotted    bool
 	AlarmTriggered bool
+
+	BodiesHidden    int
+	ItemsThrown     int
+	DoorsLockpicked int
+	Knockouts       int
+	// DistanceWalked is the distance the player covered on foot, in cells.
+	DistanceWalked float64
+	// SecondsInSight is the time the player spent in the vision cone of any NPC.
+	SecondsInSight float64
+	// NPCsAlerted counts the NPCs that saw something dangerous during the mission.
+	NPCsAlerted int
 }
 
 func NewMissionStats() *MissionStats {
@@ -121,6 +133,25 @@ func (m *MissionStats) PlayerKilledTargetsWithSniperOnly() bool {
     return true
 }
 
+// AccidentalKills counts the kills that were accidents. An empty stimulus type counts all of them.
+func (m *MissionStats) AccidentalKills(stimulus stimuli.StimulusType) int {
+    count := 0
+    for _, kill := range m.Kills {
+        if !kill.CauseOfDeath.IsAccident() {
+            continue
+        }
+        if stimulus == "" || kill.CauseOfDeath.Stimulus == stimulus {
+            count++
+        }
+    }
+    return count
+}
+
+// OnlyAccidents is true if there were kills and all of them were accidents.
+func (m *MissionStats) OnlyAccidents() bool {
+    return len(m.Kills) > 0 && m.AccidentalKills("") == len(m.Kills)
+}
+
 func (m *MissionStats) MissionDuration() time.Duration {
     return time.Duration(m.SecondsNeeded*1000) * time.Millisecond
 }
@@ -148,4 +179,11 @@ func (m *MissionStats) StartMission() {
     m.BeenSpotted = false
     m.AlarmTriggered = false
     m.Kills = []KillStatistics{}
+    m.BodiesHidden = 0
+    m.ItemsThrown = 0
+    m.DoorsLockpicked = 0
+    m.Knockouts = 0
+    m.DistanceWalked = 0
+    m.SecondsInSight = 0
+    m.NPCsAlerted = 0
 }
@@ -407,6 +407,8 @@ func DrawStyledTextAlignedInsideRect(con console.CellInterface, bounds geometry.
 
 type BriefingAnimation struct {
     Slides []BriefingSlide
+    // AssetPath is the folder of the audio and image files, the briefing folder of the current map if empty.
+    AssetPath string
 }
 type BriefingSlide struct {
     Text      []StyledText
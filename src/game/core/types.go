@@ -222,7 +222,34 @@ func (s EffectSource) WithItem(newItem *Item) EffectSource {
 	return s
 }
 
+// AccidentalObject is implemented by objects whose harm should look like an
+// accident, e.g. a rigged gas stove.
+type AccidentalObject interface {
+	IsAccidental() bool
+}
+
+// IsAccidental is true if the effect originates from an accidental object
+// or a deadly tile and nobody can be blamed for it directly.
+func (s EffectSource) IsAccidental() bool {
+	if s.Actor != nil {
+		return false
+	}
+	if s.Tile.IsLethal() {
+		return true
+	}
+	accidental, ok := s.Object.(AccidentalObject)
+	return ok && accidental.IsAccidental()
+}
+
 func (s EffectSource) ToCoDFromStim(sType stimuli.StimulusType) CoDDescription {
+	if s.IsAccidental() {
+		switch sType {
+		case stimuli.StimulusExplosionDamage:
+			return CoDGasExplosion
+		case stimuli.StimulusLethal:
+			return CoDSuffocated
+		}
+	}
 	switch sType {
 	case stimuli.StimulusPiercingDamage:
 		return s.ToCoDFromPiercingDamage()
@@ -234,6 +261,8 @@ func (s EffectSource) ToCoDFromStim(sType stimuli.StimulusType) CoDDescription {
 		return CodExploded
 	case stimuli.StimulusLethal:
 		return CoDPoisoned
+	case stimuli.StimulusHighVoltage:
+		return CoDElectrocuted
 	}
 	return CoDDescription(fmt.Sprintf("killed by %s under mysterious circumstances", sType))
 }
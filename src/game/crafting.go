@@ -0,0 +1,67 @@
+package game
+
+import (
+	"github.com/memmaker/terminal-assassin/game/core"
+	"github.com/memmaker/terminal-assassin/game/services"
+)
+
+// CombineItem crafts something from the item and other items in the inventory,
+// or fits weapon parts to firearms. If there are several options, the player picks one.
+func (m *Model) CombineItem(person *core.Actor, item *core.Item) {
+	menuItems := m.weaponPartOptions(person, item)
+	for _, r := range m.engine.GetData().Recipes() {
+		recipe := r
+		if _, hasInputs := recipe.InputsFrom(person.Inventory.Items); hasInputs && recipe.Uses(item) {
+			menuItems = append(menuItems, services.MenuItem{
+				Label:   recipe.Name,
+				Handler: func() { m.craft(person, recipe) },
+			})
+		}
+	}
+	switch len(menuItems) {
+	case 0:
+		m.PrintMessage("Nothing to combine the " + item.Name + " with.")
+	case 1:
+		menuItems[0].Handler()
+	default:
+		m.engine.GetUI().OpenFixedWidthAutoCloseMenu("Combine", menuItems)
+	}
+}
+
+// craft keeps the person busy for the crafting time. The inputs are only consumed
+// when the crafting is finished and still possible.
+func (m *Model) craft(person *core.Actor, recipe services.RecipeDefinition) {
+	done := false
+	m.engine.GetAI().SetEngrossed(person, func() bool { return done })
+	m.engine.GetAnimator().ActorEngagedAnimationWithCancel(person, core.GlyphWrench, person.Pos(), recipe.CraftingSeconds, func() {
+		done = true
+		inputs, hasInputs := recipe.InputsFrom(person.Inventory.Items)
+		if !hasInputs {
+			m.PrintMessage("You no longer have everything for the " + recipe.Name + ".")
+			return
+		}
+		for _, input := range inputs {
+			consumeCraftingInput(person, input)
+		}
+		output := services.NewFactory(m.engine).DecodeStringToItem(recipe.Output)
+		output.HeldBy = person
+		person.Inventory.AddItem(&output)
+		person.EquippedItem = &output
+		m.PrintMessage("You crafted the " + output.Name + ".")
+		m.UpdateHUD()
+	}, func() {
+		done = true
+	})
+}
+
+// consumeCraftingInput uses up one lockpick from a set of picks, all other inputs are used completely.
+func consumeCraftingInput(person *core.Actor, item *core.Item) {
+	if item.Type == core.ItemTypeMechanicalLockpick || item.Type == core.ItemTypeElectronicLockpick {
+		person.ConsumeItemsFromInventory(item.Type, 1)
+		if !person.Inventory.Contains(item) && person.EquippedItem == item {
+			person.EquippedItem = nil
+		}
+		return
+	}
+	person.RemoveItem(item)
+}
@@ -0,0 +1,647 @@
+package director
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/memmaker/terminal-assassin/game/core"
+	"github.com/memmaker/terminal-assassin/mapset"
+)
+
+// The documents in this file are a structured, editable view of the script and
+// dialogue text formats. Unlike ScriptParser and DialogueParser they do not
+// resolve anything against a running game, so they can be loaded, validated and
+// written back from the map editor.
+
+type ConditionMode int
+
+const (
+	ConditionsAnd ConditionMode = iota
+	ConditionsOr
+)
+
+func (m ConditionMode) ToString() string {
+	if m == ConditionsOr {
+		return "OR"
+	}
+	return "AND"
+}
+
+// Call is a single function call line, eg. "IsDowned($RD_LEADER)".
+type Call struct {
+	Name string
+	Args []string
+	// Comments are the comment and empty lines above the call, they are written back unchanged.
+	Comments []string
+}
+
+func NewCallFromLine(line string) Call {
+	name, args := core.GetNameAndArgs(strings.TrimSpace(line))
+	return Call{Name: name, Args: args}
+}
+
+func (c Call) String() string {
+	return fmt.Sprintf("%s(%s)", c.Name, strings.Join(c.Args, ", "))
+}
+
+// Assignment is a variable definition, eg. "$RD_LEADER = ActorWithName(Red Dragon Leader)".
+// Literal assignments ("$DELAY = 5") have an empty Call.Name and the value in Literal.
+type Assignment struct {
+	Variable string
+	Call     Call
+	Literal  string
+	// Comments are the comment and empty lines above the assignment, they are written back unchanged.
+	Comments []string
+}
+
+func NewAssignmentFromLine(line string) Assignment {
+	variable, value, _ := strings.Cut(line, "=")
+	result := Assignment{Variable: strings.TrimSpace(variable)}
+	value = strings.TrimSpace(value)
+	if core.LooksLikeAFunction(value) {
+		result.Call = NewCallFromLine(value)
+	} else {
+		result.Literal = value
+	}
+	return result
+}
+
+func (a Assignment) IsLiteral() bool {
+	return a.Call.Name == ""
+}
+
+func (a Assignment) String() string {
+	if a.IsLiteral() {
+		return fmt.Sprintf("%s = %s", a.Variable, a.Literal)
+	}
+	return fmt.Sprintf("%s = %s", a.Variable, a.Call.String())
+}
+
+// Statement is a line of the actions of a frame, either an action call or an assignment.
+// They are run in order, so an action can use the variables assigned above it.
+type Statement struct {
+	Call Call
+	// Assignment is set for assignments, Call is empty then.
+	Assignment *Assignment
+}
+
+func (s Statement) IsAssignment() bool {
+	return s.Assignment != nil
+}
+
+func (s Statement) String() string {
+	if s.IsAssignment() {
+		return s.Assignment.String()
+	}
+	return s.Call.String()
+}
+
+type FrameDocument struct {
+	// Comments are the comment lines above the "# NEWFRAME" header of the frame.
+	Comments        []string
+	StartMode       ConditionMode
+	StartConditions []Call
+	Actions         []Statement
+}
+
+type ScriptDocument struct {
+	// Comments are the comment lines at the top of the script, TrailingComments those at its end.
+	Comments          []string
+	Definitions       []Assignment
+	TimeoutMode       ConditionMode
+	TimeoutConditions []Call
+	TimeoutActions    []Call
+	Frames            []*FrameDocument
+	TrailingComments  []string
+}
+
+// definitionsHeader is the heading String writes above the definitions, the parsers ignore it.
+const definitionsHeader = "# Definitions"
+
+// ParseScriptDocument reads the same format as ScriptParser.parse. Lines that ScriptParser
+// ignores are kept as comments of the statement below them.
+func ParseScriptDocument(script string) *ScriptDocument {
+	doc := &ScriptDocument{}
+	var currentFrame *FrameDocument
+	state := ReadStatePreamble
+	// comments are the lines since the last statement, afterHeader skips the empty lines below a header
+	comments := make([]string, 0)
+	afterHeader := false
+	seenHeader := false
+	takeComments := func() []string {
+		taken := comments
+		comments = make([]string, 0)
+		return taken
+	}
+	startSection := func(newState ReadState) {
+		if !seenHeader {
+			doc.Comments = trimEmptyLines(takeComments())
+		}
+		comments = trimEmptyLines(comments)
+		state = newState
+		afterHeader = true
+		seenHeader = true
+	}
+	for _, rawLine := range strings.Split(script, "\n") {
+		line := strings.TrimSpace(rawLine)
+		if line == "" && afterHeader {
+			continue
+		}
+		afterHeader = false
+		if strings.HasPrefix(line, "$") {
+			assignment := NewAssignmentFromLine(line)
+			assignment.Comments = takeComments()
+			if state == ReadStateActions && currentFrame != nil {
+				currentFrame.Actions = append(currentFrame.Actions, Statement{Assignment: &assignment})
+			} else {
+				doc.Definitions = append(doc.Definitions, assignment)
+			}
+			continue
+		}
+		if strings.HasPrefix(line, "#") {
+			if strings.HasPrefix(line, "# NEWFRAME") {
+				startSection(ReadStateStart)
+				currentFrame = &FrameDocument{Comments: takeComments()}
+				doc.Frames = append(doc.Frames, currentFrame)
+				continue
+			} else if strings.HasPrefix(line, definitionsHeader) {
+				startSection(state)
+				continue
+			} else if strings.HasPrefix(line, "# TIMEOUTACTIONS") {
+				startSection(ReadStateTimeoutActions)
+				continue
+			} else if strings.HasPrefix(line, "# AND-TIMEOUTCONDITIONS") {
+				doc.TimeoutMode = ConditionsAnd
+				startSection(ReadStateAndTimeoutConditions)
+				continue
+			} else if strings.HasPrefix(line, "# OR-TIMEOUTCONDITIONS") {
+				doc.TimeoutMode = ConditionsOr
+				startSection(ReadStateOrTimeoutConditions)
+				continue
+			} else if strings.HasPrefix(line, "## AND-STARTCONDITIONS") && currentFrame != nil {
+				currentFrame.StartMode = ConditionsAnd
+				startSection(ReadStateAndStartConditions)
+				continue
+			} else if strings.HasPrefix(line, "## OR-STARTCONDITIONS") && currentFrame != nil {
+				currentFrame.StartMode = ConditionsOr
+				startSection(ReadStateOrStartConditions)
+				continue
+			} else if strings.HasPrefix(line, "## ACTIONS") {
+				startSection(ReadStateActions)
+				continue
+			}
+		} else if core.LooksLikeAFunction(line) {
+			call := NewCallFromLine(line)
+			isStatement := true
+			switch state {
+			case ReadStateAndTimeoutConditions, ReadStateOrTimeoutConditions:
+				call.Comments = takeComments()
+				doc.TimeoutConditions = append(doc.TimeoutConditions, call)
+			case ReadStateAndStartConditions, ReadStateOrStartConditions:
+				call.Comments = takeComments()
+				currentFrame.StartConditions = append(currentFrame.StartConditions, call)
+			case ReadStateActions:
+				if currentFrame != nil {
+					call.Comments = takeComments()
+					currentFrame.Actions = append(currentFrame.Actions, Statement{Call: call})
+				} else {
+					isStatement = false
+				}
+			case ReadStateTimeoutActions:
+				call.Comments = takeComments()
+				doc.TimeoutActions = append(doc.TimeoutActions, call)
+			default:
+				isStatement = false
+			}
+			if isStatement {
+				continue
+			}
+		}
+		comments = append(comments, strings.TrimRight(rawLine, " \t\r"))
+	}
+	doc.TrailingComments = trimEmptyLines(comments)
+	if !seenHeader && len(doc.Definitions) == 0 {
+		doc.Comments, doc.TrailingComments = doc.TrailingComments, nil
+	}
+	return doc
+}
+
+// String writes the document back into the text format read by ScriptParser.
+func (d *ScriptDocument) String() string {
+	var out strings.Builder
+	writeLines := func(lines []string) {
+		for _, line := range lines {
+			out.WriteString(line + "\n")
+		}
+	}
+	writeSection := func(header string, lines []string) {
+		out.WriteString(header + "\n\n")
+		writeLines(lines)
+		out.WriteString("\n")
+	}
+	if len(d.Comments) > 0 {
+		writeLines(d.Comments)
+		out.WriteString("\n")
+	}
+	if len(d.Definitions) > 0 {
+		writeSection(definitionsHeader, assignmentsToLines(d.Definitions))
+	}
+	if len(d.TimeoutConditions) > 0 {
+		writeSection(fmt.Sprintf("# %s-TIMEOUTCONDITIONS", d.TimeoutMode.ToString()), callsToLines(d.TimeoutConditions))
+	}
+	if len(d.TimeoutActions) > 0 {
+		writeSection("# TIMEOUTACTIONS", callsToLines(d.TimeoutActions))
+	}
+	for _, frame := range d.Frames {
+		writeLines(frame.Comments)
+		out.WriteString("# NEWFRAME\n\n")
+		if len(frame.StartConditions) > 0 {
+			writeSection(fmt.Sprintf("## %s-STARTCONDITIONS", frame.StartMode.ToString()), callsToLines(frame.StartConditions))
+		}
+		if len(frame.Actions) > 0 {
+			writeSection("## ACTIONS", statementsToLines(frame.Actions))
+		}
+	}
+	writeLines(d.TrailingComments)
+	return strings.TrimRight(out.String(), "\n") + "\n"
+}
+
+func (d *ScriptDocument) AddFrame() *FrameDocument {
+	frame := &FrameDocument{}
+	d.Frames = append(d.Frames, frame)
+	return frame
+}
+
+func (d *ScriptDocument) RemoveFrame(index int) {
+	if index < 0 || index >= len(d.Frames) {
+		return
+	}
+	d.Frames = append(d.Frames[:index], d.Frames[index+1:]...)
+}
+
+func (d *ScriptDocument) MoveFrame(index, delta int) {
+	target := index + delta
+	if index < 0 || index >= len(d.Frames) || target < 0 || target >= len(d.Frames) {
+		return
+	}
+	d.Frames[index], d.Frames[target] = d.Frames[target], d.Frames[index]
+}
+
+// DefinedVariables returns every variable defined in the document, mapped to
+// the kind of value it holds. $PLAYER is always defined.
+func (d *ScriptDocument) DefinedVariables() map[string]ParamKind {
+	variables := definitionsToKinds(d.Definitions)
+	for _, frame := range d.Frames {
+		for _, statement := range frame.Actions {
+			if statement.IsAssignment() {
+				variables[statement.Assignment.Variable] = assignmentKind(*statement.Assignment)
+			}
+		}
+	}
+	return variables
+}
+
+// ScriptContext holds the names a script can refer to on its map.
+type ScriptContext struct {
+	ActorNames    mapset.Set[string]
+	LocationNames mapset.Set[string]
+	ZoneNames     mapset.Set[string]
+	DialogueNames mapset.Set[string]
+}
+
+func NewScriptContext() ScriptContext {
+	return ScriptContext{
+		ActorNames:    mapset.NewSet[string](),
+		LocationNames: mapset.NewSet[string](),
+		ZoneNames:     mapset.NewSet[string](),
+		DialogueNames: mapset.NewSet[string](),
+	}
+}
+
+// Validate checks every line of the script against the vocabulary and the
+// names available on the map. It returns one message per problem found.
+func (d *ScriptDocument) Validate(context ScriptContext) []string {
+	problems := make([]string, 0)
+	variables := map[string]ParamKind{"$PLAYER": ParamActor}
+	report := func(where string, problem string) {
+		problems = append(problems, fmt.Sprintf("%s: %s", where, problem))
+	}
+	checkDefinition := func(where string, definition Assignment) {
+		for _, problem := range validateAssignment(definition, variables, context) {
+			report(where, problem)
+		}
+		variables[definition.Variable] = assignmentKind(definition)
+	}
+	checkCalls := func(where string, calls []Call, kind FunctionKind) {
+		for _, call := range calls {
+			for _, problem := range validateCall(call, kind, variables, context) {
+				report(where, problem)
+			}
+		}
+	}
+	for _, definition := range d.Definitions {
+		checkDefinition("Definitions", definition)
+	}
+	checkCalls("Timeout conditions", d.TimeoutConditions, FunctionPredicate)
+	checkCalls("Timeout actions", d.TimeoutActions, FunctionAction)
+	if len(d.Frames) == 0 {
+		report("Script", "has no frames")
+	}
+	for index, frame := range d.Frames {
+		where := fmt.Sprintf("Frame %d", index+1)
+		checkCalls(where, frame.StartConditions, FunctionPredicate)
+		for _, statement := range frame.Actions {
+			if statement.IsAssignment() {
+				checkDefinition(where, *statement.Assignment)
+			} else {
+				checkCalls(where, []Call{statement.Call}, FunctionAction)
+			}
+		}
+	}
+	return problems
+}
+
+type DialogueLine struct {
+	Speaker string
+	Text    string
+	// Comments are the comment lines above the line, they are written back unchanged.
+	Comments []string
+}
+
+type DialogueDocument struct {
+	Definitions []Assignment
+	// Comments are the comment lines above the "## DIALOGUE" header, TrailingComments those at the end.
+	Comments         []string
+	Name             string
+	WithPlayer       bool
+	Lines            []DialogueLine
+	TrailingComments []string
+}
+
+var dialogueSpeakerPattern = regexp.MustCompile(`^(\$[A-Za-z-_]+):`)
+
+// ParseDialogueDocument reads the same format as DialogueParser.parse. Lines that
+// DialogueParser ignores are kept as comments of the line below them.
+func ParseDialogueDocument(content string) *DialogueDocument {
+	doc := &DialogueDocument{}
+	preambleState := true
+	// comments are the lines since the last definition, header or line of speech
+	comments := make([]string, 0)
+	takeComments := func() []string {
+		taken := trimEmptyLines(comments)
+		comments = make([]string, 0)
+		return taken
+	}
+	for _, rawLine := range strings.Split(content, "\n") {
+		line := strings.TrimSpace(rawLine)
+		if strings.HasPrefix(line, "$") {
+			if preambleState {
+				assignment := NewAssignmentFromLine(line)
+				assignment.Comments = takeComments()
+				doc.Definitions = append(doc.Definitions, assignment)
+				continue
+			}
+			if match := dialogueSpeakerPattern.FindStringSubmatch(line); match != nil {
+				doc.Lines = append(doc.Lines, DialogueLine{
+					Speaker:  match[1],
+					Text:     strings.TrimSpace(line[len(match[1])+1:]),
+					Comments: takeComments(),
+				})
+				continue
+			}
+		} else if strings.HasPrefix(line, "## DIALOGUE:") {
+			preambleState = false
+			doc.Comments = takeComments()
+			doc.Name = strings.TrimSpace(line[12:])
+			doc.WithPlayer = false
+			continue
+		} else if strings.HasPrefix(line, "## DIALOGUE-PLAYER:") {
+			preambleState = false
+			doc.Comments = takeComments()
+			doc.Name = strings.TrimSpace(line[19:])
+			doc.WithPlayer = true
+			continue
+		}
+		comments = append(comments, strings.TrimRight(rawLine, " \t\r"))
+	}
+	doc.TrailingComments = takeComments()
+	return doc
+}
+
+// String writes the document back into the text format read by DialogueParser.
+// Consecutive lines of the same speaker are kept together, a change of speaker
+// or a comment is separated by an empty line.
+func (d *DialogueDocument) String() string {
+	var out strings.Builder
+	writeLines := func(lines []string) {
+		for _, line := range lines {
+			out.WriteString(line + "\n")
+		}
+	}
+	writeLines(assignmentsToLines(d.Definitions))
+	if len(d.Definitions) > 0 {
+		out.WriteString("\n")
+	}
+	writeLines(d.Comments)
+	if d.WithPlayer {
+		out.WriteString("## DIALOGUE-PLAYER: " + d.Name + "\n")
+	} else {
+		out.WriteString("## DIALOGUE: " + d.Name + "\n")
+	}
+	lastSpeaker := ""
+	for _, line := range d.Lines {
+		if line.Speaker != lastSpeaker || len(line.Comments) > 0 {
+			out.WriteString("\n")
+		}
+		writeLines(line.Comments)
+		out.WriteString(fmt.Sprintf("%s: %s\n", line.Speaker, line.Text))
+		lastSpeaker = line.Speaker
+	}
+	if len(d.TrailingComments) > 0 {
+		out.WriteString("\n")
+		writeLines(d.TrailingComments)
+	}
+	return out.String()
+}
+
+// Validate checks the definitions and speakers of the dialogue.
+func (d *DialogueDocument) Validate(context ScriptContext) []string {
+	problems := make([]string, 0)
+	variables := map[string]ParamKind{"$PLAYER": ParamActor}
+	for _, definition := range d.Definitions {
+		for _, problem := range validateAssignment(definition, variables, context) {
+			problems = append(problems, "Definitions: "+problem)
+		}
+		variables[definition.Variable] = assignmentKind(definition)
+	}
+	if strings.TrimSpace(d.Name) == "" {
+		problems = append(problems, "Dialogue: has no name")
+	}
+	if len(d.Lines) == 0 {
+		problems = append(problems, "Dialogue: has no lines")
+	}
+	playerSpeaks := false
+	for index, line := range d.Lines {
+		kind, defined := variables[line.Speaker]
+		if !defined {
+			problems = append(problems, fmt.Sprintf("Line %d: speaker %s is not defined", index+1, line.Speaker))
+		} else if kind != ParamActor {
+			problems = append(problems, fmt.Sprintf("Line %d: speaker %s is a %s, not an actor", index+1, line.Speaker, kind.ToString()))
+		}
+		if line.Speaker == "$PLAYER" {
+			playerSpeaks = true
+		}
+	}
+	if playerSpeaks && !d.WithPlayer {
+		problems = append(problems, "Dialogue: the player speaks, but it is not a player dialogue")
+	}
+	return problems
+}
+
+// DefinedVariables returns every variable defined in the dialogue, including $PLAYER.
+func (d *DialogueDocument) DefinedVariables() map[string]ParamKind {
+	return definitionsToKinds(d.Definitions)
+}
+
+func assignmentKind(assignment Assignment) ParamKind {
+	if assignment.IsLiteral() {
+		return ParamText
+	}
+	if signature, ok := LookupFunction(assignment.Call.Name, FunctionValue); ok {
+		return signature.Returns
+	}
+	return ParamText
+}
+
+func definitionsToKinds(definitions []Assignment) map[string]ParamKind {
+	variables := map[string]ParamKind{"$PLAYER": ParamActor}
+	for _, definition := range definitions {
+		variables[definition.Variable] = assignmentKind(definition)
+	}
+	return variables
+}
+
+func validateAssignment(assignment Assignment, variables map[string]ParamKind, context ScriptContext) []string {
+	problems := make([]string, 0)
+	if !strings.HasPrefix(assignment.Variable, "$") || len(assignment.Variable) < 2 {
+		problems = append(problems, fmt.Sprintf("'%s' is not a valid variable name", assignment.Variable))
+	}
+	if assignment.Variable == "$PLAYER" {
+		problems = append(problems, "$PLAYER cannot be redefined")
+	}
+	if assignment.IsLiteral() {
+		return problems
+	}
+	return append(problems, validateCall(assignment.Call, FunctionValue, variables, context)...)
+}
+
+func validateCall(call Call, kind FunctionKind, variables map[string]ParamKind, context ScriptContext) []string {
+	signature, known := LookupFunction(call.Name, kind)
+	if !known {
+		kindName := map[FunctionKind]string{FunctionValue: "value function", FunctionPredicate: "predicate", FunctionAction: "action"}[kind]
+		return []string{fmt.Sprintf("%s is not a known %s", call.Name, kindName)}
+	}
+	problems := make([]string, 0)
+	if len(call.Args) < len(signature.Params) || (!signature.Variadic && len(call.Args) > len(signature.Params)) {
+		problems = append(problems, fmt.Sprintf("%s expects %d arguments, got %d", call.Name, len(signature.Params), len(call.Args)))
+	}
+	for index, arg := range call.Args {
+		expected, hasParam := signature.ParamAt(index)
+		if !hasParam {
+			break
+		}
+		if problem := validateArgument(call, signature, arg, expected, variables, context); problem != "" {
+			problems = append(problems, problem)
+		}
+	}
+	return problems
+}
+
+func validateArgument(call Call, signature FunctionSignature, arg string, expected ParamKind, variables map[string]ParamKind, context ScriptContext) string {
+	if strings.HasPrefix(arg, "$") {
+		actual, defined := variables[arg]
+		if !defined {
+			return fmt.Sprintf("%s uses undefined variable %s", call.Name, arg)
+		}
+		if actual != expected && !(actual == ParamText && isLiteralKind(expected)) {
+			return fmt.Sprintf("%s expects a %s, but %s is a %s", call.Name, expected.ToString(), arg, actual.ToString())
+		}
+		return ""
+	}
+	switch expected {
+	case ParamActor, ParamLocation, ParamItem:
+		return fmt.Sprintf("%s expects a %s variable, got '%s'", call.Name, expected.ToString(), arg)
+	case ParamNumber:
+		if _, err := strconv.ParseFloat(arg, 64); err != nil {
+			return fmt.Sprintf("%s expects a number, got '%s'", call.Name, arg)
+		}
+	case ParamZone:
+		if context.ZoneNames != nil && !context.ZoneNames.Contains(arg) {
+			return fmt.Sprintf("%s: there is no zone named '%s'", call.Name, arg)
+		}
+	case ParamDialogue:
+		if context.DialogueNames != nil && !context.DialogueNames.Contains(arg) {
+			return fmt.Sprintf("%s: there is no dialogue named '%s'", call.Name, arg)
+		}
+	case ParamText:
+		if signature.Kind != FunctionValue {
+			return ""
+		}
+		if signature.Name == "ActorWithName" && context.ActorNames != nil && !context.ActorNames.Contains(arg) {
+			return fmt.Sprintf("%s: there is no actor named '%s'", call.Name, arg)
+		}
+		if signature.Name == "NamedLocation" && context.LocationNames != nil && !context.LocationNames.Contains(arg) {
+			return fmt.Sprintf("%s: there is no location named '%s'", call.Name, arg)
+		}
+	}
+	return ""
+}
+
+func isLiteralKind(kind ParamKind) bool {
+	switch kind {
+	case ParamText, ParamNumber, ParamZone, ParamDialogue, ParamStimulus:
+		return true
+	}
+	return false
+}
+
+func assignmentsToLines(assignments []Assignment) []string {
+	lines := make([]string, 0, len(assignments))
+	for _, assignment := range assignments {
+		lines = append(append(lines, assignment.Comments...), assignment.String())
+	}
+	return lines
+}
+
+func callsToLines(calls []Call) []string {
+	lines := make([]string, 0, len(calls))
+	for _, call := range calls {
+		lines = append(append(lines, call.Comments...), call.String())
+	}
+	return lines
+}
+
+func statementsToLines(statements []Statement) []string {
+	lines := make([]string, 0, len(statements))
+	for _, statement := range statements {
+		if statement.IsAssignment() {
+			lines = append(lines, assignmentsToLines([]Assignment{*statement.Assignment})...)
+		} else {
+			lines = append(lines, callsToLines([]Call{statement.Call})...)
+		}
+	}
+	return lines
+}
+
+// trimEmptyLines removes the empty lines at the start and the end.
+func trimEmptyLines(lines []string) []string {
+	for len(lines) > 0 && strings.TrimSpace(lines[0]) == "" {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
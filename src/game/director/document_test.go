@@ -0,0 +1,197 @@
+package director
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestScriptDocumentRoundTrip(t *testing.T) {
+	tests := []struct {
+		name   string
+		script string
+	}{
+		{
+			name: "definitions and frames",
+			script: `# Definitions
+
+$LEADER = ActorWithName(Leader)
+$LEADER_POS = NamedLocation(Leader Pos)
+
+# OR-TIMEOUTCONDITIONS
+
+IsDowned($LEADER)
+IsCurrentFrameOlderThan(360)
+
+# TIMEOUTACTIONS
+
+StopScripted($LEADER)
+
+# NEWFRAME
+
+## AND-STARTCONDITIONS
+
+IsMissionTimeInSeconds(45)
+
+## ACTIONS
+
+SwitchToScript($LEADER)
+SetPreferredLocation($LEADER, $LEADER_POS)
+`,
+		},
+		{
+			name: "assignments between actions",
+			script: `# NEWFRAME
+
+## ACTIONS
+
+SwitchToScript($PLAYER)
+$WIRE = NearestItemWithName($PLAYER, wire)
+MoveToItem($PLAYER, $WIRE)
+$DELAY = 5
+Dance($PLAYER, $DELAY)
+`,
+		},
+		{
+			name: "comments and groups",
+			script: `# the meeting of the leaders
+
+# Definitions
+
+# the hosts
+$LEADER = ActorWithName(Leader)
+
+$GUEST = ActorWithName(Guest)
+
+# TIMEOUTACTIONS
+
+StopScripted($LEADER)
+
+# both are in place now
+# NEWFRAME
+
+## OR-STARTCONDITIONS
+
+# whoever comes first
+IsAtLocation($LEADER, $POS)
+IsAtLocation($GUEST, $POS)
+
+## ACTIONS
+
+LookAtActor($LEADER, $GUEST)
+
+# keep the guest in sight
+$SEEN = ActorWithName(Guest)
+LookAtActor($GUEST, $LEADER)
+
+# end of the meeting
+`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			written := ParseScriptDocument(test.script).String()
+			if written != test.script {
+				t.Errorf("round trip changed the script\n--- got ---\n%s\n--- want ---\n%s", written, test.script)
+			}
+		})
+	}
+}
+
+func TestScriptDocumentKeepsActionOrder(t *testing.T) {
+	doc := ParseScriptDocument(`# NEWFRAME
+
+## ACTIONS
+
+SwitchToScript($PLAYER)
+$WIRE = NearestItemWithName($PLAYER, wire)
+MoveToItem($PLAYER, $WIRE)
+`)
+	if len(doc.Frames) != 1 {
+		t.Fatalf("got %d frames, want 1", len(doc.Frames))
+	}
+	want := []string{
+		"SwitchToScript($PLAYER)",
+		"$WIRE = NearestItemWithName($PLAYER, wire)",
+		"MoveToItem($PLAYER, $WIRE)",
+	}
+	actions := doc.Frames[0].Actions
+	if len(actions) != len(want) {
+		t.Fatalf("got %d actions, want %d", len(actions), len(want))
+	}
+	for index, statement := range actions {
+		if statement.String() != want[index] {
+			t.Errorf("action %d is %q, want %q", index, statement.String(), want[index])
+		}
+	}
+	if !actions[1].IsAssignment() || actions[0].IsAssignment() {
+		t.Errorf("only the second action should be an assignment")
+	}
+}
+
+func TestScriptDocumentRoundTripOfMapScripts(t *testing.T) {
+	scripts, err := filepath.Glob(filepath.Join("..", "..", "datafiles", "campaigns", "*", "*.map", "scripts", "*.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, script := range scripts {
+		content, readErr := os.ReadFile(script)
+		if readErr != nil {
+			t.Fatal(readErr)
+		}
+		text := strings.ReplaceAll(string(content), "\r\n", "\n")
+		written := ParseScriptDocument(text).String()
+		if strings.TrimRight(written, "\n") != strings.TrimRight(text, "\n") {
+			t.Errorf("round trip changed %s\n--- got ---\n%s", script, written)
+		}
+	}
+}
+
+func TestDialogueDocumentRoundTrip(t *testing.T) {
+	tests := []struct {
+		name     string
+		dialogue string
+	}{
+		{
+			name: "speakers",
+			dialogue: `$HOTELIER = ActorWithName(Hotelier)
+
+## DIALOGUE-PLAYER: player_hotel_reservation
+
+$HOTELIER: Welcome to the Himmapan Hotel, sir.
+
+$PLAYER: I've got a reservation for a room.
+
+$HOTELIER: Name?
+$HOTELIER: Ah yes, Mr. Rieper.
+`,
+		},
+		{
+			name: "comments",
+			dialogue: `# the hotel reception
+$HOTELIER = ActorWithName(Hotelier)
+
+# only after the check-in
+## DIALOGUE: hotel_smalltalk
+
+# greeting
+$HOTELIER: Welcome back, sir.
+$HOTELIER: How was your day?
+
+# the player lies
+$PLAYER: Busy.
+
+# TODO: more lines
+`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			written := ParseDialogueDocument(test.dialogue).String()
+			if written != test.dialogue {
+				t.Errorf("round trip changed the dialogue\n--- got ---\n%s\n--- want ---\n%s", written, test.dialogue)
+			}
+		})
+	}
+}
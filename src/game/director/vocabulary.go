@@ -0,0 +1,107 @@
+package director
+
+// ParamKind describes what kind of value a script function expects for one of
+// its arguments. The editor uses it to offer the right picker, the validator
+// uses it to reject arguments that cannot work at runtime.
+type ParamKind int
+
+const (
+	ParamText ParamKind = iota
+	ParamNumber
+	ParamActor
+	ParamLocation
+	ParamItem
+	ParamZone
+	ParamDialogue
+	ParamStimulus
+)
+
+func (k ParamKind) ToString() string {
+	switch k {
+	case ParamNumber:
+		return "number"
+	case ParamActor:
+		return "actor"
+	case ParamLocation:
+		return "location"
+	case ParamItem:
+		return "item"
+	case ParamZone:
+		return "zone"
+	case ParamDialogue:
+		return "dialogue"
+	case ParamStimulus:
+		return "stimulus"
+	}
+	return "text"
+}
+
+type FunctionKind int
+
+const (
+	FunctionValue FunctionKind = iota
+	FunctionPredicate
+	FunctionAction
+)
+
+// FunctionSignature documents a function that can be called from a script or
+// dialogue file. For value functions, Returns is the kind of the value that
+// gets assigned to the variable.
+type FunctionSignature struct {
+	Name     string
+	Kind     FunctionKind
+	Params   []ParamKind
+	Variadic bool
+	Returns  ParamKind
+}
+
+// Vocabulary lists the functions that can be called from scripts and dialogues. It is built from
+// the registrations in states/gameplay_register_script_funcs.go with RegisterSignature, only the
+// built-in IsCurrentFrameOlderThan of the ScriptParser is declared here.
+var Vocabulary = []FunctionSignature{
+	{Name: "IsCurrentFrameOlderThan", Kind: FunctionPredicate, Params: []ParamKind{ParamNumber}},
+}
+
+// RegisterSignature adds a function to the Vocabulary. Like the parsers, a later registration
+// replaces an earlier one with the same name and kind.
+func RegisterSignature(signature FunctionSignature) {
+	for index, known := range Vocabulary {
+		if known.Name == signature.Name && known.Kind == signature.Kind {
+			Vocabulary[index] = signature
+			return
+		}
+	}
+	Vocabulary = append(Vocabulary, signature)
+}
+
+// LookupFunction returns the signature of the function with the given name and kind.
+func LookupFunction(name string, kind FunctionKind) (FunctionSignature, bool) {
+	for _, signature := range Vocabulary {
+		if signature.Name == name && signature.Kind == kind {
+			return signature, true
+		}
+	}
+	return FunctionSignature{}, false
+}
+
+// FunctionsOfKind returns all signatures of the given kind in declaration order.
+func FunctionsOfKind(kind FunctionKind) []FunctionSignature {
+	result := make([]FunctionSignature, 0)
+	for _, signature := range Vocabulary {
+		if signature.Kind == kind {
+			result = append(result, signature)
+		}
+	}
+	return result
+}
+
+// ParamAt returns the expected kind of the argument at the given index.
+func (s FunctionSignature) ParamAt(index int) (ParamKind, bool) {
+	if index < len(s.Params) {
+		return s.Params[index], true
+	}
+	if s.Variadic && len(s.Params) > 0 {
+		return s.Params[len(s.Params)-1], true
+	}
+	return ParamText, false
+}
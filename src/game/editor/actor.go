@@ -268,5 +268,5 @@ func (g *GameStateEditor) openInventoryForSelectedActor() {
         g.PrintAsMessage(fmt.Sprintf("Item: %s", itemString))
     }, func() {
         //g.UpdateHUD()
-    }, nil)
+    }, nil, nil)
 }
@@ -35,6 +35,11 @@ func (g *GameStateEditor) openBrushMenu() {
 			Handler: func() { g.selectionTool = NewFilledRectangleBrush(); g.updateStatusLine() },
 		},
 
+		{
+			Label:   "Lasso",
+			Icon:    core.GlyphLine,
+			Handler: func() { g.selectionTool = NewLassoBrush(); g.updateStatusLine() },
+		},
 		{
 			Label:   "Outlined Circle",
 			Icon:    core.GlyphOutlinedCircle,
@@ -86,6 +91,75 @@ func (p *Pencil) StopDrawing(pos geometry.Point) []geometry.Point {
 	return slice
 }
 
+// NewLassoBrush selects the cells on and inside a freehand outline.
+func NewLassoBrush() *LassoBrush {
+	return &LassoBrush{}
+}
+
+type LassoBrush struct {
+	outline []geometry.Point
+}
+
+func (l *LassoBrush) StartDrawing(pos geometry.Point) {
+	l.outline = []geometry.Point{pos}
+}
+
+func (l *LassoBrush) Icon() rune {
+	return core.GlyphLine
+}
+
+func (l *LassoBrush) DraggedOver(pos geometry.Point) []geometry.Point {
+	if len(l.outline) == 0 || l.outline[len(l.outline)-1] != pos {
+		l.outline = append(l.outline, pos)
+	}
+	return l.outline
+}
+
+func (l *LassoBrush) StopDrawing(pos geometry.Point) []geometry.Point {
+	l.DraggedOver(pos)
+	selected := l.filledOutline()
+	l.outline = nil
+	return selected
+}
+
+// filledOutline closes the outline and returns all cells on it or inside of it (even-odd rule).
+func (l *LassoBrush) filledOutline() []geometry.Point {
+	result := mapset.NewSet[geometry.Point]()
+	if len(l.outline) == 0 {
+		return result.ToSlice()
+	}
+	closed := append(append([]geometry.Point{}, l.outline...), l.outline[0])
+	minPos, maxPos := l.outline[0], l.outline[0]
+	for i := 0; i < len(closed)-1; i++ {
+		for _, linePos := range geometry.LineOfSight(closed[i], closed[i+1], func(p geometry.Point) bool { return true }) {
+			result.Add(linePos)
+		}
+		result.Add(closed[i])
+		minPos = geometry.Point{X: IntMin(minPos.X, closed[i].X), Y: IntMin(minPos.Y, closed[i].Y)}
+		maxPos = geometry.Point{X: max(maxPos.X, closed[i].X), Y: max(maxPos.Y, closed[i].Y)}
+	}
+	for y := minPos.Y; y <= maxPos.Y; y++ {
+		for x := minPos.X; x <= maxPos.X; x++ {
+			if pointInPolygon(float64(x)+0.5, float64(y)+0.5, closed) {
+				result.Add(geometry.Point{X: x, Y: y})
+			}
+		}
+	}
+	return result.ToSlice()
+}
+
+func pointInPolygon(x, y float64, polygon []geometry.Point) bool {
+	inside := false
+	for i, j := 0, len(polygon)-1; i < len(polygon); j, i = i, i+1 {
+		xi, yi := float64(polygon[i].X)+0.5, float64(polygon[i].Y)+0.5
+		xj, yj := float64(polygon[j].X)+0.5, float64(polygon[j].Y)+0.5
+		if (yi > y) != (yj > y) && x < (xj-xi)*(y-yi)/(yj-yi)+xi {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
 func NewLineBrush() *LineBrush {
 	return &LineBrush{}
 }
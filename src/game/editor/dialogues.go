@@ -0,0 +1,263 @@
+package editor
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/memmaker/terminal-assassin/game/core"
+	"github.com/memmaker/terminal-assassin/game/director"
+	"github.com/memmaker/terminal-assassin/game/services"
+)
+
+func (g *GameStateEditor) loadDialogueDocument(filename string) {
+	content, err := g.readTextFile(filename)
+	if err != nil {
+		g.PrintAsMessage("ERR: could not read " + filename + " (" + err.Error() + ")")
+		return
+	}
+	g.currentDialogue = director.ParseDialogueDocument(content)
+	g.currentDialoguePath = filename
+	g.scriptPanelIndex = 0
+	g.openDialoguePanel()
+}
+
+func (g *GameStateEditor) createNewDialogue() {
+	g.engine.GetUI().ShowTextInput("Dialogue name: ", "", func(name string) {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			g.PrintAsMessage("ERR: dialogue name cannot be empty")
+			return
+		}
+		g.currentDialogue = &director.DialogueDocument{Name: name}
+		filename := strings.ReplaceAll(strings.ToLower(name), " ", "_") + ".txt"
+		g.currentDialoguePath = path.Join(g.engine.GetGame().GetMap().MapFileName(), "dialogues", filename)
+		g.scriptPanelIndex = 0
+		g.openDialoguePanel()
+	}, func() {
+		g.PrintAsMessage("Cancelled")
+	})
+}
+
+// openDialoguePanel shows the current dialogue as one row per speaker definition and line.
+func (g *GameStateEditor) openDialoguePanel() {
+	doc := g.currentDialogue
+	if doc == nil {
+		return
+	}
+	g.editingDialogue = true
+	menuItems := make([]services.MenuItem, 0)
+	addRow := func(label string, icon rune, handler func()) {
+		index := len(menuItems)
+		menuItems = append(menuItems, services.MenuItem{
+			Label: label,
+			Icon:  icon,
+			Handler: func() {
+				g.scriptPanelIndex = index
+				handler()
+			},
+		})
+	}
+	for i, definition := range doc.Definitions {
+		definitionIndex := i
+		addRow("Define "+definition.String(), '$', func() {
+			g.openDefinitionRowMenu(&doc.Definitions, definitionIndex, doc.DefinedVariables, g.openDialoguePanel)
+		})
+	}
+	addRow("+ Add speaker", '+', func() {
+		g.newDefinition(director.ParamActor, doc.DefinedVariables, func(definition director.Assignment) {
+			doc.Definitions = append(doc.Definitions, definition)
+		}, g.openDialoguePanel)
+	})
+	kindLabel := "Dialogue"
+	if doc.WithPlayer {
+		kindLabel = "Player dialogue"
+	}
+	addRow(fmt.Sprintf("%s: %s", kindLabel, doc.Name), '#', g.openDialogueHeaderMenu)
+	for i, line := range doc.Lines {
+		lineIndex := i
+		addRow(fmt.Sprintf("  %s: %s", line.Speaker, line.Text), ' ', func() {
+			g.openDialogueLineMenu(lineIndex)
+		})
+	}
+	addRow("+ Add line", '+', func() {
+		g.pickDialogueLine(len(doc.Lines), g.lastSpeakerBefore(len(doc.Lines)))
+	})
+	addRow("Validate", 'v', func() {
+		g.showValidationResult(path.Base(g.currentDialoguePath), doc.Validate(g.scriptContext()), g.openDialoguePanel)
+	})
+	addRow("Save", 's', g.saveDialogue)
+
+	title := "Dialogue: " + path.Base(g.currentDialoguePath)
+	g.engine.GetUI().OpenWideAutoCloseMenuWithCallback(title, menuItems, g.scriptPanelIndex, g.SetDirty)
+}
+
+func (g *GameStateEditor) openDialogueHeaderMenu() {
+	doc := g.currentDialogue
+	menuItems := []services.MenuItem{
+		{
+			Label: "Rename",
+			Handler: func() {
+				g.engine.GetUI().ShowTextInput("Dialogue name: ", doc.Name, func(name string) {
+					doc.Name = strings.TrimSpace(name)
+					g.openDialoguePanel()
+				}, g.openDialoguePanel)
+			},
+			QuickKey: "r",
+		},
+		{
+			Label: "Toggle player dialogue",
+			Handler: func() {
+				doc.WithPlayer = !doc.WithPlayer
+				g.openDialoguePanel()
+			},
+			QuickKey: "p",
+		},
+	}
+	g.openScriptPicker(doc.Name, menuItems, g.openDialoguePanel)
+}
+
+func (g *GameStateEditor) openDialogueLineMenu(index int) {
+	doc := g.currentDialogue
+	line := doc.Lines[index]
+	menuItems := []services.MenuItem{
+		{
+			Label: "Edit text",
+			Handler: func() {
+				g.engine.GetUI().ShowTextInput(line.Speaker+": ", line.Text, func(text string) {
+					doc.Lines[index].Text = text
+					g.openDialoguePanel()
+				}, g.openDialoguePanel)
+			},
+			QuickKey: "e",
+		},
+		{
+			Label: "Change speaker",
+			Handler: func() {
+				g.pickSpeaker(func(speaker string) {
+					doc.Lines[index].Speaker = speaker
+					g.openDialoguePanel()
+				})
+			},
+			QuickKey: "s",
+		},
+		{
+			Label: "Insert line before",
+			Handler: func() {
+				g.pickDialogueLine(index, g.lastSpeakerBefore(index))
+			},
+			QuickKey: "i",
+		},
+		{
+			Label: "Move up",
+			Handler: func() {
+				if index > 0 {
+					doc.Lines[index-1], doc.Lines[index] = doc.Lines[index], doc.Lines[index-1]
+					g.scriptPanelIndex--
+				}
+				g.openDialoguePanel()
+			},
+			QuickKey: "k",
+		},
+		{
+			Label: "Move down",
+			Handler: func() {
+				if index < len(doc.Lines)-1 {
+					doc.Lines[index+1], doc.Lines[index] = doc.Lines[index], doc.Lines[index+1]
+					g.scriptPanelIndex++
+				}
+				g.openDialoguePanel()
+			},
+			QuickKey: "j",
+		},
+		{
+			Label: "Delete",
+			Handler: func() {
+				doc.Lines = append(doc.Lines[:index], doc.Lines[index+1:]...)
+				g.openDialoguePanel()
+			},
+			QuickKey: core.KeyBackspace,
+		},
+	}
+	g.openScriptPicker(line.Speaker, menuItems, g.openDialoguePanel)
+}
+
+// pickDialogueLine asks for a speaker and the text of a new line that is inserted at index.
+// The speaker of the previous line is offered first, since dialogues usually alternate.
+func (g *GameStateEditor) pickDialogueLine(index int, previousSpeaker string) {
+	doc := g.currentDialogue
+	g.pickSpeaker(func(speaker string) {
+		g.engine.GetUI().ShowTextInput(speaker+": ", "", func(text string) {
+			newLine := director.DialogueLine{Speaker: speaker, Text: text}
+			doc.Lines = append(doc.Lines[:index], append([]director.DialogueLine{newLine}, doc.Lines[index:]...)...)
+			g.openDialoguePanel()
+		}, g.openDialoguePanel)
+	}, previousSpeaker)
+}
+
+// pickSpeaker offers every actor variable of the dialogue; preferred speakers are listed first.
+func (g *GameStateEditor) pickSpeaker(done func(string), preferred ...string) {
+	doc := g.currentDialogue
+	speakers := make([]string, 0)
+	for variable, kind := range doc.DefinedVariables() {
+		if kind == director.ParamActor && (variable != "$PLAYER" || doc.WithPlayer) {
+			speakers = append(speakers, variable)
+		}
+	}
+	sort.Slice(speakers, func(i, j int) bool {
+		iPreferred, jPreferred := isPreferredSpeaker(speakers[i], preferred), isPreferredSpeaker(speakers[j], preferred)
+		if iPreferred != jPreferred {
+			return iPreferred
+		}
+		return speakers[i] < speakers[j]
+	})
+	menuItems := make([]services.MenuItem, 0, len(speakers)+1)
+	for _, s := range speakers {
+		speaker := s
+		menuItems = append(menuItems, services.MenuItem{Label: speaker, Handler: func() { done(speaker) }})
+	}
+	menuItems = append(menuItems, services.MenuItem{
+		Label: "new speaker..",
+		Handler: func() {
+			g.newDefinition(director.ParamActor, doc.DefinedVariables, func(definition director.Assignment) {
+				doc.Definitions = append(doc.Definitions, definition)
+			}, func() { g.pickSpeaker(done, preferred...) })
+		},
+	})
+	g.openScriptPicker("Speaker", menuItems, g.openDialoguePanel)
+}
+
+// isPreferredSpeaker is true for speakers that did not say the previous line.
+func isPreferredSpeaker(speaker string, previous []string) bool {
+	for _, p := range previous {
+		if p == speaker {
+			return false
+		}
+	}
+	return len(previous) > 0
+}
+
+func (g *GameStateEditor) lastSpeakerBefore(index int) string {
+	if index <= 0 || index > len(g.currentDialogue.Lines) {
+		return ""
+	}
+	return g.currentDialogue.Lines[index-1].Speaker
+}
+
+func (g *GameStateEditor) saveDialogue() {
+	if g.currentDialogue == nil {
+		return
+	}
+	err := g.writeTextFile(g.currentDialoguePath, g.currentDialogue.String())
+	if err != nil {
+		g.PrintAsMessage("ERR: Failed to save dialogue to " + g.currentDialoguePath + " (" + err.Error() + ")")
+		return
+	}
+	problems := g.currentDialogue.Validate(g.scriptContext())
+	if len(problems) > 0 {
+		g.PrintAsMessage(fmt.Sprintf("Dialogue saved to %s with %d problem(s), use Validate to list them", g.currentDialoguePath, len(problems)))
+		return
+	}
+	g.PrintAsMessage("Dialogue saved to " + g.currentDialoguePath)
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/memmaker/terminal-assassin/geometry"
 
 	"github.com/memmaker/terminal-assassin/game/core"
+	"github.com/memmaker/terminal-assassin/game/director"
 	"github.com/memmaker/terminal-assassin/gridmap"
 	"github.com/memmaker/terminal-assassin/ui"
 )
@@ -47,6 +48,15 @@ type GameStateEditor struct {
 	currentPrefab         *gridmap.Prefab[*core.Actor, *core.Item, services.Object]
 	taskPreviewFov        *geometry.FOV
 	pendingLookDir        float64
+
+	currentScript       *director.ScriptDocument
+	currentScriptPath   string
+	currentDialogue     *director.DialogueDocument
+	currentDialoguePath string
+	editingDialogue     bool
+	scriptPanelIndex    int
+
+	multiSelection *multiSelection
 }
 
 func (g *GameStateEditor) ClearOverlay() {
@@ -67,7 +77,7 @@ func (h UIHandler) WithTextHandler(textReceivedHandler func(text string)) UIHand
 	return h
 }
 
-var placePrefabUI, createPrefabUI, editLightsUI, editNamedLocationUI, addObjectsUI, quickAddActorsUI, editMapUI, addStimuliUI, addZonesUI, addTasksUI, addActorsUI, editActorUI, editTaskUI, editScheduleUI, addItemsUI UIHandler
+var placePrefabUI, createPrefabUI, editLightsUI, editNamedLocationUI, addObjectsUI, quickAddActorsUI, editMapUI, addStimuliUI, addZonesUI, addTasksUI, addActorsUI, editActorUI, editTaskUI, editScheduleUI, addItemsUI, multiSelectUI UIHandler
 
 var globalKeyPresses map[core.Key]func()
 
@@ -278,6 +288,12 @@ func (g *GameStateEditor) Init(engine services.Engine) {
 		},
 		CellsSelected: g.selectAtMousePos,
 	}
+	multiSelectUI = UIHandler{
+		Name:          "multi-select",
+		KeyPressed:    map[core.Key]func(){},
+		ContextMenu:   g.multiSelectContextMenu(),
+		CellsSelected: g.selectRegion,
+	}
 	addActorsUI = UIHandler{
 		Name:          "add actors",
 		KeyPressed:    map[core.Key]func(){},
@@ -345,6 +361,12 @@ func (g *GameStateEditor) Init(engine services.Engine) {
 				Icon:     'S',
 				QuickKey: "s",
 			},
+			{
+				Label:    "Generate Patrol",
+				Handler:  g.generatePatrolForSelectedActor,
+				Icon:     'g',
+				QuickKey: "g",
+			},
 			{
 				Label:    "Adjust Look Direction",
 				Handler:  g.adjustLookDirectionForSelectedActor,
@@ -433,6 +455,32 @@ func (g *GameStateEditor) Init(engine services.Engine) {
 					return ok
 				},
 			},
+			{
+				Label:    "Set Circuit",
+				Handler:  g.setCircuitOfSelectedObject,
+				Icon:     core.GlyphPowerBox,
+				QuickKey: "c",
+				Condition: func() bool {
+					if g.selectedObject == nil {
+						return false
+					}
+					_, ok := g.selectedObject.(services.Wired)
+					return ok
+				},
+			},
+			{
+				Label:    "Toggle Fail-Open",
+				Handler:  g.toggleFailOpenOfSelectedObject,
+				Icon:     'f',
+				QuickKey: "f",
+				Condition: func() bool {
+					if g.selectedObject == nil {
+						return false
+					}
+					_, ok := g.selectedObject.(services.FailSafeLock)
+					return ok
+				},
+			},
 			{
 				Label:   "Edit contents",
 				Handler: g.editContentsOfSelectedObject,
@@ -626,5 +674,6 @@ func (g *GameStateEditor) resetSelectionAndSwitchToDefaultState() {
 	g.SelectedLightSource = nil
 	g.SelectedTaskIndex = 0
 	g.SelectedSchedule = nil
+	g.multiSelection = nil
 	g.changeUIStateTo(editMapUI)
 }
@@ -147,6 +147,14 @@ func (g *GameStateEditor) Draw(con console.CellInterface) {
             con.SetSquare(screenPos, cellAt.WithBackgroundColor(core.CurrentTheme.MarkedBackground))
         }
     }
+    for _, p := range g.multiSelection.Positions() {
+        if !m.GetCamera().ViewPort.Contains(p) {
+            continue
+        }
+        screenPos := m.GetCamera().WorldToScreen(p)
+        cellAt := con.AtSquare(screenPos)
+        con.SetSquare(screenPos, cellAt.WithBackgroundColor(core.CurrentTheme.MarkedBackground))
+    }
     for name, location := range currentMap.NamedLocations {
         if !m.GetCamera().ViewPort.Contains(location) {
             continue
@@ -51,6 +51,12 @@ func (g *GameStateEditor) createMenuBar(gridWidth int, gridHeight int) {
             Highlight: g.isState(editScheduleUI),
             QuickKey:  "F5",
         },
+        {
+            Label:     "Scripts",
+            Handler:   g.openScriptsMenu,
+            Icon:      '$',
+            QuickKey:  "F6",
+        },
         {
             Label:     "Zones",
             Handler:   g.openZoneMenu,
@@ -108,6 +114,18 @@ func (g *GameStateEditor) createMenuBar(gridWidth int, gridHeight int) {
             Icon:     'g',
             QuickKey: "F11",
         },
+        {
+            Label: "Multi-Select",
+            Handler: func() {
+                g.changeUIStateTo(multiSelectUI)
+                g.placeThingIcon = 's'
+                g.selectionTool = NewFilledRectangleBrush()
+                g.updateStatusLine()
+            },
+            Icon:      's',
+            Highlight: g.isState(multiSelectUI),
+            QuickKey:  "F12",
+        },
     }
     menuBarRect := geometry.NewRect(0, gridHeight-3, gridWidth, gridHeight-1)
     g.menuBar = ui.NewMenuBar("Editor", editorMainMenuItems, menuBarRect)
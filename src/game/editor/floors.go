@@ -0,0 +1,56 @@
+package editor
+
+import (
+	"fmt"
+
+	"github.com/memmaker/terminal-assassin/game/services"
+	"github.com/memmaker/terminal-assassin/geometry"
+	"github.com/memmaker/terminal-assassin/gridmap"
+)
+
+// addFloor grows the map to the right by the size of the top floor and adds a new floor there.
+// A map without floors becomes the ground floor first.
+func (g *GameStateEditor) addFloor() {
+	currentMap := g.engine.GetGame().GetMap()
+	if !currentMap.HasFloors() {
+		currentMap.AddFloor(&gridmap.Floor{Name: "Ground Floor", Level: 0, Bounds: geometry.NewRect(0, 0, currentMap.MapWidth, currentMap.MapHeight)})
+	}
+	top := currentMap.Floors[len(currentMap.Floors)-1]
+	g.engine.GetUI().ShowTextInput("Floor name: ", fmt.Sprintf("Floor %d", top.Level+1), func(name string) {
+		if name == "" {
+			g.PrintAsMessage("ERR: floor name cannot be empty")
+			return
+		}
+		size := top.Bounds.Size()
+		bounds := geometry.NewRect(currentMap.MapWidth, 0, currentMap.MapWidth+size.X, size.Y)
+		currentMap.Resize(bounds.Max.X, max(currentMap.MapHeight, bounds.Max.Y), g.engine.GetData().GroundTile())
+		floor := &gridmap.Floor{Name: name, Level: top.Level + 1, Bounds: bounds}
+		currentMap.AddFloor(floor)
+		g.jumpToFloor(floor)
+		g.PrintAsMessage(fmt.Sprintf("Added %s, map size: %d x %d", floor, currentMap.MapWidth, currentMap.MapHeight))
+	}, func() {
+		g.PrintAsMessage("Cancelled")
+	})
+}
+
+func (g *GameStateEditor) openFloorsMenu() {
+	currentMap := g.engine.GetGame().GetMap()
+	if !currentMap.HasFloors() {
+		g.PrintAsMessage("This map has no floors")
+		return
+	}
+	menuItems := make([]services.MenuItem, 0, len(currentMap.Floors))
+	for _, f := range currentMap.Floors {
+		floor := f
+		menuItems = append(menuItems, services.MenuItem{
+			Label:   floor.String(),
+			Handler: func() { g.jumpToFloor(floor) },
+		})
+	}
+	g.engine.GetUI().OpenFixedWidthAutoCloseMenu("Floors", menuItems)
+}
+
+func (g *GameStateEditor) jumpToFloor(floor *gridmap.Floor) {
+	g.engine.GetGame().GetCamera().CenterWithin(floor.Bounds.Mid(), floor.Bounds)
+	g.gridIsDirty = true
+}
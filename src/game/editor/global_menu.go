@@ -34,6 +34,14 @@ func (g *GameStateEditor) openGlobalMenu() {
             Label:   "Resize Map",
             Handler: g.resizeMap,
         },
+        {
+            Label:   "Add Floor",
+            Handler: g.addFloor,
+        },
+        {
+            Label:   "Go to Floor",
+            Handler: g.openFloorsMenu,
+        },
         {
             Label:    "Load Map",
             Handler:  g.loadMap,
@@ -44,6 +52,22 @@ func (g *GameStateEditor) openGlobalMenu() {
             Handler:  g.saveMap,
             QuickKey: "s",
         },
+        {
+            Label:   "Import Tiled JSON",
+            Handler: g.importTiledMap,
+        },
+        {
+            Label:   "Export Tiled JSON",
+            Handler: g.exportTiledMap,
+        },
+        {
+            Label:   "Diff against map folder",
+            Handler: g.diffMapFolders,
+        },
+        {
+            Label:   "Merge with map folder",
+            Handler: g.mergeMapFolders,
+        },
 
         {
             Label:   "Ambient Light",
@@ -117,6 +141,107 @@ func (g *GameStateEditor) saveMap() {
     return
 }
 
+// importTiledMap replaces the current map with a map read from a Tiled JSON file.
+// The imported map has no map folder, use "Save Map" to store it in the native format.
+func (g *GameStateEditor) importTiledMap() {
+    g.engine.GetUI().ShowTextInput("Tiled JSON file: ", "", func(filename string) {
+        importedMap, err := g.engine.ImportTiledMap(filename)
+        if err != nil {
+            g.PrintAsMessage("ERR: Failed to import " + filename + " (" + err.Error() + ")")
+            return
+        }
+        game := g.engine.GetGame()
+        game.ResetModel()
+        game.InitLoadedMap(importedMap)
+        importedMap.Apply(func(cell gridmap.MapCell[*core.Actor, *core.Item, services.Object]) gridmap.MapCell[*core.Actor, *core.Item, services.Object] {
+            cell.IsExplored = true
+            return cell
+        })
+        importedMap.SetAmbientLight(common.GetAmbientLightFromDayTime(importedMap.TimeOfDay).ToRGB())
+        g.gridIsDirty = true
+        g.PrintAsMessage("Imported " + filename + ", save the map to keep it")
+    }, func() {
+        g.PrintAsMessage("cancelled")
+    })
+}
+
+func (g *GameStateEditor) exportTiledMap() {
+    currentMap := g.engine.GetGame().GetMap()
+    prefilledName := "map.json"
+    if existingFileName := currentMap.MapFileName(); existingFileName != "" {
+        prefilledName = strings.TrimSuffix(existingFileName, ".map") + ".json"
+    }
+    g.engine.GetUI().ShowTextInput("Export to: ", prefilledName, func(filename string) {
+        err := g.engine.ExportTiledMap(currentMap, filename)
+        if err != nil {
+            g.PrintAsMessage("ERR: Failed to export map to " + filename + " (" + err.Error() + ")")
+            return
+        }
+        g.PrintAsMessage("Map exported to " + filename)
+    }, func() {
+        g.PrintAsMessage("cancelled")
+    })
+}
+
+// diffMapFolders lists the changes between another map folder and the saved state of the current map.
+func (g *GameStateEditor) diffMapFolders() {
+    currentFolder := g.engine.GetGame().GetMap().MapFileName()
+    if currentFolder == "" {
+        g.PrintAsMessage("ERR: save the map first")
+        return
+    }
+    g.engine.GetUI().ShowTextInput("Compare with map folder: ", path.Dir(currentFolder)+"/", func(otherFolder string) {
+        report, err := g.engine.DiffMapFolders(otherFolder, currentFolder)
+        if err != nil {
+            g.PrintAsMessage("ERR: " + err.Error())
+            return
+        }
+        g.showReport(fmt.Sprintf("%d change(s) from %s", len(report), path.Base(otherFolder)), report)
+    }, func() {
+        g.PrintAsMessage("cancelled")
+    })
+}
+
+// mergeMapFolders does a three-way merge of the saved state of the current map ("ours")
+// with another copy of the map ("theirs"), based on the version both were copied from.
+func (g *GameStateEditor) mergeMapFolders() {
+    currentFolder := g.engine.GetGame().GetMap().MapFileName()
+    if currentFolder == "" {
+        g.PrintAsMessage("ERR: save the map first")
+        return
+    }
+    userInterface := g.engine.GetUI()
+    cancelled := func() { g.PrintAsMessage("cancelled") }
+    userInterface.ShowTextInput("Base map folder: ", path.Dir(currentFolder)+"/", func(baseFolder string) {
+        userInterface.ShowTextInput("Their map folder: ", path.Dir(currentFolder)+"/", func(theirsFolder string) {
+            outputFolder := strings.TrimSuffix(currentFolder, ".map") + "-merged.map"
+            userInterface.ShowTextInput("Save merged map to: ", outputFolder, func(outputFolder string) {
+                conflicts, err := g.engine.MergeMapFolders(baseFolder, currentFolder, theirsFolder, outputFolder)
+                if err != nil {
+                    g.PrintAsMessage("ERR: " + err.Error())
+                    return
+                }
+                if len(conflicts) > 0 {
+                    g.showReport(fmt.Sprintf("Merge failed: %d conflict(s)", len(conflicts)), conflicts)
+                    return
+                }
+                g.PrintAsMessage("Merged map saved to " + outputFolder)
+            }, cancelled)
+        }, cancelled)
+    }, cancelled)
+}
+
+func (g *GameStateEditor) showReport(title string, report []string) {
+    if len(report) == 0 {
+        report = []string{"No differences"}
+    }
+    lines := make([]core.StyledText, len(report))
+    for i, line := range report {
+        lines[i] = core.Text(line)
+    }
+    g.engine.GetUI().ShowPager(title, lines, g.SetDirty)
+}
+
 // applyThemeStyleToWholeMap re-colours every tile on the map using the current
 // theme. Walkable tiles get MapForeground/MapBackground, walls get WallForeground/WallBackground.
 func (g *GameStateEditor) applyThemeStyleToWholeMap() {
@@ -171,3 +171,38 @@ func (g *GameStateEditor) setDifficultyOfSelectedObject() {
     }
     g.OpenMenuBarDropDown("Lock Difficulty", (2*4)-2, menuItems)
 }
+
+// setCircuitOfSelectedObject wires the selected object to a named circuit.
+// An empty name wires it to the fuse box in its zone.
+func (g *GameStateEditor) setCircuitOfSelectedObject() {
+    wired, ok := g.selectedObject.(services.Wired)
+    if !ok {
+        return
+    }
+    g.changeUIStateTo(addObjectsUI.WithTextHandler(func(text string) {
+        wired.SetCircuit(text)
+        if text == "" {
+            g.PrintAsMessage(fmt.Sprintf("%s is wired to the fuse box of its zone", g.selectedObject.Description()))
+        } else {
+            g.PrintAsMessage(fmt.Sprintf("%s is wired to circuit '%s'", g.selectedObject.Description(), text))
+        }
+        g.SetDirty()
+    }))
+    g.showTextInput("Circuit (e.g. main/kitchen): ", wired.GetCircuit())
+}
+
+// toggleFailOpenOfSelectedObject switches an electronic lock between
+// releasing (fail-open) and staying locked (fail-closed) without power.
+func (g *GameStateEditor) toggleFailOpenOfSelectedObject() {
+    lock, ok := g.selectedObject.(services.FailSafeLock)
+    if !ok {
+        return
+    }
+    lock.SetFailOpen(!lock.IsFailOpen())
+    if lock.IsFailOpen() {
+        g.PrintAsMessage(fmt.Sprintf("%s unlocks without power (fail-open)", g.selectedObject.Description()))
+    } else {
+        g.PrintAsMessage(fmt.Sprintf("%s stays locked without power (fail-closed)", g.selectedObject.Description()))
+    }
+    g.SetDirty()
+}
@@ -0,0 +1,147 @@
+package editor
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/memmaker/terminal-assassin/game/core"
+	"github.com/memmaker/terminal-assassin/game/services"
+	"github.com/memmaker/terminal-assassin/geometry"
+	"github.com/memmaker/terminal-assassin/gridmap"
+)
+
+// patrolWaypoint is a zone or named location that can be visited by a generated patrol.
+type patrolWaypoint struct {
+	Label    string
+	Location geometry.Point
+}
+
+// patrolWaypointCandidates lists the center of every zone and all named locations.
+func (g *GameStateEditor) patrolWaypointCandidates() []patrolWaypoint {
+	currentMap := g.engine.GetGame().GetMap()
+	candidates := make([]patrolWaypoint, 0)
+	for _, zoneName := range currentMap.ZoneNames() {
+		if location, ok := currentMap.ZoneWaypoint(zoneName); ok {
+			candidates = append(candidates, patrolWaypoint{Label: "Zone: " + zoneName, Location: location})
+		}
+	}
+	locationNames := make([]string, 0, len(currentMap.NamedLocations))
+	for name := range currentMap.NamedLocations {
+		locationNames = append(locationNames, name)
+	}
+	sort.Strings(locationNames)
+	for _, name := range locationNames {
+		candidates = append(candidates, patrolWaypoint{Label: "Location: " + name, Location: currentMap.GetNamedLocation(name)})
+	}
+	return candidates
+}
+
+// generatePatrolForSelectedActor lets the designer pick zones and named locations
+// and creates a looping patrol schedule through them for the selected actor.
+func (g *GameStateEditor) generatePatrolForSelectedActor() {
+	if g.SelectedActor == nil {
+		g.PrintAsMessage("ERR: select an Actor first")
+		return
+	}
+	if g.SelectedActor.AI == nil {
+		g.PrintAsMessage(fmt.Sprintf("ERR: %s has no AI and cannot follow a schedule", g.SelectedActor.Name))
+		return
+	}
+	candidates := g.patrolWaypointCandidates()
+	if len(candidates) < 2 {
+		g.PrintAsMessage("ERR: a patrol needs at least two zones or named locations")
+		return
+	}
+	g.openPatrolWaypointMenu(g.SelectedActor, candidates, make([]bool, len(candidates)), 0)
+}
+
+func (g *GameStateEditor) openPatrolWaypointMenu(actor *core.Actor, candidates []patrolWaypoint, chosen []bool, index int) {
+	menuItems := make([]services.MenuItem, 0, len(candidates)+1)
+	chosenCount := 0
+	for i, candidate := range candidates {
+		candidateIndex := i
+		icon := ' '
+		if chosen[i] {
+			icon = '*'
+			chosenCount++
+		}
+		menuItems = append(menuItems, services.MenuItem{
+			Label: candidate.Label,
+			Icon:  icon,
+			Handler: func() {
+				chosen[candidateIndex] = !chosen[candidateIndex]
+				g.openPatrolWaypointMenu(actor, candidates, chosen, candidateIndex)
+			},
+		})
+	}
+	menuItems = append(menuItems, services.MenuItem{
+		Label: fmt.Sprintf("Generate patrol (%d waypoints)", chosenCount),
+		Icon:  '+',
+		Handler: func() {
+			waypoints := make([]geometry.Point, 0, chosenCount)
+			for i, candidate := range candidates {
+				if chosen[i] {
+					waypoints = append(waypoints, candidate.Location)
+				}
+			}
+			g.pickPatrolTemplate(actor, waypoints)
+		},
+	})
+	g.engine.GetUI().OpenWideAutoCloseMenuWithCallback("Patrol waypoints for "+actor.Name, menuItems, index, g.SetDirty)
+}
+
+func (g *GameStateEditor) pickPatrolTemplate(actor *core.Actor, waypoints []geometry.Point) {
+	if len(waypoints) < 2 {
+		g.PrintAsMessage("ERR: choose at least two waypoints")
+		return
+	}
+	menuItems := make([]services.MenuItem, 0, len(gridmap.PatrolTemplates))
+	for _, t := range gridmap.PatrolTemplates {
+		template := t
+		menuItems = append(menuItems, services.MenuItem{
+			Label: fmt.Sprintf("%s (%.0fs per waypoint)", template.Name, template.DurationInSeconds),
+			Handler: func() {
+				g.engine.GetUI().ShowTextInput("Schedule name: ", actor.Name+" patrol", func(name string) {
+					g.createPatrolSchedule(actor, name, waypoints, template)
+				}, func() {
+					g.PrintAsMessage("Cancelled")
+				})
+			},
+		})
+	}
+	g.engine.GetUI().OpenFixedWidthAutoCloseMenu("Patrol template", menuItems)
+}
+
+func (g *GameStateEditor) createPatrolSchedule(actor *core.Actor, name string, waypoints []geometry.Point, template gridmap.PatrolTemplate) {
+	currentMap := g.engine.GetGame().GetMap()
+	if actor.AI == nil {
+		g.PrintAsMessage(fmt.Sprintf("ERR: %s has no AI and cannot follow a schedule", actor.Name))
+		return
+	}
+	if name == "" {
+		g.PrintAsMessage("ERR: schedule name cannot be empty")
+		return
+	}
+	if currentMap.GetSchedule(name) != nil {
+		g.PrintAsMessage(fmt.Sprintf("ERR: schedule '%s' already exists", name))
+		return
+	}
+	schedule, skipped, err := currentMap.GeneratePatrol(name, actor.Pos(), waypoints, template)
+	if err != nil {
+		g.PrintAsMessage("ERR: " + err.Error())
+		return
+	}
+	currentMap.AddSchedule(schedule)
+	actor.AI.Schedule = schedule.Name
+	actor.AI.CurrentTaskIndex = 0
+	g.engine.GetAI().CalculateAllTaskPaths(actor)
+
+	g.SelectedSchedule = schedule
+	g.SelectedTaskIndex = -1
+	g.changeUIStateTo(editScheduleUI)
+	if len(skipped) > 0 {
+		g.PrintAsMessage(fmt.Sprintf("Generated '%s' with %d tasks for %s, %d unreachable waypoint(s) skipped", name, len(schedule.Tasks), actor.Name, len(skipped)))
+		return
+	}
+	g.PrintAsMessage(fmt.Sprintf("Generated '%s' with %d tasks for %s", name, len(schedule.Tasks), actor.Name))
+}
@@ -2,6 +2,17 @@ package editor
 
 import (
 	"fmt"
+	"io"
+	"os"
+	"path"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/memmaker/terminal-assassin/game/core"
+	"github.com/memmaker/terminal-assassin/game/director"
+	"github.com/memmaker/terminal-assassin/game/services"
+	"github.com/memmaker/terminal-assassin/game/stimuli"
 	"github.com/memmaker/terminal-assassin/geometry"
 )
 
@@ -59,3 +70,793 @@ func (g *GameStateEditor) deleteSelectedNamedLocation() {
 	g.changeUIStateTo(editNamedLocationUI)
 	g.gridIsDirty = true
 }
+
+// ── scripts & dialogues ───────────────────────────────────────────────────────
+
+// openScriptsMenu lists the script and dialogue files of the current map folder.
+func (g *GameStateEditor) openScriptsMenu() {
+	mapFolder := g.engine.GetGame().GetMap().MapFileName()
+	if mapFolder == "" {
+		g.PrintAsMessage("ERR: save the map first, scripts are stored in the map folder")
+		return
+	}
+	menuItems := []services.MenuItem{
+		{
+			Label:    "New Script",
+			Handler:  g.createNewScript,
+			Icon:     '+',
+			QuickKey: "n",
+		},
+		{
+			Label:    "New Dialogue",
+			Handler:  g.createNewDialogue,
+			Icon:     '+',
+			QuickKey: "d",
+		},
+	}
+	for _, f := range g.filesInMapSubfolder("scripts") {
+		filename := f
+		menuItems = append(menuItems, services.MenuItem{
+			Label:   "Script: " + path.Base(filename),
+			Handler: func() { g.loadScriptDocument(filename) },
+			Icon:    's',
+		})
+	}
+	for _, f := range g.filesInMapSubfolder("dialogues") {
+		filename := f
+		menuItems = append(menuItems, services.MenuItem{
+			Label:   "Dialogue: " + path.Base(filename),
+			Handler: func() { g.loadDialogueDocument(filename) },
+			Icon:    'd',
+		})
+	}
+	g.OpenMenuBarDropDown("Scripts", (2*6)-2, menuItems)
+}
+
+// filesInMapSubfolder returns the files in a subfolder of the current map,
+// sorted by name. Files that exist on disk and in the embedded data are listed once.
+func (g *GameStateEditor) filesInMapSubfolder(subfolder string) []string {
+	folder := path.Join(g.engine.GetGame().GetMap().MapFileName(), subfolder)
+	seen := make(map[string]bool)
+	result := make([]string, 0)
+	for _, filename := range g.engine.GetFiles().GetFilesInPath(folder) {
+		if seen[path.Base(filename)] {
+			continue
+		}
+		seen[path.Base(filename)] = true
+		result = append(result, filename)
+	}
+	sort.Strings(result)
+	return result
+}
+
+func (g *GameStateEditor) readTextFile(filename string) (string, error) {
+	file, err := g.engine.GetFiles().Open(filename)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+	content, err := io.ReadAll(file)
+	if err != nil {
+		return "", err
+	}
+	return string(content), nil
+}
+
+func (g *GameStateEditor) writeTextFile(filename string, content string) error {
+	if err := os.MkdirAll(path.Dir(filename), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(filename, []byte(content), 0644)
+}
+
+func (g *GameStateEditor) loadScriptDocument(filename string) {
+	content, err := g.readTextFile(filename)
+	if err != nil {
+		g.PrintAsMessage("ERR: could not read " + filename + " (" + err.Error() + ")")
+		return
+	}
+	g.currentScript = director.ParseScriptDocument(content)
+	g.currentScriptPath = filename
+	g.scriptPanelIndex = 0
+	g.openScriptPanel()
+}
+
+func (g *GameStateEditor) createNewScript() {
+	g.engine.GetUI().ShowTextInput("Script name: ", "", func(name string) {
+		name = strings.TrimSuffix(strings.TrimSpace(name), ".txt")
+		if name == "" {
+			g.PrintAsMessage("ERR: script name cannot be empty")
+			return
+		}
+		g.currentScript = &director.ScriptDocument{}
+		g.currentScript.AddFrame()
+		g.currentScriptPath = path.Join(g.engine.GetGame().GetMap().MapFileName(), "scripts", name+".txt")
+		g.scriptPanelIndex = 0
+		g.openScriptPanel()
+	}, func() {
+		g.PrintAsMessage("Cancelled")
+	})
+}
+
+// scriptContext collects the names that scripts and dialogues of the current map can refer to.
+func (g *GameStateEditor) scriptContext() director.ScriptContext {
+	currentMap := g.engine.GetGame().GetMap()
+	context := director.NewScriptContext()
+	for _, actor := range currentMap.Actors() {
+		context.ActorNames.Add(actor.Name)
+	}
+	for _, actor := range currentMap.DownedActors() {
+		context.ActorNames.Add(actor.Name)
+	}
+	for name := range currentMap.NamedLocations {
+		context.LocationNames.Add(name)
+	}
+	for _, zone := range currentMap.ListOfZones {
+		context.ZoneNames.Add(zone.Name)
+	}
+	for _, dialogueName := range g.dialogueNames() {
+		context.DialogueNames.Add(dialogueName)
+	}
+	return context
+}
+
+// dialogueNames parses the dialogue files of the current map and returns the dialogue names.
+func (g *GameStateEditor) dialogueNames() []string {
+	names := make([]string, 0)
+	for _, filename := range g.filesInMapSubfolder("dialogues") {
+		if filename == g.currentDialoguePath && g.currentDialogue != nil {
+			names = append(names, g.currentDialogue.Name)
+			continue
+		}
+		content, err := g.readTextFile(filename)
+		if err != nil {
+			continue
+		}
+		if name := director.ParseDialogueDocument(content).Name; name != "" {
+			names = append(names, name)
+		}
+	}
+	if g.currentDialogue != nil && !slices.Contains(names, g.currentDialogue.Name) && g.currentDialogue.Name != "" {
+		names = append(names, g.currentDialogue.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// openScriptPanel shows the current script as one row per definition,
+// condition and action. Selecting a row opens its edit menu.
+func (g *GameStateEditor) openScriptPanel() {
+	doc := g.currentScript
+	if doc == nil {
+		return
+	}
+	g.editingDialogue = false
+	menuItems := make([]services.MenuItem, 0)
+	addRow := func(label string, icon rune, handler func()) {
+		index := len(menuItems)
+		menuItems = append(menuItems, services.MenuItem{
+			Label: label,
+			Icon:  icon,
+			Handler: func() {
+				g.scriptPanelIndex = index
+				handler()
+			},
+		})
+	}
+	addCallRows := func(prefix string, calls *[]director.Call, kind director.FunctionKind) {
+		for i, c := range *calls {
+			callIndex := i
+			addRow(prefix+c.String(), ' ', func() { g.openCallRowMenu(calls, callIndex, kind, g.openScriptPanel) })
+		}
+	}
+	for i, definition := range doc.Definitions {
+		definitionIndex := i
+		addRow("Define "+definition.String(), '$', func() {
+			g.openDefinitionRowMenu(&doc.Definitions, definitionIndex, g.currentScript.DefinedVariables, g.openScriptPanel)
+		})
+	}
+	addRow("+ Add definition", '+', func() {
+		g.newDefinition(-1, g.currentScript.DefinedVariables, func(definition director.Assignment) {
+			doc.Definitions = append(doc.Definitions, definition)
+		}, g.openScriptPanel)
+	})
+
+	addRow(fmt.Sprintf("Timeout conditions (%s)", doc.TimeoutMode.ToString()), 'T', func() {
+		doc.TimeoutMode = toggledConditionMode(doc.TimeoutMode)
+		g.openScriptPanel()
+	})
+	addCallRows("  if ", &doc.TimeoutConditions, director.FunctionPredicate)
+	addRow("  + Add timeout condition", '+', func() {
+		g.appendCall(&doc.TimeoutConditions, director.FunctionPredicate, g.openScriptPanel)
+	})
+	addCallRows("  do ", &doc.TimeoutActions, director.FunctionAction)
+	addRow("  + Add timeout action", '+', func() {
+		g.appendCall(&doc.TimeoutActions, director.FunctionAction, g.openScriptPanel)
+	})
+
+	for i, f := range doc.Frames {
+		frameIndex, frame := i, f
+		addRow(fmt.Sprintf("Frame %d (start when %s)", frameIndex+1, frame.StartMode.ToString()), '#', func() {
+			g.openFrameMenu(frameIndex)
+		})
+		addCallRows("  if ", &frame.StartConditions, director.FunctionPredicate)
+		for j, statement := range frame.Actions {
+			statementIndex := j
+			if statement.IsAssignment() {
+				addRow("  set "+statement.String(), '$', func() {
+					g.openStatementRowMenu(&frame.Actions, statementIndex, g.currentScript.DefinedVariables, g.openScriptPanel)
+				})
+			} else {
+				addRow("  do "+statement.String(), ' ', func() {
+					g.openStatementRowMenu(&frame.Actions, statementIndex, g.currentScript.DefinedVariables, g.openScriptPanel)
+				})
+			}
+		}
+	}
+	addRow("+ Add frame", '+', func() {
+		doc.AddFrame()
+		g.openScriptPanel()
+	})
+	addRow("Validate", 'v', func() {
+		g.showValidationResult(path.Base(g.currentScriptPath), doc.Validate(g.scriptContext()), g.openScriptPanel)
+	})
+	addRow("Save", 's', g.saveScript)
+
+	title := "Script: " + path.Base(g.currentScriptPath)
+	g.engine.GetUI().OpenWideAutoCloseMenuWithCallback(title, menuItems, g.scriptPanelIndex, g.SetDirty)
+}
+
+func (g *GameStateEditor) openFrameMenu(frameIndex int) {
+	doc := g.currentScript
+	frame := doc.Frames[frameIndex]
+	menuItems := []services.MenuItem{
+		{
+			Label: "Add start condition",
+			Handler: func() {
+				g.appendCall(&frame.StartConditions, director.FunctionPredicate, g.openScriptPanel)
+			},
+			QuickKey: "c",
+		},
+		{
+			Label: "Add action",
+			Handler: func() {
+				g.pickCall(director.FunctionAction, func(call director.Call) {
+					frame.Actions = append(frame.Actions, director.Statement{Call: call})
+					g.openScriptPanel()
+				}, g.openScriptPanel)
+			},
+			QuickKey: "a",
+		},
+		{
+			Label: "Add variable",
+			Handler: func() {
+				g.newDefinition(-1, doc.DefinedVariables, func(definition director.Assignment) {
+					frame.Actions = append(frame.Actions, director.Statement{Assignment: &definition})
+				}, g.openScriptPanel)
+			},
+			QuickKey: "v",
+		},
+		{
+			Label: fmt.Sprintf("Toggle start mode (%s)", frame.StartMode.ToString()),
+			Handler: func() {
+				frame.StartMode = toggledConditionMode(frame.StartMode)
+				g.openScriptPanel()
+			},
+			QuickKey: "t",
+		},
+		{
+			Label: "Move frame up",
+			Handler: func() {
+				doc.MoveFrame(frameIndex, -1)
+				g.openScriptPanel()
+			},
+			QuickKey: "k",
+		},
+		{
+			Label: "Move frame down",
+			Handler: func() {
+				doc.MoveFrame(frameIndex, 1)
+				g.openScriptPanel()
+			},
+			QuickKey: "j",
+		},
+		{
+			Label: "Delete frame",
+			Handler: func() {
+				doc.RemoveFrame(frameIndex)
+				g.scriptPanelIndex = 0
+				g.openScriptPanel()
+			},
+			QuickKey: core.KeyBackspace,
+		},
+	}
+	g.openScriptPicker(fmt.Sprintf("Frame %d", frameIndex+1), menuItems, g.openScriptPanel)
+}
+
+func (g *GameStateEditor) saveScript() {
+	if g.currentScript == nil {
+		return
+	}
+	err := g.writeTextFile(g.currentScriptPath, g.currentScript.String())
+	if err != nil {
+		g.PrintAsMessage("ERR: Failed to save script to " + g.currentScriptPath + " (" + err.Error() + ")")
+		return
+	}
+	problems := g.currentScript.Validate(g.scriptContext())
+	if len(problems) > 0 {
+		g.PrintAsMessage(fmt.Sprintf("Script saved to %s with %d problem(s), use Validate to list them", g.currentScriptPath, len(problems)))
+		return
+	}
+	g.PrintAsMessage("Script saved to " + g.currentScriptPath)
+}
+
+func (g *GameStateEditor) showValidationResult(title string, problems []string, onQuit func()) {
+	if len(problems) == 0 {
+		g.PrintAsMessage(title + ": no problems found")
+		onQuit()
+		return
+	}
+	lines := make([]core.StyledText, len(problems))
+	for i, problem := range problems {
+		lines[i] = core.Text(problem)
+	}
+	g.engine.GetUI().ShowPager(fmt.Sprintf("%s: %d problem(s)", title, len(problems)), lines, onQuit)
+}
+
+func toggledConditionMode(mode director.ConditionMode) director.ConditionMode {
+	if mode == director.ConditionsAnd {
+		return director.ConditionsOr
+	}
+	return director.ConditionsAnd
+}
+
+// openScriptPicker opens a menu, returning to onCancel when it is closed without a choice.
+func (g *GameStateEditor) openScriptPicker(title string, menuItems []services.MenuItem, onCancel func()) {
+	chosen := false
+	for i := range menuItems {
+		handler := menuItems[i].Handler
+		menuItems[i].Handler = func() {
+			chosen = true
+			handler()
+		}
+	}
+	userInterface := g.engine.GetUI()
+	userInterface.OpenWideAutoCloseMenuWithCallback(title, menuItems, 0, func() {
+		g.gridIsDirty = true
+		g.engine.Schedule(0, func() {
+			if !chosen && onCancel != nil && !userInterface.IsShowingUI() {
+				onCancel()
+			}
+		})
+	})
+}
+
+// ── row editing shared by scripts and dialogues ──────────────────────────────
+
+func (g *GameStateEditor) openCallRowMenu(calls *[]director.Call, index int, kind director.FunctionKind, back func()) {
+	call := (*calls)[index]
+	replace := func(newCall director.Call) { (*calls)[index] = newCall }
+	menuItems := append(g.callEditItems(call, kind, replace, back), g.rowOrderItems(len(*calls), index, func(i, j int) {
+		(*calls)[i], (*calls)[j] = (*calls)[j], (*calls)[i]
+	}, func() {
+		*calls = append((*calls)[:index], (*calls)[index+1:]...)
+	}, back)...)
+	g.openScriptPicker(call.String(), menuItems, back)
+}
+
+func (g *GameStateEditor) openDefinitionRowMenu(definitions *[]director.Assignment, index int, variables func() map[string]director.ParamKind, back func()) {
+	definition := (*definitions)[index]
+	replace := func(newDefinition director.Assignment) { (*definitions)[index] = newDefinition }
+	menuItems := append(g.definitionEditItems(definition, variables, replace, back), g.rowOrderItems(len(*definitions), index, func(i, j int) {
+		(*definitions)[i], (*definitions)[j] = (*definitions)[j], (*definitions)[i]
+	}, func() {
+		*definitions = append((*definitions)[:index], (*definitions)[index+1:]...)
+	}, back)...)
+	g.openScriptPicker(definition.String(), menuItems, back)
+}
+
+// openStatementRowMenu edits a row of the actions of a frame, which is either an action or an assignment.
+func (g *GameStateEditor) openStatementRowMenu(statements *[]director.Statement, index int, variables func() map[string]director.ParamKind, back func()) {
+	statement := (*statements)[index]
+	var menuItems []services.MenuItem
+	if statement.IsAssignment() {
+		menuItems = g.definitionEditItems(*statement.Assignment, variables, func(newDefinition director.Assignment) {
+			(*statements)[index] = director.Statement{Assignment: &newDefinition}
+		}, back)
+	} else {
+		menuItems = g.callEditItems(statement.Call, director.FunctionAction, func(newCall director.Call) {
+			(*statements)[index] = director.Statement{Call: newCall}
+		}, back)
+	}
+	menuItems = append(menuItems, g.rowOrderItems(len(*statements), index, func(i, j int) {
+		(*statements)[i], (*statements)[j] = (*statements)[j], (*statements)[i]
+	}, func() {
+		*statements = append((*statements)[:index], (*statements)[index+1:]...)
+	}, back)...)
+	g.openScriptPicker(statement.String(), menuItems, back)
+}
+
+// callEditItems are the menu items to change a call, the comments above the call are kept.
+func (g *GameStateEditor) callEditItems(call director.Call, kind director.FunctionKind, replace func(director.Call), back func()) []services.MenuItem {
+	return []services.MenuItem{
+		{
+			Label: "Edit arguments",
+			Handler: func() {
+				signature, known := director.LookupFunction(call.Name, kind)
+				if !known {
+					g.PrintAsMessage(fmt.Sprintf("ERR: %s is unknown, replace the function instead", call.Name))
+					back()
+					return
+				}
+				g.pickArguments(signature, g.variablesForCurrentDocument(), g.definitionAdderForCurrentDocument(), func(args []string) {
+					replace(director.Call{Name: call.Name, Args: args, Comments: call.Comments})
+					back()
+				}, back)
+			},
+			QuickKey: "e",
+		},
+		{
+			Label: "Replace function",
+			Handler: func() {
+				g.pickCall(kind, func(newCall director.Call) {
+					newCall.Comments = call.Comments
+					replace(newCall)
+					back()
+				}, back)
+			},
+			QuickKey: "r",
+		},
+	}
+}
+
+// definitionEditItems are the menu items to change a definition, the comments above it are kept.
+func (g *GameStateEditor) definitionEditItems(definition director.Assignment, variables func() map[string]director.ParamKind, replace func(director.Assignment), back func()) []services.MenuItem {
+	return []services.MenuItem{
+		{
+			Label: "Redefine",
+			Handler: func() {
+				g.newDefinition(-1, variables, func(newDefinition director.Assignment) {
+					newDefinition.Variable = definition.Variable
+					newDefinition.Comments = definition.Comments
+					replace(newDefinition)
+				}, back)
+			},
+			QuickKey: "e",
+		},
+		{
+			Label: "Rename variable",
+			Handler: func() {
+				g.engine.GetUI().ShowTextInput("Variable name: ", definition.Variable, func(name string) {
+					renamed := definition
+					renamed.Variable = normalizedVariableName(name)
+					replace(renamed)
+					back()
+				}, back)
+			},
+			QuickKey: "r",
+		},
+	}
+}
+
+// rowOrderItems are the menu items to move the row at index within its list of count rows or to delete it.
+func (g *GameStateEditor) rowOrderItems(count int, index int, swap func(i, j int), remove func(), back func()) []services.MenuItem {
+	return []services.MenuItem{
+		{
+			Label: "Move up",
+			Handler: func() {
+				if index > 0 {
+					swap(index-1, index)
+					g.scriptPanelIndex--
+				}
+				back()
+			},
+			QuickKey: "k",
+		},
+		{
+			Label: "Move down",
+			Handler: func() {
+				if index < count-1 {
+					swap(index, index+1)
+					g.scriptPanelIndex++
+				}
+				back()
+			},
+			QuickKey: "j",
+		},
+		{
+			Label: "Delete",
+			Handler: func() {
+				remove()
+				back()
+			},
+			QuickKey: core.KeyBackspace,
+		},
+	}
+}
+
+func (g *GameStateEditor) appendCall(calls *[]director.Call, kind director.FunctionKind, back func()) {
+	g.pickCall(kind, func(call director.Call) {
+		*calls = append(*calls, call)
+		back()
+	}, back)
+}
+
+// variablesForCurrentDocument returns the variables of the script or dialogue currently being edited.
+func (g *GameStateEditor) variablesForCurrentDocument() func() map[string]director.ParamKind {
+	if g.currentDialogue != nil && g.editingDialogue {
+		return g.currentDialogue.DefinedVariables
+	}
+	if g.currentScript != nil {
+		return g.currentScript.DefinedVariables
+	}
+	return func() map[string]director.ParamKind {
+		return map[string]director.ParamKind{"$PLAYER": director.ParamActor}
+	}
+}
+
+// definitionAdderForCurrentDocument returns a function that adds a definition
+// to the preamble of the script or dialogue currently being edited.
+func (g *GameStateEditor) definitionAdderForCurrentDocument() func(director.Assignment) {
+	if g.currentDialogue != nil && g.editingDialogue {
+		return func(definition director.Assignment) {
+			g.currentDialogue.Definitions = append(g.currentDialogue.Definitions, definition)
+		}
+	}
+	return func(definition director.Assignment) {
+		if g.currentScript != nil {
+			g.currentScript.Definitions = append(g.currentScript.Definitions, definition)
+		}
+	}
+}
+
+// pickCall lets the designer choose a function of the given kind and then fill in its arguments.
+func (g *GameStateEditor) pickCall(kind director.FunctionKind, done func(director.Call), cancel func()) {
+	menuItems := make([]services.MenuItem, 0)
+	for _, s := range director.FunctionsOfKind(kind) {
+		signature := s
+		menuItems = append(menuItems, services.MenuItem{
+			Label: signatureLabel(signature),
+			Handler: func() {
+				g.pickArguments(signature, g.variablesForCurrentDocument(), g.definitionAdderForCurrentDocument(), func(args []string) {
+					done(director.Call{Name: signature.Name, Args: args})
+				}, cancel)
+			},
+		})
+	}
+	title := "Choose action"
+	if kind == director.FunctionPredicate {
+		title = "Choose condition"
+	}
+	g.openScriptPicker(title, menuItems, cancel)
+}
+
+// newDefinition lets the designer define a variable from a value function.
+// If returns is not negative, only value functions returning that kind are offered.
+func (g *GameStateEditor) newDefinition(returns director.ParamKind, variables func() map[string]director.ParamKind, add func(director.Assignment), back func()) {
+	menuItems := make([]services.MenuItem, 0)
+	for _, s := range director.FunctionsOfKind(director.FunctionValue) {
+		signature := s
+		if returns >= 0 && signature.Returns != returns {
+			continue
+		}
+		menuItems = append(menuItems, services.MenuItem{
+			Label: signatureLabel(signature) + " -> " + signature.Returns.ToString(),
+			Handler: func() {
+				g.pickArguments(signature, variables, g.definitionAdderForCurrentDocument(), func(args []string) {
+					suggestion := "$" + strings.ToUpper(signature.Returns.ToString())
+					if len(args) > 0 {
+						suggestion = variableNameFor(args[len(args)-1])
+					}
+					g.engine.GetUI().ShowTextInput("Variable name: ", uniqueVariableName(suggestion, variables()), func(name string) {
+						add(director.Assignment{
+							Variable: normalizedVariableName(name),
+							Call:     director.Call{Name: signature.Name, Args: args},
+						})
+						back()
+					}, back)
+				}, back)
+			},
+		})
+	}
+	menuItems = append(menuItems, services.MenuItem{
+		Label: "Text value",
+		Handler: func() {
+			g.engine.GetUI().ShowTextInput("Definition ($NAME = value): ", "$", func(line string) {
+				if !strings.Contains(line, "=") {
+					g.PrintAsMessage("ERR: expected '$NAME = value'")
+					back()
+					return
+				}
+				definition := director.NewAssignmentFromLine(line)
+				definition.Variable = normalizedVariableName(definition.Variable)
+				add(definition)
+				back()
+			}, back)
+		},
+		Condition: func() bool { return returns < 0 || returns == director.ParamText },
+	})
+	g.openScriptPicker("Define variable", menuItems, back)
+}
+
+// pickArguments asks for every argument of the signature in turn, using a picker
+// that matches the parameter kind.
+func (g *GameStateEditor) pickArguments(signature director.FunctionSignature, variables func() map[string]director.ParamKind, addDefinition func(director.Assignment), done func([]string), cancel func()) {
+	var pickNext func(args []string)
+	pickNext = func(args []string) {
+		if len(args) < len(signature.Params) {
+			kind, _ := signature.ParamAt(len(args))
+			g.pickArgument(signature.Name, len(args), kind, variables, addDefinition, func(arg string) {
+				pickNext(append(args, arg))
+			}, cancel)
+			return
+		}
+		if !signature.Variadic {
+			done(args)
+			return
+		}
+		kind, _ := signature.ParamAt(len(args))
+		g.openScriptPicker(signature.Name, []services.MenuItem{
+			{
+				Label: "Add another " + kind.ToString(),
+				Handler: func() {
+					g.pickArgument(signature.Name, len(args), kind, variables, addDefinition, func(arg string) {
+						pickNext(append(args, arg))
+					}, cancel)
+				},
+			},
+			{
+				Label:   "Done",
+				Handler: func() { done(args) },
+			},
+		}, cancel)
+	}
+	pickNext([]string{})
+}
+
+func (g *GameStateEditor) pickArgument(functionName string, index int, kind director.ParamKind, variables func() map[string]director.ParamKind, addDefinition func(director.Assignment), done func(string), cancel func()) {
+	currentMap := g.engine.GetGame().GetMap()
+	title := fmt.Sprintf("%s: argument %d (%s)", functionName, index+1, kind.ToString())
+	askForText := func() {
+		g.engine.GetUI().ShowTextInput(fmt.Sprintf("%s argument %d (%s): ", functionName, index+1, kind.ToString()), "", done, cancel)
+	}
+	menuItems := make([]services.MenuItem, 0)
+	addChoice := func(label string, value string) {
+		menuItems = append(menuItems, services.MenuItem{Label: label, Handler: func() { done(value) }})
+	}
+	// variables of the right kind
+	if kind != director.ParamZone && kind != director.ParamDialogue && kind != director.ParamStimulus {
+		names := make([]string, 0)
+		for variable, variableKind := range variables() {
+			if variableKind == kind || (variableKind == director.ParamText && kind == director.ParamNumber) {
+				names = append(names, variable)
+			}
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			addChoice(name, name)
+		}
+	}
+	// new variables bound to names on the map
+	defineAndUse := func(label string, valueFunction string, name string) {
+		menuItems = append(menuItems, services.MenuItem{
+			Label: label,
+			Handler: func() {
+				variable := uniqueVariableName(variableNameFor(name), variables())
+				addDefinition(director.Assignment{Variable: variable, Call: director.Call{Name: valueFunction, Args: []string{name}}})
+				done(variable)
+			},
+		})
+	}
+	switch kind {
+	case director.ParamActor:
+		for _, actorName := range sortedActorNames(currentMap.Actors()) {
+			defineAndUse("new: "+actorName, "ActorWithName", actorName)
+		}
+	case director.ParamLocation:
+		locationNames := make([]string, 0, len(currentMap.NamedLocations))
+		for name := range currentMap.NamedLocations {
+			locationNames = append(locationNames, name)
+		}
+		sort.Strings(locationNames)
+		for _, locationName := range locationNames {
+			defineAndUse("new: "+locationName, "NamedLocation", locationName)
+		}
+	case director.ParamItem:
+		menuItems = append(menuItems, services.MenuItem{
+			Label: "new item variable..",
+			Handler: func() {
+				g.newDefinition(director.ParamItem, variables, addDefinition, func() {
+					g.pickArgument(functionName, index, kind, variables, addDefinition, done, cancel)
+				})
+			},
+		})
+	case director.ParamZone:
+		for _, zoneName := range currentMap.ZoneNames() {
+			addChoice(zoneName, zoneName)
+		}
+	case director.ParamDialogue:
+		for _, dialogueName := range g.dialogueNames() {
+			addChoice(dialogueName, dialogueName)
+		}
+	case director.ParamStimulus:
+		for _, stimType := range []stimuli.StimulusType{
+			stimuli.StimulusWater, stimuli.StimulusFire, stimuli.StimulusBurnable, stimuli.StimulusSmoke,
+			stimuli.StimulusBlood, stimuli.StimulusHighVoltage, stimuli.StimulusEmetic, stimuli.StimulusLethal,
+			stimuli.StimulusSleep, stimuli.StimulusFrenzy,
+		} {
+			addChoice(string(stimType), string(stimType))
+		}
+	case director.ParamText, director.ParamNumber:
+		if len(menuItems) == 0 {
+			askForText()
+			return
+		}
+	}
+	if kind == director.ParamText || kind == director.ParamNumber || kind == director.ParamZone || kind == director.ParamDialogue {
+		menuItems = append(menuItems, services.MenuItem{Label: "enter value..", Handler: askForText})
+	}
+	if len(menuItems) == 0 {
+		g.PrintAsMessage(fmt.Sprintf("ERR: nothing on this map can be used as %s", kind.ToString()))
+		cancel()
+		return
+	}
+	g.openScriptPicker(title, menuItems, cancel)
+}
+
+func signatureLabel(signature director.FunctionSignature) string {
+	params := make([]string, len(signature.Params))
+	for i, param := range signature.Params {
+		params[i] = param.ToString()
+	}
+	if signature.Variadic {
+		params[len(params)-1] += "..."
+	}
+	return fmt.Sprintf("%s(%s)", signature.Name, strings.Join(params, ", "))
+}
+
+func sortedActorNames(actors []*core.Actor) []string {
+	names := make([]string, 0, len(actors))
+	for _, actor := range actors {
+		names = append(names, actor.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// variableNameFor turns a name from the map into a script variable, eg. "Red Dragon Leader" -> "$RED_DRAGON_LEADER".
+func variableNameFor(name string) string {
+	var result strings.Builder
+	for _, r := range strings.ToUpper(strings.TrimSpace(name)) {
+		if (r >= 'A' && r <= 'Z') || r == '-' || r == '_' {
+			result.WriteRune(r)
+		} else if r == ' ' || (r >= '0' && r <= '9') {
+			result.WriteRune('_')
+		}
+	}
+	if result.Len() == 0 {
+		return "$VAR"
+	}
+	return "$" + result.String()
+}
+
+func normalizedVariableName(name string) string {
+	name = strings.TrimSpace(name)
+	if !strings.HasPrefix(name, "$") {
+		name = "$" + name
+	}
+	return variableNameFor(name[1:])
+}
+
+func uniqueVariableName(suggestion string, taken map[string]director.ParamKind) string {
+	if _, exists := taken[suggestion]; !exists {
+		return suggestion
+	}
+	for suffix := 'B'; suffix <= 'Z'; suffix++ {
+		candidate := fmt.Sprintf("%s_%c", suggestion, suffix)
+		if _, exists := taken[candidate]; !exists {
+			return candidate
+		}
+	}
+	return suggestion
+}
@@ -0,0 +1,492 @@
+package editor
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+
+	"github.com/memmaker/terminal-assassin/game/core"
+	"github.com/memmaker/terminal-assassin/game/services"
+	"github.com/memmaker/terminal-assassin/geometry"
+	"github.com/memmaker/terminal-assassin/gridmap"
+)
+
+// multiSelection holds everything picked up by a region selection, so that
+// bulk operations can be applied to all of it at once.
+type multiSelection struct {
+	Actors  []*core.Actor
+	Items   []*core.Item
+	Objects []services.Object
+	Cells   []geometry.Point
+}
+
+func (s *multiSelection) IsEmpty() bool {
+	return s == nil || (len(s.Actors) == 0 && len(s.Items) == 0 && len(s.Objects) == 0 && len(s.Cells) == 0)
+}
+
+// Positions returns the positions of all selected actors, items and objects.
+func (s *multiSelection) Positions() []geometry.Point {
+	if s == nil {
+		return nil
+	}
+	result := make([]geometry.Point, 0, len(s.Actors)+len(s.Items)+len(s.Objects))
+	for _, actor := range s.Actors {
+		result = append(result, actor.Pos())
+	}
+	for _, item := range s.Items {
+		result = append(result, item.Pos())
+	}
+	for _, object := range s.Objects {
+		result = append(result, object.Pos())
+	}
+	return result
+}
+
+func (s *multiSelection) String() string {
+	return fmt.Sprintf("%d actor(s), %d item(s), %d object(s), %d cell(s)", len(s.Actors), len(s.Items), len(s.Objects), len(s.Cells))
+}
+
+func (g *GameStateEditor) multiSelectContextMenu() []services.MenuItem {
+	return []services.MenuItem{
+		{
+			Label:    "Set Team",
+			Handler:  g.setTeamForMultiSelection,
+			Icon:     'T',
+			QuickKey: "T",
+		},
+		{
+			Label:    "Set Actor Type",
+			Handler:  g.setTypeForMultiSelection,
+			Icon:     't',
+			QuickKey: "t",
+		},
+		{
+			Label:    "Assign Schedule",
+			Handler:  g.assignScheduleToMultiSelection,
+			Icon:     'S',
+			QuickKey: "s",
+		},
+		{
+			Label:    "Set Lock Difficulty",
+			Handler:  g.setLockDifficultyForMultiSelection,
+			Icon:     'l',
+			QuickKey: "l",
+		},
+		{
+			Label:    "Set Key",
+			Handler:  g.setKeyForMultiSelection,
+			Icon:     'k',
+			QuickKey: "k",
+		},
+		{
+			Label:    "Replace Tiles",
+			Handler:  g.replaceTilesOfMultiSelection,
+			Icon:     'r',
+			QuickKey: "r",
+		},
+		{
+			Label:    "Move by Offset",
+			Handler:  g.moveMultiSelection,
+			Icon:     'w',
+			QuickKey: "w",
+		},
+		{
+			Label:    "Clear Selection",
+			Handler:  g.clearMultiSelection,
+			Icon:     'c',
+			QuickKey: "c",
+		},
+		{
+			Label:    "Delete",
+			Handler:  g.deleteMultiSelection,
+			Icon:     'x',
+			QuickKey: core.KeyBackspace,
+		},
+	}
+}
+
+// selectRegion collects the actors, downed actors included, items and objects in the selected cells.
+// Holding control adds to the current selection instead of replacing it.
+func (g *GameStateEditor) selectRegion() {
+	if !ebiten.IsKeyPressed(ebiten.KeyControl) || g.multiSelection == nil {
+		g.multiSelection = &multiSelection{}
+	}
+	selection := g.multiSelection
+	currentMap := g.engine.GetGame().GetMap()
+	knownCells := make(map[geometry.Point]bool, len(selection.Cells))
+	for _, p := range selection.Cells {
+		knownCells[p] = true
+	}
+	for _, pos := range g.selectedWorldPositions {
+		if !currentMap.Contains(pos) || knownCells[pos] {
+			continue
+		}
+		knownCells[pos] = true
+		selection.Cells = append(selection.Cells, pos)
+		if currentMap.IsActorAt(pos) {
+			selection.Actors = append(selection.Actors, currentMap.ActorAt(pos))
+		}
+		if currentMap.IsDownedActorAt(pos) {
+			selection.Actors = append(selection.Actors, currentMap.DownedActorAt(pos))
+		}
+		if currentMap.IsItemAt(pos) {
+			selection.Items = append(selection.Items, currentMap.ItemAt(pos))
+		}
+		if currentMap.IsObjectAt(pos) {
+			selection.Objects = append(selection.Objects, currentMap.ObjectAt(pos))
+		}
+	}
+	g.PrintAsMessage("Selected " + selection.String())
+	g.gridIsDirty = true
+}
+
+func (g *GameStateEditor) clearMultiSelection() {
+	g.multiSelection = nil
+	g.PrintAsMessage("Selection cleared")
+	g.gridIsDirty = true
+}
+
+func (g *GameStateEditor) hasSelectedActors() bool {
+	if g.multiSelection == nil || len(g.multiSelection.Actors) == 0 {
+		g.PrintAsMessage("ERR: no Actors selected")
+		return false
+	}
+	return true
+}
+
+func (g *GameStateEditor) setTeamForMultiSelection() {
+	if !g.hasSelectedActors() {
+		return
+	}
+	actors := g.multiSelection.Actors
+	setTeam := func(team string) {
+		for _, actor := range actors {
+			actor.Team = team
+		}
+		if team == "" {
+			g.PrintAsMessage(fmt.Sprintf("Removed %d actor(s) from their team", len(actors)))
+		} else {
+			g.PrintAsMessage(fmt.Sprintf("Set team of %d actor(s) to %s", len(actors), team))
+		}
+		g.SetDirty()
+	}
+	promptNewTeam := func() {
+		g.handler = multiSelectUI.WithTextHandler(func(name string) {
+			g.changeUIStateTo(multiSelectUI)
+			if name == "" {
+				return
+			}
+			setTeam(name)
+		})
+		g.showTextInput("Team name: ", "")
+	}
+	existingTeams := collectTeams(g.engine.GetGame().GetMap().Actors())
+	menuItems := make([]services.MenuItem, 0, len(existingTeams)+2)
+	for _, team := range existingTeams {
+		t := team
+		menuItems = append(menuItems, services.MenuItem{
+			Label:   t,
+			Handler: func() { setTeam(t) },
+		})
+	}
+	menuItems = append(menuItems, services.MenuItem{
+		Label:   "(new team...)",
+		Handler: promptNewTeam,
+	})
+	menuItems = append(menuItems, services.MenuItem{
+		Label:   "(no team)",
+		Handler: func() { setTeam("") },
+	})
+	g.OpenMenuBarDropDown("Set team", 0, menuItems)
+}
+
+func (g *GameStateEditor) setTypeForMultiSelection() {
+	if !g.hasSelectedActors() {
+		return
+	}
+	actors := g.multiSelection.Actors
+	actorTypes := []core.ActorType{core.ActorTypeCivilian, core.ActorTypeGuard, core.ActorTypeFence, core.ActorTypePredator}
+	menuItems := make([]services.MenuItem, 0, len(actorTypes))
+	for _, actorType := range actorTypes {
+		t := actorType
+		menuItems = append(menuItems, services.MenuItem{
+			Label: string(t),
+			Handler: func() {
+				for _, actor := range actors {
+					actor.Type = t
+				}
+				g.PrintAsMessage(fmt.Sprintf("%d actor(s) are now of type %s", len(actors), t))
+				g.SetDirty()
+			},
+		})
+	}
+	g.OpenMenuBarDropDown("Actor type", 0, menuItems)
+}
+
+func (g *GameStateEditor) assignScheduleToMultiSelection() {
+	if !g.hasSelectedActors() {
+		return
+	}
+	actors := g.multiSelection.Actors
+	schedules := g.engine.GetGame().GetMap().ListOfSchedules()
+	if len(schedules) == 0 {
+		g.PrintAsMessage("No schedules in library yet — create one via the Schedule tab (F5)")
+		return
+	}
+	menuItems := make([]services.MenuItem, 0, len(schedules))
+	for _, sched := range schedules {
+		s := sched
+		menuItems = append(menuItems, services.MenuItem{
+			Label: fmt.Sprintf("%s (%d tasks)", s.Name, len(s.Tasks)),
+			Handler: func() {
+				assigned := 0
+				for _, actor := range actors {
+					if actor.AI == nil {
+						continue
+					}
+					actor.AI.Schedule = s.Name
+					actor.AI.CurrentTaskIndex = 0
+					g.engine.GetAI().CalculateAllTaskPaths(actor)
+					assigned++
+				}
+				if skipped := len(actors) - assigned; skipped > 0 {
+					g.PrintAsMessage(fmt.Sprintf("Assigned '%s' to %d actor(s), %d without AI skipped", s.Name, assigned, skipped))
+				} else {
+					g.PrintAsMessage(fmt.Sprintf("Assigned '%s' to %d actor(s)", s.Name, assigned))
+				}
+			},
+			Icon: 'S',
+		})
+	}
+	g.engine.GetUI().OpenFixedWidthAutoCloseMenu("Schedules", menuItems)
+}
+
+func (g *GameStateEditor) setLockDifficultyForMultiSelection() {
+	holders := make([]services.LockDifficultyHolder, 0)
+	if g.multiSelection != nil {
+		for _, object := range g.multiSelection.Objects {
+			if holder, ok := object.(services.LockDifficultyHolder); ok {
+				holders = append(holders, holder)
+			}
+		}
+	}
+	if len(holders) == 0 {
+		g.PrintAsMessage("ERR: no lockable Objects selected")
+		return
+	}
+	difficulties := []core.LockDifficulty{
+		core.LockDifficultyEasy,
+		core.LockDifficultyMedium,
+		core.LockDifficultyHard,
+	}
+	menuItems := make([]services.MenuItem, 0, len(difficulties))
+	for _, diff := range difficulties {
+		d := diff
+		menuItems = append(menuItems, services.MenuItem{
+			Label: fmt.Sprintf("%s (%d pick(s), %.0fs)", d.ToString(), d.PickCount(), d.PickTime()),
+			Handler: func() {
+				for _, holder := range holders {
+					holder.SetLockDifficulty(d)
+				}
+				g.PrintAsMessage(fmt.Sprintf("Lock difficulty of %d object(s) set to %s", len(holders), d.ToString()))
+				g.SetDirty()
+			},
+		})
+	}
+	g.OpenMenuBarDropDown("Lock Difficulty", 0, menuItems)
+}
+
+func (g *GameStateEditor) setKeyForMultiSelection() {
+	keyed := make([]services.KeyBound, 0)
+	if g.multiSelection != nil {
+		for _, object := range g.multiSelection.Objects {
+			if keyedObj, ok := object.(services.KeyBound); ok {
+				keyed = append(keyed, keyedObj)
+			}
+		}
+	}
+	if len(keyed) == 0 {
+		g.PrintAsMessage("ERR: no key bound Objects selected")
+		return
+	}
+	g.handler = multiSelectUI.WithTextHandler(func(text string) {
+		for _, keyedObj := range keyed {
+			keyedObj.SetKey(text)
+		}
+		g.changeUIStateTo(multiSelectUI)
+		g.PrintAsMessage(fmt.Sprintf("Key of %d object(s) set to %s", len(keyed), text))
+		g.SetDirty()
+	})
+	g.showTextInput("Set Object Key: ", keyed[0].GetKey())
+}
+
+// replaceTilesOfMultiSelection places the chosen tile on all selected cells.
+// Tiles have no colors of their own, they are taken from the theme by tile type.
+func (g *GameStateEditor) replaceTilesOfMultiSelection() {
+	if g.multiSelection == nil || len(g.multiSelection.Cells) == 0 {
+		g.PrintAsMessage("ERR: no cells selected")
+		return
+	}
+	cells := g.multiSelection.Cells
+	data := g.engine.GetData()
+	menuItems := make([]services.MenuItem, len(data.Tiles()))
+	for i, t := range data.Tiles() {
+		tile := *t
+		menuItems[i] = services.MenuItem{
+			Label: tile.Description(),
+			Icon:  tile.DefinedIcon,
+			Handler: func() {
+				for _, pos := range cells {
+					g.placeTileAtPos(tile, pos)
+				}
+				currentMap := g.engine.GetGame().GetMap()
+				currentMap.UpdateBakedLights()
+				currentMap.UpdateDynamicLights()
+				g.PrintAsMessage(fmt.Sprintf("Replaced %d tile(s) with %s", len(cells), tile.Description()))
+				g.SetDirty()
+			},
+		}
+	}
+	g.OpenTilePickerDropDown("Replace tiles", menuItems)
+}
+
+func (g *GameStateEditor) deleteMultiSelection() {
+	selection := g.multiSelection
+	if selection.IsEmpty() {
+		return
+	}
+	currentMap := g.engine.GetGame().GetMap()
+	for _, actor := range selection.Actors {
+		if isDownedOnMap(currentMap, actor) {
+			currentMap.RemoveDownedActorFromMap(actor)
+		} else {
+			currentMap.RemoveActor(actor)
+		}
+	}
+	for _, item := range selection.Items {
+		currentMap.RemoveItem(item)
+	}
+	for _, object := range selection.Objects {
+		if r, ok := object.(services.Removable); ok {
+			r.OnRemoved(g.engine)
+		}
+		currentMap.RemoveObjectAt(object.Pos())
+	}
+	g.PrintAsMessage(fmt.Sprintf("Deleted %d actor(s), %d item(s) and %d object(s)", len(selection.Actors), len(selection.Items), len(selection.Objects)))
+	g.multiSelection = nil
+	currentMap.UpdateBakedLights()
+	currentMap.UpdateDynamicLights()
+	g.SetDirty()
+}
+
+func (g *GameStateEditor) moveMultiSelection() {
+	if g.multiSelection.IsEmpty() {
+		return
+	}
+	g.handler = multiSelectUI.WithTextHandler(func(text string) {
+		g.changeUIStateTo(multiSelectUI)
+		offset, err := parseOffset(text)
+		if err != nil {
+			g.PrintAsMessage("ERR: " + err.Error())
+			return
+		}
+		g.moveMultiSelectionBy(offset)
+	})
+	g.showTextInput("Move by (dx dy): ", "")
+}
+
+// moveMultiSelectionBy moves everything selected by offset. Entities are moved
+// front first, so that they don't block each other. Entities whose destination
+// is blocked stay where they are.
+func (g *GameStateEditor) moveMultiSelectionBy(offset geometry.Point) {
+	selection := g.multiSelection
+	currentMap := g.engine.GetGame().GetMap()
+	frontFirst := func(positionOf func(i int) geometry.Point) func(i, j int) bool {
+		return func(i, j int) bool {
+			a, b := positionOf(i), positionOf(j)
+			return a.X*offset.X+a.Y*offset.Y > b.X*offset.X+b.Y*offset.Y
+		}
+	}
+	moved, blocked := 0, 0
+
+	sort.SliceStable(selection.Actors, frontFirst(func(i int) geometry.Point { return selection.Actors[i].Pos() }))
+	for _, actor := range selection.Actors {
+		dest := actor.Pos().Add(offset)
+		if isDownedOnMap(currentMap, actor) {
+			if !currentMap.Contains(dest) || currentMap.IsDownedActorAt(dest) || !currentMap.IsTileWalkable(dest) {
+				blocked++
+				continue
+			}
+			currentMap.MoveDownedActor(actor, dest)
+			moved++
+			continue
+		}
+		if !currentMap.Contains(dest) || currentMap.IsActorAt(dest) || !currentMap.IsCurrentlyPassable(dest) {
+			blocked++
+			continue
+		}
+		currentMap.MoveActor(actor, dest)
+		g.engine.GetAI().CalculateAllTaskPaths(actor)
+		currentMap.UpdateFieldOfView(actor)
+		moved++
+	}
+
+	sort.SliceStable(selection.Items, frontFirst(func(i int) geometry.Point { return selection.Items[i].Pos() }))
+	for _, item := range selection.Items {
+		dest := item.Pos().Add(offset)
+		if !currentMap.Contains(dest) || currentMap.IsItemAt(dest) || !currentMap.IsTileWalkable(dest) {
+			blocked++
+			continue
+		}
+		currentMap.MoveItem(item, dest)
+		moved++
+	}
+
+	sort.SliceStable(selection.Objects, frontFirst(func(i int) geometry.Point { return selection.Objects[i].Pos() }))
+	for _, object := range selection.Objects {
+		dest := object.Pos().Add(offset)
+		if !currentMap.Contains(dest) || currentMap.IsObjectAt(dest) {
+			blocked++
+			continue
+		}
+		currentMap.MoveObject(object, dest)
+		moved++
+	}
+
+	for i, cell := range selection.Cells {
+		selection.Cells[i] = cell.Add(offset)
+	}
+	currentMap.UpdateBakedLights()
+	currentMap.UpdateDynamicLights()
+	if blocked > 0 {
+		g.PrintAsMessage(fmt.Sprintf("Moved %d entities by %s, %d blocked", moved, offset.String(), blocked))
+	} else {
+		g.PrintAsMessage(fmt.Sprintf("Moved %d entities by %s", moved, offset.String()))
+	}
+	g.SetDirty()
+}
+
+// isDownedOnMap is true for actors lying on the map, they are kept apart from the active actors.
+func isDownedOnMap(currentMap *gridmap.GridMap[*core.Actor, *core.Item, services.Object], actor *core.Actor) bool {
+	return currentMap.IsDownedActorAt(actor.Pos()) && currentMap.DownedActorAt(actor.Pos()) == actor
+}
+
+// parseOffset reads an offset like "3 -2" or "3,-2".
+func parseOffset(text string) (geometry.Point, error) {
+	fields := strings.Fields(strings.ReplaceAll(text, ",", " "))
+	if len(fields) != 2 {
+		return geometry.Point{}, fmt.Errorf("expected an offset like '3 -2', got '%s'", text)
+	}
+	dx, err := strconv.Atoi(fields[0])
+	if err != nil {
+		return geometry.Point{}, fmt.Errorf("invalid x offset '%s'", fields[0])
+	}
+	dy, err := strconv.Atoi(fields[1])
+	if err != nil {
+		return geometry.Point{}, fmt.Errorf("invalid y offset '%s'", fields[1])
+	}
+	return geometry.Point{X: dx, Y: dy}, nil
+}
@@ -0,0 +1,15 @@
+package game
+
+import (
+	"github.com/memmaker/terminal-assassin/game/services"
+)
+
+// SetEscalation marks the current mission as a level of the map's escalation contract.
+func (m *Model) SetEscalation(level *services.EscalationLevel) {
+	m.escalation = level
+}
+
+// GetEscalation returns the escalation level that is played or nil for the standard mission.
+func (m *Model) GetEscalation() *services.EscalationLevel {
+	return m.escalation
+}
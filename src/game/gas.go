@@ -0,0 +1,18 @@
+package game
+
+import (
+	"github.com/memmaker/terminal-assassin/game/core"
+)
+
+// UpdateGas advances the gas simulation by one step. Whoever the drifting gas
+// reaches is affected by it, with a force that depends on its concentration.
+func (m *Model) UpdateGas() {
+	currentMap := m.GetMap()
+	update := currentMap.UpdateGas()
+	for p, gasStims := range update.Reached {
+		source := core.NewEffectSourceFromTile(currentMap.GetCell(p).TileType)
+		for _, stim := range gasStims {
+			m.ApplyStimulusToThings(p, source, stim)
+		}
+	}
+}
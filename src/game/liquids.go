@@ -0,0 +1,78 @@
+package game
+
+import (
+	"github.com/memmaker/terminal-assassin/game/ai"
+	"github.com/memmaker/terminal-assassin/game/core"
+	"github.com/memmaker/terminal-assassin/game/stimuli"
+	"github.com/memmaker/terminal-assassin/geometry"
+)
+
+const (
+	slipperyVolume      = 10
+	slipDurationSeconds = 4.0
+	slipNoiseRadius     = 5
+	bloodDropVolume     = 5
+)
+
+// UpdateLiquids advances the liquid simulation by one step. Whatever the liquids
+// reach is affected by them and water next to live electricity becomes live itself.
+func (m *Model) UpdateLiquids() {
+	currentMap := m.GetMap()
+	update := currentMap.UpdateLiquids()
+	for p, liquid := range update.Wetted {
+		source := core.NewEffectSourceFromTile(currentMap.GetCell(p).TileType)
+		m.ApplyStimulusToThings(p, source, currentMap.GetStimAt(p, liquid))
+		if liquid != stimuli.StimulusWater {
+			continue
+		}
+		electroNeighbor := currentMap.GetNeighborWithStim(p, stimuli.StimulusHighVoltage)
+		if electroNeighbor == p || currentMap.IsStimulusOnTile(p, stimuli.StimulusHighVoltage) {
+			continue
+		}
+		electroStim := currentMap.GetStimAt(electroNeighbor, stimuli.StimulusHighVoltage)
+		currentMap.PropagateElectroStimFromWaterTileAt(p, electroStim)
+		electroTiles := currentMap.GetConnected(p, func(q geometry.Point) bool {
+			return currentMap.IsStimulusOnTile(q, stimuli.StimulusWater)
+		})
+		m.engine.GetAnimator().ElectricityAnimation(electroTiles, source, electroStim)
+	}
+}
+
+func (m *Model) isSlipperyAt(p geometry.Point) bool {
+	currentMap := m.GetMap()
+	return currentMap.ForceOfStimulusOnTile(p, stimuli.StimulusWater) >= slipperyVolume ||
+		currentMap.ForceOfStimulusOnTile(p, stimuli.StimulusBurnable) >= slipperyVolume
+}
+
+// Slip lets the person fall to the floor for a few seconds. The fall is audible, less so with the Light Feet skill.
+func (m *Model) Slip(person *core.Actor) {
+	if person.IsDowned() {
+		return
+	}
+	currentMap := m.GetMap()
+	if person == currentMap.Player {
+		person.Slipped = true
+		m.PrintMessage("You slip and fall.")
+	} else {
+		person.AI.PushState(&ai.SlippedState{AIContext: ai.AIContext{Engine: m.engine, Person: person}})
+		currentMap.SetActorToDowned(person)
+	}
+	m.SoundEventAt(person.Pos(), core.ObservationStrangeNoiseHeard, m.movementNoiseRadius(person, slipNoiseRadius))
+	m.engine.ScheduleGameTime(slipDurationSeconds, func() { m.getUp(person) })
+	m.UpdateHUD()
+}
+
+func (m *Model) getUp(person *core.Actor) {
+	if person.Dead || !person.IsSlipped() {
+		return
+	}
+	currentMap := m.GetMap()
+	if person == currentMap.Player {
+		person.Slipped = false
+	} else {
+		person.AI.PopState()
+		currentMap.SetDownedActorToActive(person)
+	}
+	currentMap.UpdateFieldOfView(person)
+	m.UpdateHUD()
+}
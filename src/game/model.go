@@ -24,6 +24,15 @@ type Model struct {
 	currentGameStates []services.GameState
 
 	MissionStats *core.MissionStats
+	objectives   *services.MissionObjectives
+	escalation   *services.EscalationLevel
+	contract     *services.Contract
+	skills       services.PlayerSkills
+	// campaignFlags are set when the mission starts, see SetCampaignFlags.
+	campaignFlags       []string
+	scriptCampaignFlags []string
+	queuedCampaignFlags []string
+	queueCampaignFlags  bool
 
 	oldMousePos geometry.Point
 	playerPos   geometry.Point
@@ -91,6 +100,11 @@ func (m *Model) ResetModel() {
 	m.actions.Reset()
 	m.ClearMap(m.gridMap.MapWidth, m.gridMap.MapHeight)
 	m.MissionStats = core.NewMissionStats()
+	m.objectives = nil
+	m.escalation = nil
+	m.contract = nil
+	m.skills = services.PlayerSkills{}
+	m.SetCampaignFlags(nil, false)
 }
 
 func (m *Model) ResetGameState() {
@@ -197,7 +211,7 @@ func (m *Model) Init(engine services.Engine) {
 		career.PlayerName = "0816"
 		career.CurrentCampaignFolder = "first blood" //TODO: check if this is correct
 		engine.GetGame().PushState(&states.GameStateMainMenu{})
-	} else if engine.GetFiles().FileExists("career.gob") {
+	} else if engine.GetCareer().PlayerName != "" {
 		engine.GetGame().PushState(&states.GameStateMainMenu{})
 	} else {
 		engine.GetGame().PushState(&states.GameStateNewCareer{})
@@ -243,6 +257,10 @@ func (m *Model) ApplyStimulusToTile(atLocation geometry.Point, source core.Effec
 	currentMap := m.GetMap()
 	animator := m.engine.GetAnimator()
 
+	if isTileDamage(stim.Type()) {
+		m.DamageTile(atLocation, stim.Force())
+	}
+
 	switch stim.Type() {
 	case stimuli.StimulusFire:
 		m.ApplyFireToTile(atLocation, source, stim)
@@ -273,7 +291,7 @@ func (m *Model) SoundEventAt(soundLocation geometry.Point, kindOfSound core.Obse
 	currentMap := m.GetMap()
 	animator := m.engine.GetAnimator()
 	aic := m.engine.GetAI()
-	soundTiles := currentMap.WavePropagationFrom(soundLocation, maxDistance, 0)
+	soundTiles := currentMap.SoundPropagationFrom(soundLocation, maxDistance)
 	animator.SoundPropagationAnimation(kindOfSound, soundTiles, func() {
 		for _, tiles := range soundTiles {
 			for _, p := range tiles {
@@ -360,14 +378,84 @@ func (m *Model) ApplyStimulusToThings(atLocation geometry.Point, source core.Eff
 
 func (m *Model) ApplyFireToTile(atLocation geometry.Point, source core.EffectSource, stim stimuli.Stimulus) {
 	gridMap := m.GetMap()
-	// also emit a delayed fire stim to all burnable neighbors
-	if gridMap.IsStimulusOnTile(atLocation, stimuli.StimulusBurnable) {
-		stim = stim.WithForce(80) // spark to flames..
-		gridMap.AddStimulusToTile(atLocation, stim)
-		for _, n := range gridMap.GetFilteredCardinalNeighbors(atLocation, func(p geometry.Point) bool {
-			return gridMap.IsStimulusOnTile(p, stimuli.StimulusBurnable) && !gridMap.IsStimulusOnTile(p, stimuli.StimulusFire)
-		}) {
-			m.ApplyDelayed(n, source, stimuli.StimEffect{Stimuli: []stimuli.Stimulus{stim}}, rng.R.Float64()*0.5)
+	// a spark only catches on something that can burn, the fire simulation does the rest
+	if gridMap.FuelAt(atLocation, m.fuelOfThingsAt) > 0 {
+		gridMap.Ignite(atLocation, stim.Force())
+	}
+}
+
+// UpdateFire advances the fire simulation by one step and applies its consequences
+// to everything on the affected tiles.
+func (m *Model) UpdateFire() {
+	currentMap := m.GetMap()
+	update := currentMap.UpdateFire(m.fuelOfThingsAt)
+	for _, p := range update.Ignited {
+		fireStim := currentMap.GetStimAt(p, stimuli.StimulusFire)
+		m.ApplyStimulusToThings(p, core.NewEffectSourceFromTile(currentMap.GetCell(p).TileType), fireStim)
+	}
+	for _, p := range update.BurntOut {
+		m.burnAway(p)
+	}
+	if len(update.Burning) > 0 {
+		m.triggerFireSuppressors(update.Burning)
+	}
+}
+
+// fuelOfThingsAt is the fuel that objects and bodies add to a fire on their tile.
+func (m *Model) fuelOfThingsAt(p geometry.Point) int {
+	currentMap := m.GetMap()
+	fuel := 0
+	if currentMap.IsObjectAt(p) {
+		if flammable, ok := currentMap.ObjectAt(p).(services.Flammable); ok {
+			fuel += flammable.Fuel()
+		}
+	}
+	if currentMap.IsDownedActorAt(p) {
+		fuel += 30
+	}
+	return fuel
+}
+
+// burnAway removes everything that was consumed by the fire at p.
+func (m *Model) burnAway(p geometry.Point) {
+	currentMap := m.GetMap()
+	currentMap.RemoveStimulusFromTile(p, stimuli.StimulusBurnable)
+	if currentMap.IsObjectAt(p) {
+		objectAt := currentMap.ObjectAt(p)
+		if flammable, ok := objectAt.(services.Flammable); ok && flammable.Fuel() > 0 {
+			if r, isRemovable := objectAt.(services.Removable); isRemovable {
+				r.OnRemoved(m.engine)
+			}
+			currentMap.RemoveObjectAt(p)
+		}
+	}
+	if currentMap.IsDownedActorAt(p) {
+		body := currentMap.DownedActorAt(p)
+		if !body.IsDead() {
+			m.Kill(body, core.NewCauseOfDeath(core.CoDBurned, nil))
+		}
+		currentMap.RemoveDownedActorFromMap(body)
+	}
+	tile := currentMap.GetCell(p).TileType
+	if tile.Fuel > 0 && tile.Special != gridmap.SpecialTileDefaultFloor {
+		currentMap.SetTile(p, m.engine.GetData().GroundTile())
+	}
+	m.UpdateAllFoVsFrom(p)
+}
+
+func (m *Model) triggerFireSuppressors(burning []geometry.Point) {
+	currentMap := m.GetMap()
+	for _, object := range currentMap.Objects() {
+		suppressor, ok := object.(services.FireSuppressor)
+		if !ok || !suppressor.IsReadyToSuppress() {
+			continue
+		}
+		rangeSquared := suppressor.SuppressionRange() * suppressor.SuppressionRange()
+		for _, p := range burning {
+			if geometry.DistanceSquared(p, object.Pos()) <= rangeSquared {
+				suppressor.SuppressFire(m.engine)
+				break
+			}
 		}
 	}
 }
@@ -404,20 +492,9 @@ func (m *Model) Apply(atLocation geometry.Point, source core.EffectSource, effec
 
 func (m *Model) ApplyBurnableToTile(atLocation geometry.Point, source core.EffectSource, stim stimuli.Stimulus) {
 	currentMap := m.GetMap()
-	// if any neighbor is on fire, emit a delayed fire stim to this tile
+	// burning neighbours will heat up and ignite the spilled fuel
 	if !currentMap.IsStimulusOnTile(atLocation, stimuli.StimulusWater) {
 		currentMap.AddStimulusToTile(atLocation, stim)
-		for _, n := range currentMap.GetFilteredCardinalNeighbors(atLocation, func(p geometry.Point) bool {
-			return currentMap.IsStimulusOnTile(p, stimuli.StimulusFire)
-		}) {
-			m.engine.ScheduleGameTime(rng.R.Float64()*0.5, func() {
-				fireForce := currentMap.ForceOfStimulusOnTile(n, stimuli.StimulusFire)
-				currentMap.AddStimulusToTile(n, stimuli.Stim{StimType: stimuli.StimulusFire, StimForce: fireForce})
-			})
-
-			m.ApplyDelayed(atLocation, source, stimuli.StimEffect{Stimuli: []stimuli.Stimulus{stimuli.Stim{StimType: stimuli.StimulusFire, StimForce: currentMap.ForceOfStimulusOnTile(n, stimuli.StimulusFire)}}}, rng.R.Float64()*0.5)
-			break
-		}
 	}
 }
 
@@ -675,9 +752,13 @@ func (m *Model) SendToSleep(sleeper *core.Actor) {
 			sleeper.Sleeping = false
 		})
 	} else {
+		if sleeper.IsSlipped() {
+			sleeper.AI.PopState()
+		}
 		sleeper.AI.PushState(&ai.SleepingState{AIContext: ai.AIContext{Engine: m.engine, Person: sleeper}})
 		currentMap.SetActorToDowned(sleeper)
 		m.DropInventory(sleeper)
+		m.engine.PublishEvent(services.ActorKnockedOutEvent{Victim: sleeper})
 	}
 }
 
@@ -707,12 +788,18 @@ func (m *Model) ActorEnteredCell(person *core.Actor, oldPosition geometry.Point,
 		m.handleDeathTile(person, newCell)
 		return
 	}
+	if newCell.TileType.Special == gridmap.SpecialTileShards {
+		m.stepOnShards(person, newPosition)
+	}
+	if person.IsRunning() && m.isSlipperyAt(newPosition) {
+		m.Slip(person)
+	}
 
 	if person.EquippedItem != nil {
 		m.SendTriggerStimuli(person, person.EquippedItem, newPosition, core.TriggerOnTakenToNewCell)
 	}
-	if person.IsBleeding() && !person.IsBodyBagged && rng.R.Float64() < 0.1 {
-		currentMap.AddStimulusToTile(person.Pos(), stimuli.Stim{StimType: stimuli.StimulusBlood, StimForce: 5})
+	if person.IsBleeding() && !person.IsBodyBagged && rng.R.Float64() < 0.5 {
+		currentMap.AddBloodDrop(person.Pos(), bloodDropVolume)
 	}
 	if person == currentMap.Player {
 		m.playerEnteredCell(oldPosition, newPosition)
@@ -770,6 +857,7 @@ func (m *Model) handleDeathTile(person *core.Actor, deathCell gridmap.MapCell[*c
 func (m *Model) playerEnteredCell(oldPosition geometry.Point, newPosition geometry.Point) {
 	m.tryCleaning(oldPosition)
 	m.tryItemSneakingStimuli(oldPosition)
+	m.engine.PublishEvent(services.PlayerMovedEvent{OldPosition: oldPosition, NewPosition: newPosition})
 }
 
 func (m *Model) handleDragging(dragger *core.Actor, oldPosition geometry.Point) {
@@ -783,10 +871,10 @@ func (m *Model) handleDragging(dragger *core.Actor, oldPosition geometry.Point)
 	isSneaking := dragger.MovementMode == core.MovementModeSneaking
 	isWalking := dragger.MovementMode == core.MovementModeWalking
 	hasPianoWire := dragger.EquippedItem != nil && dragger.EquippedItem.Type == core.ItemTypePianoWire
-	canDrag := isSneaking || (isWalking && hasPianoWire)
+	canDrag := isSneaking || (isWalking && (hasPianoWire || m.canDragWhileWalking(dragger)))
 	noBigItem := dragger.EquippedItem == nil || !dragger.EquippedItem.IsBig
 
-	if !canDrag && dragger.IsDraggingBody() { // stop dragging when not sneaking (or walking with piano wire)
+	if !canDrag && dragger.IsDraggingBody() { // stop dragging when not sneaking (or walking with piano wire or a strong back)
 		dragger.DraggedBody = nil
 		return
 	}
@@ -1068,6 +1156,8 @@ var tileActions = map[gridmap.SpecialTileType]services.ContextAction{
 	gridmap.SpecialTilePlayerExit:      ExitAction{},
 	gridmap.SpecialTileTypeFood:        PoisonAction{},
 	gridmap.SpecialTileTypePowerOutlet: ExposeElectricityAction{},
+	gridmap.SpecialTileStairs:          ChangeFloorAction{Icon: core.GlyphStairs},
+	gridmap.SpecialTileElevator:        ChangeFloorAction{Icon: core.GlyphElevator},
 }
 
 func (m *Model) GetContextActionAt(position geometry.Point) services.ContextAction {
@@ -1171,6 +1261,9 @@ func (m *Model) UpdateKnowledgeFromVision(person *core.Actor) {
 
 		dangerObservation := m.GetDangerObservation(person, actorAt)
 		if dangerObservation != core.ObservationNull {
+			if !person.IsEyeWitness {
+				m.engine.PublishEvent(services.ActorAlertedEvent{Actor: person})
+			}
 			person.IsEyeWitness = true
 			a.Knowledge.AddDangerousSighting(person, actorAt, dangerObservation, m.engine.CurrentGameTime())
 			if actorAt.IsPlayer() {
@@ -1204,7 +1297,7 @@ func (m *Model) GetSuspicionObservation(person *core.Actor, susActor *core.Actor
 	if aic.IsNearActiveIllegalIncident(person, susActor.Pos()) {
 		return core.ObservationNearActiveIllegalIncident
 	}
-	if currentMap.IsTrespassing(susActor) {
+	if currentMap.IsTrespassing(susActor) && !m.blendsIn(person, susActor) {
 		return core.ObservationTrespassing
 	}
 
@@ -1231,7 +1324,7 @@ func (m *Model) createIncidentsForSuspiciousActivity(person *core.Actor, p geome
 	isDownedActorHere := currentMap.IsDownedActorAt(p)
 	if isDownedActorHere {
 		downedActorAt := currentMap.DownedActorAt(p)
-		if !downedActorAt.IsBodyBagged {
+		if !downedActorAt.IsBodyBagged && !downedActorAt.IsSlipped() {
 			m.engine.PublishEvent(services.BodyDiscoveredEvent{Discoverer: person, BodyPos: p})
 			aic.HandleIncident(person, core.IncidentReport{Type: core.ObservationBodyFound, Location: p, Time: m.engine.CurrentGameTime()})
 		}
@@ -1282,6 +1375,8 @@ func (m *Model) InitLoadedMap(loadedMap *gridmap.GridMap[*core.Actor, *core.Item
 	}
 	// set ambient light from time of day
 	m.gridMap.SetAmbientLight(common.GetAmbientLightFromDayTime(m.gridMap.TimeOfDay).ToRGB())
+	// switch off everything on circuits that start without power
+	m.UpdatePowerGrid()
 }
 
 func (m *Model) InitActor(a *core.Actor) {
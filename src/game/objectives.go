@@ -0,0 +1,29 @@
+package game
+
+import (
+	"github.com/memmaker/terminal-assassin/game/services"
+)
+
+// LoadObjectives reads the objectives of the current map. Call it after the player was spawned.
+func (m *Model) LoadObjectives() {
+	m.objectives = services.LoadMissionObjectives(m.engine)
+}
+
+func (m *Model) GetObjectives() *services.MissionObjectives {
+	return m.objectives
+}
+
+// UpdateObjectives evaluates the open mission objectives and announces the completed ones.
+func (m *Model) UpdateObjectives() {
+	if m.objectives == nil {
+		return
+	}
+	completed := m.objectives.Update()
+	if len(completed) == 0 {
+		return
+	}
+	for _, objective := range completed {
+		m.PrintMessage("Objective completed: " + objective.Title)
+	}
+	m.UpdateHUD()
+}
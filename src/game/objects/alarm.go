@@ -88,3 +88,4 @@ func (a *AlarmObject) EncodeAsString() string        { return a.Name }
 func (a *AlarmObject) IsWalkable(*core.Actor) bool   { return false }
 func (a *AlarmObject) IsTransparent() bool           { return true }
 func (a *AlarmObject) IsPassableForProjectile() bool { return false }
+func (a *AlarmObject) IsBroken() bool                { return a.state == AlarmStateBroken }
@@ -74,6 +74,7 @@ func (cc *CorpseContainer) PutDraggedBodyInContainer(m services.Engine, holder *
 	cc.ContainedActor = actorToContain
 	cc.ContainedActor.IsHidden = true
 	currentMap.MoveDownedActor(actorToContain, cc.Pos())
+	m.PublishEvent(services.BodyHiddenEvent{Body: actorToContain, Container: cc.Pos()})
 }
 
 func (cc *CorpseContainer) IsActionAllowed(m services.Engine, person *core.Actor) bool {
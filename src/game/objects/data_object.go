@@ -0,0 +1,218 @@
+package objects
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/memmaker/terminal-assassin/common"
+	"github.com/memmaker/terminal-assassin/game/core"
+	"github.com/memmaker/terminal-assassin/game/services"
+	"github.com/memmaker/terminal-assassin/game/stimuli"
+	"github.com/memmaker/terminal-assassin/geometry"
+)
+
+// DataObject is a map object whose looks and behaviour come from an
+// objects.txt definition. It is a small state machine: actions of persons
+// and reactions to stimuli move it from one state to another and apply effects.
+type DataObject struct {
+	position         geometry.Point
+	definition       services.ObjectDefinition
+	State            string
+	Locked           bool
+	KeyString        string
+	Difficulty       core.LockDifficulty
+	ContentItemNames []string
+}
+
+// NewDataObject creates an object from its definition. Only lockable objects
+// have a key and a lock difficulty and only containers have contents.
+func NewDataObject(definition services.ObjectDefinition) services.Object {
+	object := &DataObject{
+		definition: definition,
+		State:      definition.InitialState,
+		Locked:     definition.HasLock,
+		Difficulty: definition.LockDifficulty,
+	}
+	switch {
+	case definition.HasLock && definition.Container:
+		return &lockableDataContainer{lockableDataObject{object}}
+	case definition.HasLock:
+		return &lockableDataObject{object}
+	case definition.Container:
+		return &dataContainer{object}
+	}
+	return object
+}
+
+type lockableDataObject struct{ *DataObject }
+
+func (o *lockableDataObject) GetKey() string                          { return o.KeyString }
+func (o *lockableDataObject) SetKey(key string)                       { o.KeyString = key }
+func (o *lockableDataObject) GetLockDifficulty() core.LockDifficulty  { return o.Difficulty }
+func (o *lockableDataObject) SetLockDifficulty(d core.LockDifficulty) { o.Difficulty = d }
+
+type dataContainer struct{ *DataObject }
+
+func (o *dataContainer) GetContents() []string  { return o.ContentItemNames }
+func (o *dataContainer) SetContents(c []string) { o.ContentItemNames = c }
+
+type lockableDataContainer struct{ lockableDataObject }
+
+func (o *lockableDataContainer) GetContents() []string  { return o.ContentItemNames }
+func (o *lockableDataContainer) SetContents(c []string) { o.ContentItemNames = c }
+
+func (d *DataObject) currentState() services.ObjectStateDefinition {
+	return d.definition.States[d.State]
+}
+
+// ---- services.Object ----
+
+func (d *DataObject) Action(m services.Engine, person *core.Actor) {
+	if d.Locked {
+		d.unlock(m, person)
+		return
+	}
+	for _, action := range d.definition.Actions {
+		if action.IsPossibleIn(d.State) {
+			d.transition(m, person, action)
+			return
+		}
+	}
+}
+
+func (d *DataObject) IsActionAllowed(_ services.Engine, _ *core.Actor) bool {
+	if d.Locked {
+		return true
+	}
+	for _, action := range d.definition.Actions {
+		if action.IsPossibleIn(d.State) {
+			return true
+		}
+	}
+	return false
+}
+
+func (d *DataObject) ApplyStimulus(m services.Engine, stim stimuli.Stimulus) {
+	for _, reaction := range d.definition.Reactions {
+		if reaction.Stimulus != stim.Type() || stim.Force() < reaction.Threshold {
+			continue
+		}
+		if !reaction.IsPossibleIn(d.State) || reaction.To == d.State {
+			continue
+		}
+		d.transition(m, nil, reaction.ObjectTransitionDefinition)
+		return
+	}
+}
+
+func (d *DataObject) unlock(m services.Engine, person *core.Actor) {
+	unlock := func() { d.Locked = false }
+	switch {
+	case isUnlockableWithKeyFrom(d.definition.LockType, d.KeyString, person):
+		d.Locked = false
+		m.GetGame().PrintMessage("Unlocked.")
+	case d.definition.LockType == core.LockTypeMechanical:
+		performMechanicalPickLock(m, person, d.position, d.Difficulty, unlock, unlock)
+	case d.definition.LockType == core.LockTypeElectronic:
+		performElectronicPickLock(m, person, d.position, d.Difficulty, unlock)
+	}
+}
+
+// transition moves the object into the next state and applies the effects.
+// The person is nil for reactions to stimuli.
+func (d *DataObject) transition(m services.Engine, person *core.Actor, transition services.ObjectTransitionDefinition) {
+	wasTransparent := d.IsTransparent()
+	d.State = transition.To
+	for _, effect := range transition.Effects {
+		d.applyEffect(m, person, effect)
+	}
+	if wasTransparent != d.IsTransparent() {
+		m.GetGame().UpdateAllFoVsFrom(d.position)
+	}
+}
+
+func (d *DataObject) applyEffect(m services.Engine, person *core.Actor, effect services.ObjectEffectDefinition) {
+	game := m.GetGame()
+	switch effect.Kind {
+	case "Message":
+		if person == nil || person == game.GetMap().Player {
+			game.PrintMessage(effect.Argument)
+		}
+	case "DispenseItem":
+		item := services.NewFactory(m).DecodeStringToItem(effect.Argument)
+		game.PlaceItem(d.position, &item)
+	case "Search":
+		if person != nil {
+			d.search(m, person)
+		}
+	case "Stims":
+		target := d.position
+		source := core.NewEffectSourceFromObject(d)
+		if person != nil {
+			source = core.NewEffectSourceFromUsedObject(person, d)
+			if effect.Stims.Distribution == stimuli.DistributeDirect {
+				target = person.Pos()
+			}
+		}
+		game.Apply(target, source, effect.Stims)
+	case "Noise":
+		radius, _ := strconv.Atoi(effect.Argument)
+		game.SoundEventAt(d.position, core.ObservationStrangeNoiseHeard, radius)
+	case "Illegal":
+		game.IllegalActionAt(d.position, core.ObservationIllegalAction)
+	}
+}
+
+// search starts the 3-second search animation and transfers the contents on completion.
+func (d *DataObject) search(m services.Engine, person *core.Actor) {
+	done := false
+	m.GetAI().SetEngrossed(person, func() bool { return done })
+	m.GetAnimator().ActorEngagedAnimationWithCancel(person, core.GlyphEmptyHand, d.position, 3.0, func() {
+		done = true
+		d.transferContentsToInventory(m, person)
+	}, func() {
+		done = true
+	})
+}
+
+func (d *DataObject) transferContentsToInventory(m services.Engine, person *core.Actor) {
+	game := m.GetGame()
+	if len(d.ContentItemNames) == 0 {
+		game.PrintMessage("Nothing here.")
+		return
+	}
+	factory := services.NewFactory(m)
+	for _, name := range d.ContentItemNames {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		item := factory.DecodeStringToItem(name)
+		game.PlaceItem(d.position, &item)
+		game.PickUpItemAt(person, d.position)
+	}
+	d.ContentItemNames = nil
+	game.PrintMessage("You search the " + d.definition.Name + " and take the contents.")
+}
+
+func (d *DataObject) Description() string {
+	if d.Locked {
+		return d.currentState().Description + " (locked)"
+	}
+	return d.currentState().Description
+}
+
+func (d *DataObject) Style(st common.Style) common.Style {
+	return common.Style{Foreground: core.CurrentTheme.ObjectForeground, Background: st.Background}
+}
+
+// Fuel implements services.Flammable.
+func (d *DataObject) Fuel() int { return d.definition.Fuel }
+
+func (d *DataObject) Icon() rune                    { return d.currentState().Icon }
+func (d *DataObject) Pos() geometry.Point           { return d.position }
+func (d *DataObject) SetPos(p geometry.Point)       { d.position = p }
+func (d *DataObject) EncodeAsString() string        { return d.definition.Name }
+func (d *DataObject) IsWalkable(*core.Actor) bool   { return d.currentState().Walkable }
+func (d *DataObject) IsTransparent() bool           { return d.currentState().Transparent }
+func (d *DataObject) IsPassableForProjectile() bool { return d.currentState().ProjectilePassable }
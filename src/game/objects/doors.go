@@ -64,6 +64,45 @@ type Door struct {
 	DamageThreshold int
 	Difficulty      core.LockDifficulty
 	uniqueName      string
+	Circuit         string
+	FailOpen        bool
+	unpowered       bool
+	// releasedByOutage is set when a fail-open lock was released by a power cut
+	releasedByOutage bool
+}
+
+// ---- services.Wired ----
+
+func (d *Door) GetCircuit() string        { return d.Circuit }
+func (d *Door) SetCircuit(circuit string) { d.Circuit = circuit }
+
+// SetPowered releases fail-open electronic locks during a power outage
+// and locks them again once the power is back and the door is closed.
+func (d *Door) SetPowered(_ services.Engine, powered bool) {
+	if d.Type != DoorTypeElectronic || d.unpowered == !powered {
+		return
+	}
+	d.unpowered = !powered
+	switch {
+	case !powered && d.FailOpen && d.State == DoorStateLocked:
+		d.State = DoorStateClosed
+		d.releasedByOutage = true
+	case powered && d.releasedByOutage:
+		if d.State == DoorStateClosed {
+			d.State = DoorStateLocked
+		}
+		d.releasedByOutage = false
+	}
+}
+
+// ---- services.FailSafeLock ----
+
+func (d *Door) IsFailOpen() bool          { return d.FailOpen }
+func (d *Door) SetFailOpen(failOpen bool) { d.FailOpen = failOpen }
+
+// isLockWithoutPower is true for electronic locks that cannot be operated during a power outage.
+func (d *Door) isLockWithoutPower() bool {
+	return d.Type == DoorTypeElectronic && d.unpowered && d.State == DoorStateLocked
 }
 
 // ---- services.LockDifficultyHolder ----
@@ -110,6 +149,14 @@ func (d *Door) ApplyStimulus(m services.Engine, stim stimuli.Stimulus) {
 	}
 }
 
+// Fuel is only provided by wooden doors, electronic doors don't burn.
+func (d *Door) Fuel() int {
+	if d.Type == DoorTypeMechanic || d.IsBurnable {
+		return 40
+	}
+	return 0
+}
+
 func (d *Door) Pos() geometry.Point {
 	return d.position
 }
@@ -119,7 +166,7 @@ func (d *Door) SetPos(pos geometry.Point) {
 }
 
 func (d *Door) IsWalkable(person *core.Actor) bool {
-	if d.State == DoorStateLocked && d.IsUnlockableWithKeyFrom(person) {
+	if d.State == DoorStateLocked && d.IsUnlockableWithKeyFrom(person) && !d.isLockWithoutPower() {
 		return true
 	}
 	return d.State != DoorStateLocked
@@ -128,6 +175,11 @@ func (d *Door) IsWalkable(person *core.Actor) bool {
 func (d *Door) IsTransparent() bool {
 	return d.State == DoorStateOpen
 }
+
+// BlocksGas is true unless the door is open.
+func (d *Door) BlocksGas() bool {
+	return d.State != DoorStateOpen
+}
 func (d *Door) IsPassableForProjectile() bool {
 	return true
 }
@@ -139,8 +191,14 @@ func (d *Door) ActionDescription() string {
 func (d *Door) Action(m services.Engine, person *core.Actor) {
 	game := m.GetGame()
 	unlockDoor := func() { d.State = DoorStateClosed }
+	pickDoor := func() {
+		unlockDoor()
+		m.PublishEvent(services.DoorLockpickedEvent{Picker: person, DoorPos: d.Pos()})
+	}
 	breakDoor := func() { d.State = DoorStateOpen; game.UpdateAllFoVsFrom(d.Pos()) }
 	switch {
+	case d.isLockWithoutPower():
+		game.PrintMessage("The lock has no power.")
 	case d.State == DoorStateLocked && d.Keypad:
 		m.GetUI().ShowTextInput("Enter code: ", "", func(code string) {
 			if code == d.KeyString {
@@ -154,9 +212,9 @@ func (d *Door) Action(m services.Engine, person *core.Actor) {
 	case d.State == DoorStateLocked && person.HasKeyCardInInventory(d.KeyString) && d.Type == DoorTypeElectronic:
 		d.State = DoorStateClosed
 	case d.State == DoorStateLocked && d.Type == DoorTypeMechanic:
-		performMechanicalPickLock(m, person, d.Pos(), d.Difficulty, unlockDoor, breakDoor)
+		performMechanicalPickLock(m, person, d.Pos(), d.Difficulty, pickDoor, breakDoor)
 	case d.State == DoorStateLocked && d.Type == DoorTypeElectronic:
-		performElectronicPickLock(m, person, d.Pos(), d.Difficulty, unlockDoor)
+		performElectronicPickLock(m, person, d.Pos(), d.Difficulty, pickDoor)
 	case d.State == DoorStateOpen:
 		d.State = DoorStateClosed
 		game.UpdateAllFoVsFrom(d.Pos())
@@ -0,0 +1,127 @@
+package objects
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/memmaker/terminal-assassin/common"
+	"github.com/memmaker/terminal-assassin/game/core"
+	"github.com/memmaker/terminal-assassin/game/services"
+	"github.com/memmaker/terminal-assassin/game/stimuli"
+	"github.com/memmaker/terminal-assassin/geometry"
+)
+
+// FuseBox holds the breaker of a circuit. Cutting the power switches off
+// everything on the circuit and the circuits it feeds. A fuse box without a
+// named circuit switches the power of its own zone. The nearest guard
+// will come and investigate the outage.
+type FuseBox struct {
+	position geometry.Point
+	icon     rune
+	Name     string
+	Circuit  string
+	tripped  bool
+	broken   bool
+}
+
+func NewFuseBox(name string, icon rune) *FuseBox {
+	return &FuseBox{Name: name, icon: icon}
+}
+
+// ---- services.CircuitBreaker ----
+
+func (f *FuseBox) GetCircuit() string                   { return f.Circuit }
+func (f *FuseBox) SetCircuit(circuit string)            { f.Circuit = circuit }
+func (f *FuseBox) SetPowered(_ services.Engine, _ bool) {}
+func (f *FuseBox) IsBreakerClosed() bool                { return !f.tripped && !f.broken }
+func (f *FuseBox) IsBroken() bool                       { return f.broken }
+
+func (f *FuseBox) setBreaker(m services.Engine, closed bool) {
+	wasClosed := f.IsBreakerClosed()
+	f.tripped = !closed
+	if wasClosed != f.IsBreakerClosed() {
+		f.onBreakerSwitched(m)
+	}
+}
+
+func (f *FuseBox) onBreakerSwitched(m services.Engine) {
+	m.GetGame().UpdatePowerGrid()
+	if !f.IsBreakerClosed() {
+		f.sendGuardToInvestigate(m)
+	}
+}
+
+func (f *FuseBox) sendGuardToInvestigate(m services.Engine) {
+	var nearestGuard *core.Actor
+	minDist := math.MaxInt
+	for _, actor := range m.GetGame().GetMap().Actors() {
+		if !actor.IsAvailableGuard() || !actor.CanBeDistracted() {
+			continue
+		}
+		dist := geometry.DistanceManhattan(actor.Pos(), f.position)
+		if dist < minDist {
+			minDist = dist
+			nearestGuard = actor
+		}
+	}
+	if nearestGuard == nil {
+		return
+	}
+	m.GetAI().SwitchToInvestigation(nearestGuard, core.IncidentReport{Type: core.ObservationDeviceDistraction, Location: f.position, Time: m.CurrentGameTime()})
+}
+
+// ---- services.Object ----
+
+func (f *FuseBox) Action(m services.Engine, _ *core.Actor) {
+	f.setBreaker(m, f.tripped)
+	if f.IsBreakerClosed() {
+		m.GetGame().PrintMessage(fmt.Sprintf("You restore the power of %s.", f.circuitName()))
+	} else {
+		m.GetGame().PrintMessage(fmt.Sprintf("You cut the power of %s.", f.circuitName()))
+	}
+}
+
+// IsActionAllowed is false for a fuse box that switches nothing: it has no
+// named circuit and stands outside of any zone.
+func (f *FuseBox) IsActionAllowed(m services.Engine, _ *core.Actor) bool {
+	return !f.broken && (f.Circuit != "" || m.GetGame().GetMap().ZoneAt(f.position) != nil)
+}
+
+func (f *FuseBox) ApplyStimulus(m services.Engine, stim stimuli.Stimulus) {
+	switch stim.Type() {
+	case stimuli.StimulusPiercingDamage, stimuli.StimulusBluntDamage, stimuli.StimulusExplosionDamage, stimuli.StimulusWater:
+		if !f.broken {
+			wasClosed := f.IsBreakerClosed()
+			f.broken = true
+			if wasClosed {
+				f.onBreakerSwitched(m)
+			}
+		}
+	}
+}
+
+func (f *FuseBox) circuitName() string {
+	if f.Circuit == "" {
+		return "the fuse box"
+	}
+	return fmt.Sprintf("circuit '%s'", f.Circuit)
+}
+
+func (f *FuseBox) Style(st common.Style) common.Style {
+	fg := core.CurrentTheme.DeviceOnForeground
+	if f.broken {
+		fg = core.CurrentTheme.DeviceBrokenForeground
+	} else if f.tripped {
+		fg = core.CurrentTheme.ObjectForeground
+	}
+	return common.Style{Foreground: fg, Background: st.Background}
+}
+
+func (f *FuseBox) Icon() rune                    { return f.icon }
+func (f *FuseBox) Pos() geometry.Point           { return f.position }
+func (f *FuseBox) SetPos(p geometry.Point)       { f.position = p }
+func (f *FuseBox) Description() string           { return f.Name }
+func (f *FuseBox) EncodeAsString() string        { return f.Name }
+func (f *FuseBox) IsWalkable(*core.Actor) bool   { return false }
+func (f *FuseBox) IsTransparent() bool           { return true }
+func (f *FuseBox) IsPassableForProjectile() bool { return false }
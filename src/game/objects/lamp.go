@@ -15,6 +15,8 @@ type Lamp struct {
 	state       DeviceState
 	engine      services.Engine
 	lightSource *gridmap.LightSource
+	Circuit     string
+	unpowered   bool
 }
 
 func CreateLamp(engine services.Engine, description string) *Lamp {
@@ -54,7 +56,7 @@ func (l *Lamp) ApplyStimulus(m services.Engine, stim stimuli.Stimulus) {
 
 func (l *Lamp) Icon() rune {
 	// Lazy initialization: add light if not yet initialized
-	if l.lightSource == nil && l.state == DeviceStateOn && l.engine != nil && l.position.X != 0 && l.position.Y != 0 {
+	if l.lightSource == nil && l.isLit() && l.engine != nil && l.position.X != 0 && l.position.Y != 0 {
 		l.addLightToMap(l.engine)
 	}
 	return core.GlyphStreetLight
@@ -62,7 +64,7 @@ func (l *Lamp) Icon() rune {
 
 func (l *Lamp) Style(st common.Style) common.Style {
 	st = common.Style{Foreground: core.CurrentTheme.ObjectForeground, Background: st.Background}
-	if l.state == DeviceStateOn {
+	if l.isLit() {
 		st = st.WithFg(core.CurrentTheme.DeviceOnForeground)
 	} else if l.state == DeviceStateBroken {
 		st = st.WithFg(core.CurrentTheme.DeviceBrokenForeground)
@@ -76,7 +78,9 @@ func (l *Lamp) Action(m services.Engine, person *core.Actor) {
 	}
 	if l.state == DeviceStateOff {
 		l.state = DeviceStateOn
-		l.addLightToMap(m)
+		if l.isLit() {
+			l.addLightToMap(m)
+		}
 	} else {
 		l.state = DeviceStateOff
 		l.removeLightFromMap(m)
@@ -108,6 +112,32 @@ func (l *Lamp) SetPos(pos geometry.Point) {
 	l.position = pos
 }
 
+// ---- services.Wired ----
+
+func (l *Lamp) GetCircuit() string        { return l.Circuit }
+func (l *Lamp) SetCircuit(circuit string) { l.Circuit = circuit }
+
+// SetPowered switches the light off during a power outage, the switch itself keeps its state.
+func (l *Lamp) SetPowered(m services.Engine, powered bool) {
+	if l.unpowered == !powered {
+		return
+	}
+	l.unpowered = !powered
+	currentMap := m.GetGame().GetMap()
+	if !powered {
+		currentMap.SwitchOffDynamicLightAt(l.position)
+		return
+	}
+	if l.isLit() && !currentMap.SwitchOnDynamicLightAt(l.position) {
+		l.lightSource = nil
+		l.addLightToMap(m)
+	}
+}
+
+func (l *Lamp) isLit() bool {
+	return l.state == DeviceStateOn && !l.unpowered
+}
+
 // OnRemoved implements services.Removable — cleans up the dynamic light when
 // the lamp object is deleted from the map (e.g. in the editor).
 func (l *Lamp) OnRemoved(m services.Engine) {
@@ -116,7 +146,7 @@ func (l *Lamp) OnRemoved(m services.Engine) {
 
 // Initialize should be called after the lamp is placed and SetPos has been called
 func (l *Lamp) Initialize() {
-	if l.state == DeviceStateOn && l.engine != nil && l.lightSource == nil {
+	if l.isLit() && l.engine != nil && l.lightSource == nil {
 		l.addLightToMap(l.engine)
 	}
 }
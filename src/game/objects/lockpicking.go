@@ -62,7 +62,7 @@ func performMechanicalPickLock(
 
 	switch {
 	case hasPicks:
-		pickTime := difficulty.PickTime()
+		pickTime := pickTimeFor(m, person, difficulty)
 		done := false
 		aic.SetEngrossed(person, func() bool { return done })
 		game.IllegalActionAt(lockPos, core.ObservationIllegalAction)
@@ -75,7 +75,7 @@ func performMechanicalPickLock(
 		})
 
 	case hasCrowbar:
-		crowbarTime := difficulty.PickTime() * 3
+		crowbarTime := pickTimeFor(m, person, difficulty) * 3
 		game.SoundEventAt(lockPos, core.ObservationMeleeNoises, 20)
 		game.IllegalActionAt(lockPos, core.ObservationIllegalAction)
 		done := false
@@ -116,7 +116,7 @@ func performElectronicPickLock(
 		return
 	}
 
-	pickTime := difficulty.PickTime()
+	pickTime := pickTimeFor(m, person, difficulty)
 	done := false
 	aic.SetEngrossed(person, func() bool { return done })
 	game.IllegalActionAt(lockPos, core.ObservationIllegalAction)
@@ -128,3 +128,12 @@ func performElectronicPickLock(
 		done = true
 	})
 }
+
+// pickTimeFor returns the seconds the person needs to open the lock, the Quick Picks skill makes the player faster.
+func pickTimeFor(m services.Engine, person *core.Actor, difficulty core.LockDifficulty) float64 {
+	pickTime := difficulty.PickTime()
+	if person.IsPlayer() && m.GetGame().GetPlayerSkills().QuickPicks {
+		pickTime *= services.SkillQuickPicksFactor
+	}
+	return pickTime
+}
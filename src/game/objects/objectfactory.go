@@ -1,6 +1,7 @@
 package objects
 
 import (
+	"path"
 	"strings"
 
 	"github.com/memmaker/terminal-assassin/game/core"
@@ -34,13 +35,17 @@ func NewFactory(engine services.Engine) *ObjectFactory {
 }
 
 type ObjectFactory struct {
-	engine     services.Engine
-	factoryMap map[string]services.ObjectCreator
+	engine services.Engine
+	// factoryMap is built for the objects of factoryMapCampaign and rebuilt when the campaign changes.
+	factoryMap         map[string]services.ObjectCreator
+	factoryMapCampaign string
 }
 
-func (f ObjectFactory) getFactoryMap() map[string]services.ObjectCreator {
-	if f.factoryMap == nil {
+func (f *ObjectFactory) getFactoryMap() map[string]services.ObjectCreator {
+	campaignDir := f.campaignDirectory()
+	if f.factoryMap == nil || f.factoryMapCampaign != campaignDir {
 		f.factoryMap = make(map[string]services.ObjectCreator)
+		f.factoryMapCampaign = campaignDir
 		for _, v := range f.SimpleObjects() {
 			f.factoryMap[v.Name] = v
 		}
@@ -48,7 +53,11 @@ func (f ObjectFactory) getFactoryMap() map[string]services.ObjectCreator {
 	return f.factoryMap
 }
 
-func (f ObjectFactory) NewObjectFromName(name string) services.Object {
+func (f ObjectFactory) campaignDirectory() string {
+	return path.Join(f.engine.GetGame().GetConfig().CampaignDirectory, f.engine.GetCareer().CurrentCampaignFolder)
+}
+
+func (f *ObjectFactory) NewObjectFromName(name string) services.Object {
 	if strings.HasPrefix(name, gravestonePrefix) {
 		return GravestoneFromEncoded(name)
 	}
@@ -60,8 +69,56 @@ func (f ObjectFactory) NewObjectFromName(name string) services.Object {
 	return producer.Create(name)
 }
 
+// SimpleObjects lists the built-in objects followed by the objects defined in data files.
+// Data defined objects replace built-in objects with the same name.
 func (f ObjectFactory) SimpleObjects() []services.ObjectCreator {
+	creators := append(f.builtinObjects(), f.riggableObjects()...)
+	return append(creators, f.dataObjects()...)
+}
+
+// dataObjects are the objects defined in the objects.txt files of the core data and the current campaign.
+func (f ObjectFactory) dataObjects() []services.ObjectCreator {
+	definitions := f.engine.GetData().ObjectDefinitions(f.campaignDirectory())
+	creators := make([]services.ObjectCreator, 0, len(definitions))
+	for _, d := range definitions {
+		definition := d
+		creators = append(creators, services.ObjectCreator{
+			Name: definition.Name,
+			Icon: definition.Icon,
+			Create: func(name string) services.Object {
+				return NewDataObject(definition)
+			},
+		})
+	}
+	return creators
+}
+
+// riggableObjects are the appliances defined in the riggables.txt data files.
+func (f ObjectFactory) riggableObjects() []services.ObjectCreator {
+	definitions := f.engine.GetData().Riggables()
+	creators := make([]services.ObjectCreator, 0, len(definitions))
+	for _, d := range definitions {
+		definition := d
+		creators = append(creators, services.ObjectCreator{
+			Name: definition.Name,
+			Icon: definition.Icon,
+			Create: func(name string) services.Object {
+				return NewRiggableObject(definition)
+			},
+		})
+	}
+	return creators
+}
+
+func (f ObjectFactory) builtinObjects() []services.ObjectCreator {
 	return []services.ObjectCreator{
+		{
+			Name: "fuse box",
+			Icon: core.GlyphPowerBox,
+			Create: func(name string) services.Object {
+				return NewFuseBox(name, core.GlyphPowerBox)
+			},
+		},
 		{
 			Name: "radio (distractor)",
 			Icon: core.GlyphRadio,
@@ -160,6 +217,13 @@ func (f ObjectFactory) SimpleObjects() []services.ObjectCreator {
 				return NewTriggerObject(name, 'L')
 			},
 		},
+		{
+			Name: "sprinkler",
+			Icon: 's',
+			Create: func(name string) services.Object {
+				return NewSprinkler(name, 's')
+			},
+		},
 		{
 			Name: "boulder (falling)",
 			Icon: 'b',
@@ -0,0 +1,98 @@
+package objects
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/memmaker/terminal-assassin/common"
+	"github.com/memmaker/terminal-assassin/game/core"
+	"github.com/memmaker/terminal-assassin/game/services"
+	"github.com/memmaker/terminal-assassin/game/stimuli"
+	"github.com/memmaker/terminal-assassin/geometry"
+)
+
+// RiggableObject is an appliance defined in riggables.txt.
+// People use it when their scheduled task brings them next to it.
+// Once rigged with a fitting tool, the next use sets off its effect,
+// which looks like an accident to everyone else.
+type RiggableObject struct {
+	position   geometry.Point
+	definition services.RiggableDefinition
+	rigged     bool
+	broken     bool
+}
+
+func NewRiggableObject(definition services.RiggableDefinition) *RiggableObject {
+	return &RiggableObject{definition: definition}
+}
+
+// ---- services.Riggable ----
+
+func (r *RiggableObject) IsRigged() bool { return r.rigged }
+
+func (r *RiggableObject) CanBeRiggedWith(tool *core.Item) bool {
+	return tool != nil && !r.rigged && !r.broken && slices.Contains(r.definition.RigWith, tool.Type)
+}
+
+func (r *RiggableObject) Rig(engine services.Engine, person *core.Actor, tool *core.Item) {
+	if !r.CanBeRiggedWith(tool) {
+		return
+	}
+	r.rigged = true
+	game := engine.GetGame()
+	game.IllegalActionAt(r.position, core.ObservationIllegalAction)
+	if person == game.GetMap().Player {
+		game.PrintMessage(fmt.Sprintf("You rig the %s with the %s.", r.definition.Name, tool.Name))
+	}
+}
+
+// UseAppliance is called when a person has finished using the appliance.
+// A rigged appliance sets off its effect and is broken afterwards.
+func (r *RiggableObject) UseAppliance(engine services.Engine, person *core.Actor) {
+	if !r.rigged || r.broken {
+		return
+	}
+	r.rigged = false
+	r.broken = true
+	target := r.position
+	if r.definition.Effect.Distribution == stimuli.DistributeDirect {
+		target = person.Pos()
+	}
+	engine.GetGame().Apply(target, core.NewEffectSourceFromObject(r), r.definition.Effect)
+}
+
+func (r *RiggableObject) UseDurationInSeconds() float64 { return r.definition.UseSeconds }
+
+// IsAccidental marks the harm done by this object as an accident.
+func (r *RiggableObject) IsAccidental() bool { return true }
+
+// ---- services.Object ----
+
+func (r *RiggableObject) Action(_ services.Engine, _ *core.Actor) {}
+
+func (r *RiggableObject) IsActionAllowed(_ services.Engine, _ *core.Actor) bool { return false }
+
+func (r *RiggableObject) ApplyStimulus(_ services.Engine, stim stimuli.Stimulus) {
+	switch stim.Type() {
+	case stimuli.StimulusPiercingDamage, stimuli.StimulusBluntDamage, stimuli.StimulusExplosionDamage:
+		r.rigged = false
+		r.broken = true
+	}
+}
+
+func (r *RiggableObject) Style(st common.Style) common.Style {
+	fg := core.CurrentTheme.DeviceOnForeground
+	if r.broken {
+		fg = core.CurrentTheme.DeviceBrokenForeground
+	}
+	return common.Style{Foreground: fg, Background: st.Background}
+}
+
+func (r *RiggableObject) Icon() rune                    { return r.definition.Icon }
+func (r *RiggableObject) Pos() geometry.Point           { return r.position }
+func (r *RiggableObject) SetPos(p geometry.Point)       { r.position = p }
+func (r *RiggableObject) Description() string           { return r.definition.Name }
+func (r *RiggableObject) EncodeAsString() string        { return r.definition.Name }
+func (r *RiggableObject) IsWalkable(*core.Actor) bool   { return false }
+func (r *RiggableObject) IsTransparent() bool           { return true }
+func (r *RiggableObject) IsPassableForProjectile() bool { return false }
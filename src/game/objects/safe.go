@@ -41,6 +41,10 @@ type Safe struct {
 	Difficulty       core.LockDifficulty
 	ContentItemNames []string
 	position         geometry.Point
+	Circuit          string
+	FailOpen         bool
+	unpowered        bool
+	releasedByOutage bool
 }
 
 // ---- services.LockDifficultyHolder ----
@@ -65,6 +69,35 @@ func newKeypadSafe() *Safe {
 func (s *Safe) GetKey() string    { return s.KeyString }
 func (s *Safe) SetKey(key string) { s.KeyString = key }
 
+// ---- services.Wired ----
+
+func (s *Safe) GetCircuit() string        { return s.Circuit }
+func (s *Safe) SetCircuit(circuit string) { s.Circuit = circuit }
+
+// SetPowered unlocks fail-open electronic safes during a power outage
+// and locks them again once the power is back, unless they have been opened.
+func (s *Safe) SetPowered(_ services.Engine, powered bool) {
+	if s.Type != SafeTypeElectronic || s.unpowered == !powered {
+		return
+	}
+	s.unpowered = !powered
+	switch {
+	case !powered && s.FailOpen && s.State == SafeStateLocked:
+		s.State = SafeStateClosed
+		s.releasedByOutage = true
+	case powered && s.releasedByOutage:
+		if s.State == SafeStateClosed {
+			s.State = SafeStateLocked
+		}
+		s.releasedByOutage = false
+	}
+}
+
+// ---- services.FailSafeLock ----
+
+func (s *Safe) IsFailOpen() bool          { return s.FailOpen }
+func (s *Safe) SetFailOpen(failOpen bool) { s.FailOpen = failOpen }
+
 // ---- services.ContentHolder ----
 
 func (s *Safe) GetContents() []string  { return s.ContentItemNames }
@@ -153,6 +186,10 @@ func (s *Safe) Action(m services.Engine, person *core.Actor) {
 
 	switch {
 
+	// ── Electronic lock without power ────────────────────────────────────────
+	case s.Type == SafeTypeElectronic && s.unpowered && s.State == SafeStateLocked:
+		game.PrintMessage("The lock has no power.")
+
 	// ── Keypad: prompt for code ───────────────────────────────────────────────
 	case s.Keypad && s.State == SafeStateLocked:
 		m.GetUI().ShowTextInput("Enter code: ", "", func(code string) {
@@ -88,6 +88,7 @@ func (sc *SearchableContainer) IsWalkable(*core.Actor) bool
 func (sc *SearchableContainer) IsTransparent() bool                                 { return false }
 func (sc *SearchableContainer) IsPassableForProjectile() bool                       { return false }
 func (sc *SearchableContainer) ApplyStimulus(_ services.Engine, _ stimuli.Stimulus) {}
+func (sc *SearchableContainer) Fuel() int                                           { return 30 }
 
 func (sc *SearchableContainer) Icon() rune { return sc.icon }
 
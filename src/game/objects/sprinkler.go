@@ -0,0 +1,75 @@
+package objects
+
+import (
+	"github.com/memmaker/terminal-assassin/common"
+	"github.com/memmaker/terminal-assassin/game/core"
+	"github.com/memmaker/terminal-assassin/game/services"
+	"github.com/memmaker/terminal-assassin/game/stimuli"
+	"github.com/memmaker/terminal-assassin/geometry"
+)
+
+// Sprinkler is mounted on the ceiling and floods the area around it with
+// water when a fire breaks out within its range. It only works once.
+type Sprinkler struct {
+	position geometry.Point
+	icon     rune
+	Name     string
+	Range    int
+	released bool
+	broken   bool
+}
+
+func NewSprinkler(name string, icon rune) *Sprinkler {
+	return &Sprinkler{Name: name, icon: icon, Range: 4}
+}
+
+// ---- services.FireSuppressor ----
+
+func (s *Sprinkler) SuppressionRange() int   { return s.Range }
+func (s *Sprinkler) IsReadyToSuppress() bool { return !s.released && !s.broken }
+func (s *Sprinkler) IsBroken() bool          { return s.broken }
+
+func (s *Sprinkler) SuppressFire(engine services.Engine) {
+	if !s.IsReadyToSuppress() {
+		return
+	}
+	s.released = true
+	engine.GetGame().Apply(s.position, core.NewEffectSourceFromObject(s), stimuli.EffectLeak(stimuli.StimulusWater, 10, s.Range))
+}
+
+// ---- services.Object ----
+
+// Action lets a person set off the sprinkler by hand, e.g. to wet the floor.
+func (s *Sprinkler) Action(engine services.Engine, _ *core.Actor) {
+	s.SuppressFire(engine)
+}
+
+func (s *Sprinkler) IsActionAllowed(_ services.Engine, _ *core.Actor) bool {
+	return s.IsReadyToSuppress()
+}
+
+func (s *Sprinkler) ApplyStimulus(engine services.Engine, stim stimuli.Stimulus) {
+	switch stim.Type() {
+	case stimuli.StimulusFire:
+		s.SuppressFire(engine)
+	case stimuli.StimulusPiercingDamage, stimuli.StimulusBluntDamage, stimuli.StimulusExplosionDamage:
+		s.broken = true
+	}
+}
+
+func (s *Sprinkler) Style(st common.Style) common.Style {
+	fg := core.CurrentTheme.DeviceOnForeground
+	if !s.IsReadyToSuppress() {
+		fg = core.CurrentTheme.DeviceBrokenForeground
+	}
+	return common.Style{Foreground: fg, Background: st.Background}
+}
+
+func (s *Sprinkler) Icon() rune                    { return s.icon }
+func (s *Sprinkler) Pos() geometry.Point           { return s.position }
+func (s *Sprinkler) SetPos(p geometry.Point)       { s.position = p }
+func (s *Sprinkler) Description() string           { return s.Name }
+func (s *Sprinkler) EncodeAsString() string        { return s.Name }
+func (s *Sprinkler) IsWalkable(*core.Actor) bool   { return true }
+func (s *Sprinkler) IsTransparent() bool           { return true }
+func (s *Sprinkler) IsPassableForProjectile() bool { return true }
@@ -100,6 +100,15 @@ func (w *Window) IsTransparent() bool {
 func (w *Window) IsPassableForProjectile() bool {
 	return w.State != WindowStateClosed
 }
+
+// BlocksGas is true for closed windows, open and broken ones let gas through.
+func (w *Window) BlocksGas() bool {
+	return w.State == WindowStateClosed
+}
+func (w *Window) IsBroken() bool {
+	return w.State == WindowStateBroken
+}
+
 func (w *Window) EncodeAsString() string {
 	return w.uniqueIdentifier
 }
@@ -0,0 +1,152 @@
+package game
+
+import (
+	"strings"
+
+	"github.com/memmaker/terminal-assassin/game/services"
+	"github.com/memmaker/terminal-assassin/game/stimuli"
+	"github.com/memmaker/terminal-assassin/geometry"
+	"github.com/memmaker/terminal-assassin/gridmap"
+)
+
+// UpdatePowerGrid switches all wired objects, dynamic lights and electrified
+// floors on or off, depending on whether their circuit is live.
+func (m *Model) UpdatePowerGrid() {
+	currentMap := m.GetMap()
+	openCircuits := m.openCircuits()
+	wiredPositions := make(map[geometry.Point]bool)
+	for _, object := range currentMap.Objects() {
+		wired, ok := object.(services.Wired)
+		if !ok {
+			continue
+		}
+		wiredPositions[object.Pos()] = true
+		if _, isBreaker := object.(services.CircuitBreaker); isBreaker {
+			continue
+		}
+		wired.SetPowered(m.engine, isCircuitLive(m.circuitOf(object.Pos(), wired.GetCircuit()), openCircuits))
+	}
+
+	// wired lamps handle their own light
+	unpoweredLights := make([]geometry.Point, 0)
+	for p := range currentMap.DynamicLights {
+		if !wiredPositions[p] && !isCircuitLive(m.circuitOf(p, ""), openCircuits) {
+			unpoweredLights = append(unpoweredLights, p)
+		}
+	}
+	for _, p := range unpoweredLights {
+		currentMap.SwitchOffDynamicLightAt(p)
+	}
+	for _, p := range currentMap.SwitchedOffDynamicLights() {
+		if !wiredPositions[p] && isCircuitLive(m.circuitOf(p, ""), openCircuits) {
+			currentMap.SwitchOnDynamicLightAt(p)
+		}
+	}
+	currentMap.UpdateDynamicLights()
+
+	m.removeElectricityFromDeadOutlets(openCircuits)
+}
+
+// IsPoweredAt is true if the circuit of the zone at pos is live.
+// Positions in zones without a fuse box always have power.
+func (m *Model) IsPoweredAt(pos geometry.Point) bool {
+	return isCircuitLive(m.circuitOf(pos, ""), m.openCircuits())
+}
+
+// circuitOf returns the circuit something at pos is wired to. Explicit wiring
+// wins, otherwise it is the most specific circuit of a fuse box in the same zone.
+// A zone whose fuse boxes have no named circuit uses the circuit of the zone.
+func (m *Model) circuitOf(pos geometry.Point, wiredTo string) string {
+	if wiredTo != "" {
+		return wiredTo
+	}
+	currentMap := m.GetMap()
+	zone := currentMap.ZoneAt(pos)
+	if zone == nil {
+		return ""
+	}
+	circuit := ""
+	hasUnnamedBreaker := false
+	for _, object := range currentMap.Objects() {
+		breaker, ok := object.(services.CircuitBreaker)
+		if !ok || currentMap.ZoneAt(object.Pos()) != zone {
+			continue
+		}
+		if breaker.GetCircuit() == "" {
+			hasUnnamedBreaker = true
+			continue
+		}
+		if circuit == "" || strings.Count(breaker.GetCircuit(), "/") > strings.Count(circuit, "/") {
+			circuit = breaker.GetCircuit()
+		}
+	}
+	if circuit == "" && hasUnnamedBreaker {
+		return zoneCircuit(zone)
+	}
+	return circuit
+}
+
+// breakerCircuit returns the circuit a breaker switches. A fuse box that is
+// not wired to a named circuit switches the circuit of its own zone.
+func (m *Model) breakerCircuit(object services.Object, breaker services.CircuitBreaker) string {
+	if breaker.GetCircuit() != "" {
+		return breaker.GetCircuit()
+	}
+	zone := m.GetMap().ZoneAt(object.Pos())
+	if zone == nil {
+		return ""
+	}
+	return zoneCircuit(zone)
+}
+
+// zoneCircuit is the implicit circuit of a zone, used by fuse boxes without a named circuit.
+func zoneCircuit(zone *gridmap.ZoneInfo) string {
+	return "zone:" + zone.Name
+}
+
+// openCircuits returns the circuits that have at least one open breaker.
+func (m *Model) openCircuits() map[string]bool {
+	open := make(map[string]bool)
+	for _, object := range m.GetMap().Objects() {
+		if breaker, ok := object.(services.CircuitBreaker); ok && !breaker.IsBreakerClosed() {
+			if circuit := m.breakerCircuit(object, breaker); circuit != "" {
+				open[circuit] = true
+			}
+		}
+	}
+	return open
+}
+
+// isCircuitLive is true if neither the circuit nor any circuit feeding it is open.
+func isCircuitLive(circuit string, openCircuits map[string]bool) bool {
+	if circuit == "" {
+		return true
+	}
+	parts := strings.Split(circuit, "/")
+	for i := range parts {
+		if openCircuits[strings.Join(parts[:i+1], "/")] {
+			return false
+		}
+	}
+	return true
+}
+
+// removeElectricityFromDeadOutlets takes the voltage from exposed outlets
+// without power and from the water they electrified.
+func (m *Model) removeElectricityFromDeadOutlets(openCircuits map[string]bool) {
+	currentMap := m.GetMap()
+	for i := range currentMap.Cells {
+		p := geometry.Point{X: i % currentMap.MapWidth, Y: i / currentMap.MapWidth}
+		if !currentMap.IsTileWithSpecialAt(p, gridmap.SpecialTileTypePowerOutlet) ||
+			!currentMap.IsStimulusOnTile(p, stimuli.StimulusHighVoltage) ||
+			isCircuitLive(m.circuitOf(p, ""), openCircuits) {
+			continue
+		}
+		electrified := currentMap.GetConnected(p, func(q geometry.Point) bool {
+			return currentMap.IsStimulusOnTile(q, stimuli.StimulusHighVoltage)
+		})
+		for _, q := range append(electrified, p) {
+			currentMap.RemoveStimulusFromTile(q, stimuli.StimulusHighVoltage)
+		}
+	}
+}
@@ -0,0 +1,253 @@
+package services
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/memmaker/terminal-assassin/mapset"
+	rec_files "github.com/memmaker/terminal-assassin/rec-files"
+)
+
+/* EXAMPLE CAMPAIGN FILE CONTENTS (campaign.txt in the campaign folder):
+
+Title: First Blood
+Flags: triads_at_war; informant_alive
+
+Interlude: prologue
+
+Mission: Triads-Beta.map
+Title: Triad Troubles
+Sets_Flag: triads_at_war
+
+Interlude: after_the_triads
+
+Mission: Harbor.map
+Title: Down at the Docks
+Requires_Mission: Triads-Beta.map
+Requires_Level: 3
+
+The records are in the order of the campaign. An interlude is played once all missions above it
+were completed. Like a briefing, it is a folder (interludes/<name>) with a slides.txt and the
+audio and image files of the slides.
+Flags are declared in the first record, missions set them when they are completed and scripts
+can read and set them with IsCampaignFlagSet(name) and SetCampaignFlag(name).
+*/
+
+// CampaignMission is a map of the campaign, together with what is needed to play it.
+type CampaignMission struct {
+	Folder           string
+	Title            string
+	RequiredMissions []string
+	RequiredLevel    uint64
+	SetsFlags        []string
+}
+
+// CampaignInterlude is a story scene that is played between missions.
+type CampaignInterlude struct {
+	Name string
+	// After are the missions that must be completed before the interlude is played.
+	After []string
+}
+
+// Campaign is the structure of a campaign folder as defined by its campaign.txt.
+type Campaign struct {
+	Folder     string
+	Path       string
+	Title      string
+	Flags      []string
+	Missions   []*CampaignMission
+	Interludes []*CampaignInterlude
+}
+
+// LoadCampaign reads the campaign.txt of the campaign folder. It returns nil for campaigns without one,
+// their maps are played in any order.
+func LoadCampaign(files FileInterface, campaignDirectory, campaignFolder string) *Campaign {
+	campaignPath := path.Join(campaignDirectory, campaignFolder)
+	campaignFilename := path.Join(campaignPath, "campaign.txt")
+	if !files.FileExists(campaignFilename) {
+		return nil
+	}
+	file, err := files.Open(campaignFilename)
+	if err != nil {
+		println("Error opening campaign file: " + campaignFilename)
+		return nil
+	}
+	defer file.Close()
+
+	campaign := &Campaign{Folder: campaignFolder, Path: campaignPath, Title: campaignFolder}
+	missionsSoFar := make([]string, 0)
+	for _, record := range rec_files.Read(file) {
+		fields := record.ToMap()
+		if missionFolder, isMission := fields["Mission"]; isMission {
+			mission := &CampaignMission{Folder: missionFolder, Title: missionFolder}
+			for _, field := range record {
+				switch field.Name {
+				case "Title":
+					mission.Title = field.Value
+				case "Requires_Mission":
+					mission.RequiredMissions = append(mission.RequiredMissions, field.Value)
+				case "Requires_Level":
+					mission.RequiredLevel, _ = strconv.ParseUint(field.Value, 10, 64)
+				case "Sets_Flag":
+					mission.SetsFlags = append(mission.SetsFlags, field.Value)
+				}
+			}
+			campaign.Missions = append(campaign.Missions, mission)
+			missionsSoFar = append(missionsSoFar, missionFolder)
+			continue
+		}
+		if interludeName, isInterlude := fields["Interlude"]; isInterlude {
+			campaign.Interludes = append(campaign.Interludes, &CampaignInterlude{
+				Name:  interludeName,
+				After: slices.Clone(missionsSoFar),
+			})
+			continue
+		}
+		if title, hasTitle := fields["Title"]; hasTitle {
+			campaign.Title = title
+		}
+		for _, flag := range trimmedSplit(fields["Flags"], ";") {
+			if flag != "" {
+				campaign.Flags = append(campaign.Flags, flag)
+			}
+		}
+	}
+	return campaign
+}
+
+// CurrentCampaign returns the structure of the campaign the player is in or nil if it has none.
+func CurrentCampaign(engine Engine) *Campaign {
+	return LoadCampaign(engine.GetFiles(), engine.GetGame().GetConfig().CampaignDirectory, engine.GetCareer().CurrentCampaignFolder)
+}
+
+// MapPath returns the path of the map folder of the mission.
+func (c *Campaign) MapPath(mission *CampaignMission) string {
+	return path.Join(c.Path, mission.Folder)
+}
+
+// InterludePath returns the folder of the slides of the interlude.
+func (c *Campaign) InterludePath(interlude *CampaignInterlude) string {
+	return path.Join(c.Path, "interludes", interlude.Name)
+}
+
+// MissionFor returns the mission of the campaign that is played on the map or nil.
+func (c *Campaign) MissionFor(mapPath string) *CampaignMission {
+	for _, mission := range c.Missions {
+		if c.MapPath(mission) == mapPath {
+			return mission
+		}
+	}
+	return nil
+}
+
+// MissingRequirements describes what the player still has to do to play the mission.
+func (c *Campaign) MissingRequirements(mission *CampaignMission, career *CareerData) []string {
+	missing := make([]string, 0)
+	for _, required := range mission.RequiredMissions {
+		requiredMission := &CampaignMission{Folder: required, Title: required}
+		for _, other := range c.Missions {
+			if other.Folder == required {
+				requiredMission = other
+			}
+		}
+		if !career.HasCompletedMap(c.MapPath(requiredMission)) {
+			missing = append(missing, "Complete "+requiredMission.Title)
+		}
+	}
+	if career.Level() < mission.RequiredLevel {
+		missing = append(missing, fmt.Sprintf("Reach level %d", mission.RequiredLevel))
+	}
+	return missing
+}
+
+func (c *Campaign) IsUnlocked(mission *CampaignMission, career *CareerData) bool {
+	return len(c.MissingRequirements(mission, career)) == 0
+}
+
+// NextInterlude returns the first interlude the player has not seen, if all missions before it are completed.
+func (c *Campaign) NextInterlude(career *CareerData) *CampaignInterlude {
+	for _, interlude := range c.Interludes {
+		if career.HasSeenInterlude(c.Folder, interlude.Name) {
+			continue
+		}
+		for _, missionFolder := range interlude.After {
+			if !career.HasCompletedMap(path.Join(c.Path, missionFolder)) {
+				return nil
+			}
+		}
+		return interlude
+	}
+	return nil
+}
+
+// HasFlag is true when the campaign declares the flag.
+func (c *Campaign) HasFlag(flag string) bool {
+	return slices.Contains(c.Flags, flag)
+}
+
+// CompleteMission sets the flags of the campaign mission that was played on the map.
+func (c *Campaign) CompleteMission(career *CareerData, mapPath string) {
+	mission := c.MissionFor(mapPath)
+	if mission == nil {
+		return
+	}
+	for _, flag := range mission.SetsFlags {
+		c.SetFlag(career, flag)
+	}
+}
+
+// SetFlag sets a flag the campaign declares, others are ignored.
+func (c *Campaign) SetFlag(career *CareerData, flag string) {
+	if !c.HasFlag(flag) {
+		println(fmt.Sprintf("(WARNING) The campaign '%s' has no flag named '%s'", c.Folder, flag))
+		return
+	}
+	if career.CampaignFlags == nil {
+		career.CampaignFlags = mapset.NewSet[string]()
+	}
+	career.CampaignFlags.Add(campaignKey(c.Folder, flag))
+}
+
+func (c *Campaign) IsFlagSet(career *CareerData, flag string) bool {
+	return career.CampaignFlags != nil && career.CampaignFlags.Contains(campaignKey(c.Folder, flag))
+}
+
+// FlagsSetIn returns the flags of the campaign that are set in the career, in the order they are declared.
+func (c *Campaign) FlagsSetIn(career *CareerData) []string {
+	flags := make([]string, 0, len(c.Flags))
+	for _, flag := range c.Flags {
+		if c.IsFlagSet(career, flag) {
+			flags = append(flags, flag)
+		}
+	}
+	return flags
+}
+
+// HasCompletedMap is true when the player finished the map at least once.
+func (c *CareerData) HasCompletedMap(mapPath string) bool {
+	for _, stats := range c.MapStatistics {
+		if stats.FileName == mapPath && stats.FinishCount > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *CareerData) HasSeenInterlude(campaignFolder, interlude string) bool {
+	return c.SeenInterludes != nil && c.SeenInterludes.Contains(campaignKey(campaignFolder, interlude))
+}
+
+func (c *CareerData) MarkInterludeSeen(campaignFolder, interlude string) {
+	if c.SeenInterludes == nil {
+		c.SeenInterludes = mapset.NewSet[string]()
+	}
+	c.SeenInterludes.Add(campaignKey(campaignFolder, interlude))
+}
+
+// campaignKey is how flags and interludes are stored in the career, they are unique per campaign.
+func campaignKey(campaignFolder, name string) string {
+	return strings.TrimSpace(campaignFolder) + "/" + strings.TrimSpace(name)
+}
@@ -0,0 +1,93 @@
+package services
+
+import (
+	"slices"
+	"testing"
+)
+
+func testCampaign() *Campaign {
+	return &Campaign{
+		Folder: "test",
+		Path:   "campaigns/test",
+		Missions: []*CampaignMission{
+			{Folder: "first.map", Title: "First"},
+			{Folder: "second.map", Title: "Second", RequiredMissions: []string{"first.map"}},
+			{Folder: "third.map", Title: "Third", RequiredMissions: []string{"first.map", "second.map"}, RequiredLevel: 3},
+			{Folder: "bonus.map", Title: "Bonus", RequiredMissions: []string{"missing.map"}},
+		},
+		Interludes: []*CampaignInterlude{
+			{Name: "intro"},
+			{Name: "middle", After: []string{"first.map", "second.map"}},
+		},
+	}
+}
+
+func testCampaignCareer(campaign *Campaign, experience uint64, completed ...string) *CareerData {
+	career := &CareerData{ExperiencePoints: experience, MapStatistics: make(map[string]*MapStatistics)}
+	for _, missionFolder := range completed {
+		mapPath := campaign.MapPath(&CampaignMission{Folder: missionFolder})
+		career.MapStatistics[missionFolder] = &MapStatistics{FileName: mapPath, FinishCount: 1}
+	}
+	return career
+}
+
+func TestCampaignMissingRequirements(t *testing.T) {
+	tests := []struct {
+		name       string
+		mission    int
+		experience uint64
+		completed  []string
+		want       []string
+	}{
+		{name: "no requirements", mission: 0, want: []string{}},
+		{name: "required mission missing", mission: 1, want: []string{"Complete First"}},
+		{name: "required mission completed", mission: 1, completed: []string{"first.map"}, want: []string{}},
+		{name: "missions and level missing", mission: 2, experience: levelTwoExperience, completed: []string{"first.map"}, want: []string{"Complete Second", "Reach level 3"}},
+		{name: "level reached", mission: 2, experience: levelThreeExperience, completed: []string{"first.map", "second.map"}, want: []string{}},
+		{name: "unknown mission uses its folder", mission: 3, want: []string{"Complete missing.map"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			campaign := testCampaign()
+			career := testCampaignCareer(campaign, test.experience, test.completed...)
+			mission := campaign.Missions[test.mission]
+			missing := campaign.MissingRequirements(mission, career)
+			if !slices.Equal(missing, test.want) {
+				t.Errorf("MissingRequirements() = %v, want %v", missing, test.want)
+			}
+			if unlocked := campaign.IsUnlocked(mission, career); unlocked != (len(test.want) == 0) {
+				t.Errorf("IsUnlocked() = %v with missing requirements %v", unlocked, missing)
+			}
+		})
+	}
+}
+
+func TestCampaignNextInterlude(t *testing.T) {
+	tests := []struct {
+		name      string
+		completed []string
+		seen      []string
+		want      string
+	}{
+		{name: "the first interlude needs no missions", want: "intro"},
+		{name: "seen interludes are skipped", seen: []string{"intro"}, completed: []string{"first.map", "second.map"}, want: "middle"},
+		{name: "missions before the interlude are missing", seen: []string{"intro"}, completed: []string{"first.map"}},
+		{name: "all interludes seen", seen: []string{"intro", "middle"}, completed: []string{"first.map", "second.map"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			campaign := testCampaign()
+			career := testCampaignCareer(campaign, 0, test.completed...)
+			for _, interlude := range test.seen {
+				career.MarkInterludeSeen(campaign.Folder, interlude)
+			}
+			got := ""
+			if interlude := campaign.NextInterlude(career); interlude != nil {
+				got = interlude.Name
+			}
+			if got != test.want {
+				t.Errorf("NextInterlude() = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
@@ -9,12 +9,21 @@ import (
     "time"
 
     "github.com/memmaker/terminal-assassin/game/core"
+    "github.com/memmaker/terminal-assassin/game/stimuli"
     "github.com/memmaker/terminal-assassin/gridmap"
     "github.com/memmaker/terminal-assassin/mapset"
+    rec_files "github.com/memmaker/terminal-assassin/rec-files"
 )
 
+// PlayerSkills are the skills the player unlocked in the skill tree, see SkillTree.
 type PlayerSkills struct {
     DoubleAssassination bool
+    QuickPicks          bool
+    LightFeet           bool
+    StrongBack          bool
+    BlendIn             bool
+    DeepPockets         bool
+    CornerPeek          bool
 }
 
 type CareerData struct {
@@ -24,6 +33,10 @@ type CareerData struct {
     MapStatistics         map[string]*MapStatistics
     Money                 uint64
     UnlockedSkills        PlayerSkills
+    SkillPointsSpent      uint64
+    // CampaignFlags and SeenInterludes hold "<campaign folder>/<name>" entries, see Campaign.
+    CampaignFlags  mapset.Set[string]
+    SeenInterludes mapset.Set[string]
 }
 
 func (c *CareerData) Level() uint64 {
@@ -59,32 +72,61 @@ func (c *CareerData) Title() string {
         return "Untrusted Agent"
     }
 }
+// SaveToFile writes the career to the profile of the player, see CareerFormatVersion.
 func (c *CareerData) SaveToFile() {
-    fileWriter, fileErr := os.Create("career.tmp")
+    if !IsValidProfileName(c.PlayerName) {
+        println("Error saving career data: invalid profile name '" + c.PlayerName + "'")
+        return
+    }
+    if err := os.MkdirAll(ProfileDirectory(), 0o755); err != nil {
+        println("Error creating profile directory: " + err.Error())
+        return
+    }
+    profileFile := profilePath(c.PlayerName)
+    tempFile := profileFile + ".tmp"
+    fileWriter, fileErr := os.Create(tempFile)
     if fileErr != nil {
-        os.Remove("career.tmp")
+        os.Remove(tempFile)
         println("Error saving career data: " + fileErr.Error())
         return
     }
-    encoder := gob.NewEncoder(fileWriter)
-    err := encoder.Encode(c)
+    err := rec_files.Write(fileWriter, c.toRecords())
     if err != nil {
-        os.Remove("career.tmp")
+        fileWriter.Close()
+        os.Remove(tempFile)
         println("Error encoding career data: " + err.Error())
         return
     }
     closeErr := fileWriter.Close()
     if closeErr != nil {
-        os.Remove("career.tmp")
+        os.Remove(tempFile)
         println("Error closing career file: " + closeErr.Error())
         return
     }
-    renameErr := os.Rename("career.tmp", "career.gob")
+    renameErr := os.Rename(tempFile, profileFile)
     if renameErr != nil {
         println("Error renaming career file: " + renameErr.Error())
         return
     }
-    println("Saved career data to file.")
+    println("Saved career data to " + profileFile)
+}
+
+// ImportLegacyCareer reads the gob encoded career of format version 1.
+func ImportLegacyCareer(filename string) (*CareerData, error) {
+    fileReader, fileErr := os.Open(filename)
+    if fileErr != nil {
+        return nil, fileErr
+    }
+    defer fileReader.Close()
+    careerData := &CareerData{}
+    err := gob.NewDecoder(fileReader).Decode(careerData)
+    if err != nil {
+        return nil, err
+    }
+    if careerData.MapStatistics == nil {
+        careerData.MapStatistics = make(map[string]*MapStatistics)
+    }
+    return careerData, nil
 }
 
 func (c *CareerData) registerChallengePredicates(parser *ChallengeParser, engine Engine, stats core.MissionStats) {
@@ -143,6 +185,58 @@ func (c *CareerData) registerChallengePredicates(parser *ChallengeParser, engine
         }
         return false
     })
+
+    // Counting predicates, eg. KnockoutsAtMost(0) or BodiesHiddenAtLeast(2)
+    registerCountPredicates(parser, "BodiesHidden", float64(stats.BodiesHidden))
+    registerCountPredicates(parser, "ItemsThrown", float64(stats.ItemsThrown))
+    registerCountPredicates(parser, "DoorsLockpicked", float64(stats.DoorsLockpicked))
+    registerCountPredicates(parser, "Knockouts", float64(stats.Knockouts))
+    registerCountPredicates(parser, "Kills", float64(len(stats.Kills)))
+    registerCountPredicates(parser, "DistanceWalked", stats.DistanceWalked)
+    registerCountPredicates(parser, "SecondsInSight", stats.SecondsInSight)
+    registerCountPredicates(parser, "MissionSeconds", stats.SecondsNeeded)
+
+    // AccidentalKillsAtLeast(2) or AccidentalKillsAtLeast(1, fire) for a single stimulus type
+    parser.RegisterPredicate("AccidentalKillsAtLeast", func(args ...any) bool {
+        count, _ := strconv.Atoi(args[0].(string))
+        return stats.AccidentalKills(accidentStimulus(args)) >= count
+    })
+    parser.RegisterPredicate("AccidentalKillsAtMost", func(args ...any) bool {
+        count, _ := strconv.Atoi(args[0].(string))
+        return stats.AccidentalKills(accidentStimulus(args)) <= count
+    })
+    parser.RegisterPredicate("OnlyAccidents", func(args ...any) bool {
+        return stats.OnlyAccidents()
+    })
+    parser.RegisterPredicate("NeverSpotted", func(args ...any) bool {
+        return !stats.BeenSpotted
+    })
+    parser.RegisterPredicate("BodiesNeverFound", func(args ...any) bool {
+        return !stats.BodiesFound
+    })
+    parser.RegisterPredicate("NoAlarm", func(args ...any) bool {
+        return !stats.AlarmTriggered
+    })
+}
+
+// registerCountPredicates registers <name>AtMost(n) and <name>AtLeast(n) for a value of the mission stats.
+func registerCountPredicates(parser *ChallengeParser, name string, value float64) {
+    parser.RegisterPredicate(name+"AtMost", func(args ...any) bool {
+        limit, _ := strconv.ParseFloat(args[0].(string), 64)
+        return value <= limit
+    })
+    parser.RegisterPredicate(name+"AtLeast", func(args ...any) bool {
+        limit, _ := strconv.ParseFloat(args[0].(string), 64)
+        return value >= limit
+    })
+}
+
+// accidentStimulus returns the optional stimulus type argument of the accident predicates.
+func accidentStimulus(args []any) stimuli.StimulusType {
+    if len(args) < 2 {
+        return ""
+    }
+    return stimuli.StimulusType(args[1].(string))
 }
 func containsAll(haystack []string, needles []string) bool {
 	set := make(map[string]struct{}, len(haystack))
@@ -188,17 +282,12 @@ type ChallengeResults struct {
     OldCompletionPercentage float64
 }
 
-func (c *CareerData) CheckForChallengeCompletion(engine Engine, stats core.MissionStats) ChallengeResults {
+// CompletedChallenges returns the challenges of the current map that the mission stats fulfill,
+// together with the number of challenges the map has. The career is not changed.
+func (c *CareerData) CompletedChallenges(engine Engine, stats core.MissionStats) ([]*DiskChallenge, int) {
     completionTime := stats.MissionDuration()
     game := engine.GetGame()
     completedChallenges := make([]*DiskChallenge, 0)
-    newlyCompletedChallenges := make([]Challenge, 0)
-    fasterCompletedChallenges := make([]Challenge, 0)
-    missionMap := game.GetMap()
-    mapHash := missionMap.MapHash()
-    if _, ok := c.MapStatistics[mapHash]; !ok {
-        c.MapStatistics[mapHash] = NewMapStats(missionMap)
-    }
     challengeCount := 0
     classicChallenges := LoadClassicChallenges(game, stats)
     for _, challenge := range classicChallenges {
@@ -208,13 +297,25 @@ func (c *CareerData) CheckForChallengeCompletion(engine Engine, stats core.Missi
     }
     challengeCount += len(classicChallenges)
 
-    mapChallenges := c.ParseMapChallenges(missionMap.MapFileName(), engine, stats)
+    mapChallenges := c.ParseMapChallenges(game.GetMap().MapFileName(), engine, stats)
     for _, challenge := range mapChallenges {
         if challenge.IsCompleted() {
             completedChallenges = append(completedChallenges, NewDiskChallenge(challenge, completionTime))
         }
     }
     challengeCount += len(mapChallenges)
+    return completedChallenges, challengeCount
+}
+
+func (c *CareerData) CheckForChallengeCompletion(engine Engine, stats core.MissionStats) ChallengeResults {
+    newlyCompletedChallenges := make([]Challenge, 0)
+    fasterCompletedChallenges := make([]Challenge, 0)
+    missionMap := engine.GetGame().GetMap()
+    mapHash := missionMap.MapHash()
+    if _, ok := c.MapStatistics[mapHash]; !ok {
+        c.MapStatistics[mapHash] = NewMapStats(missionMap)
+    }
+    completedChallenges, challengeCount := c.CompletedChallenges(engine, stats)
     oldCompletedCount := len(c.MapStatistics[mapHash].CompletedChallenges)
     for _, challenge := range completedChallenges {
         if existingChallengeCompletion, ok := c.MapStatistics[mapHash].CompletedChallenges[challenge.ID()]; ok {
@@ -302,6 +403,9 @@ func (c *CareerData) AddMissionStats(missionMap *gridmap.GridMap[*core.Actor, *c
     if c.MapStatistics[mapHash].UnlockedLocations == nil {
         c.MapStatistics[mapHash].UnlockedLocations = mapset.NewSet[string]()
     }
+    if c.MapStatistics[mapHash].UnlockedItems == nil {
+        c.MapStatistics[mapHash].UnlockedItems = mapset.NewSet[string]()
+    }
 }
 
 func (c *CareerData) AddUnlockedLocation(hash string, locationName string) {
@@ -66,7 +66,9 @@ func TargetDiedOfAccident(statistics core.MissionStats) func() bool {
 			if kill.IsTarget && (kill.CauseOfDeath.Description == core.CoDFalling ||
 				kill.CauseOfDeath.Description == core.CoDBurned ||
 				kill.CauseOfDeath.Description == core.CoDDrowned ||
-				kill.CauseOfDeath.Description == core.CoDElectrocuted) {
+				kill.CauseOfDeath.Description == core.CoDElectrocuted ||
+				kill.CauseOfDeath.Description == core.CoDGasExplosion ||
+				kill.CauseOfDeath.Description == core.CoDSuffocated) {
 				return true
 			}
 		}
@@ -0,0 +1,284 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/memmaker/terminal-assassin/game/core"
+	"github.com/memmaker/terminal-assassin/game/stimuli"
+	"github.com/memmaker/terminal-assassin/gridmap"
+	"github.com/memmaker/terminal-assassin/rng"
+)
+
+// ContractCodePrefix starts every shareable contract code, eg. "C-4F2K9Z".
+const ContractCodePrefix = "C-"
+
+// contractSeedRange keeps the generated contract codes at six characters.
+const contractSeedRange = 36 * 36 * 36 * 36 * 36 * 36
+
+// contractKillMethod is a cause of death a contract can demand, together with the test
+// whether the map offers the means for it.
+type contractKillMethod struct {
+	description  core.CoDDescription
+	isPossibleOn func(missionMap *gridmap.GridMap[*core.Actor, *core.Item, Object]) bool
+}
+
+// contractKillMethods are the causes of death a contract can demand.
+var contractKillMethods = []contractKillMethod{
+	{core.CoDStrangled, func(*gridmap.GridMap[*core.Actor, *core.Item, Object]) bool { return true }},
+	{core.CoDStrangledWithWire, mapHasItemOfType(core.ItemTypePianoWire)},
+	{core.CoDPoisoned, mapHasStimulus(stimuli.StimulusLethal)},
+	{core.CoDDrownedInToilet, mapHasSpecialTile(gridmap.SpecialTileToilet)},
+	{core.CoDElectrocuted, func(missionMap *gridmap.GridMap[*core.Actor, *core.Item, Object]) bool {
+		return mapHasSpecialTile(gridmap.SpecialTileTypePowerOutlet)(missionMap) || mapHasStimulus(stimuli.StimulusHighVoltage)(missionMap)
+	}},
+	{core.CoDBurned, mapHasStimulus(stimuli.StimulusFire)},
+	{core.CoDFalling, mapHasSpecialTile(gridmap.SpecialTileLethal)},
+}
+
+// Contract is a randomly generated mission on an existing map.
+// The same code always generates the same contract on the same map.
+type Contract struct {
+	Code    string
+	Targets []string
+	// KillMethod is the cause of death every target must die from, empty means any.
+	KillMethod core.CoDDescription
+	// KillItemType is the type of the item every target must be killed with, only used when RequiresItemType is set.
+	KillItemType     core.ItemType
+	RequiresItemType bool
+	// RequiredExit is the name of the zone the player has to leave the map from, empty means any exit.
+	RequiredExit string
+	Briefing     []string
+}
+
+// NewContractSeed picks the seed for a new contract from a seed that changes with every call, eg. the current time.
+func NewContractSeed(entropy int64) int64 {
+	if entropy < 0 {
+		entropy = -entropy
+	}
+	return entropy % contractSeedRange
+}
+
+// ContractCode returns the shareable code of a contract seed.
+func ContractCode(seed int64) string {
+	return ContractCodePrefix + strings.ToUpper(strconv.FormatInt(seed, 36))
+}
+
+// ParseContractCode returns the seed of a contract code, the prefix is optional.
+func ParseContractCode(code string) (int64, error) {
+	code = strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(code)), ContractCodePrefix)
+	seed, err := strconv.ParseInt(strings.ToLower(code), 36, 64)
+	if err != nil || seed < 0 || seed >= contractSeedRange {
+		return 0, fmt.Errorf("invalid contract code: %s", code)
+	}
+	return seed, nil
+}
+
+// GenerateContract creates the contract of the seed for the map. It reseeds the gameplay RNG,
+// so it must be called before the mission seeds it.
+func GenerateContract(missionMap *gridmap.GridMap[*core.Actor, *core.Item, Object], seed int64) *Contract {
+	rng.Seed(seed)
+	contract := &Contract{Code: ContractCode(seed)}
+
+	candidates := contractTargetCandidates(missionMap)
+	rng.R.Shuffle(len(candidates), func(i, j int) {
+		candidates[i], candidates[j] = candidates[j], candidates[i]
+	})
+	targetCount := min(1+rng.R.Intn(3), len(candidates))
+	for _, target := range candidates[:targetCount] {
+		contract.Targets = append(contract.Targets, target.Name)
+	}
+
+	switch rng.R.Intn(3) {
+	case 1:
+		if killMethods := contractKillMethodsFor(missionMap); len(killMethods) > 0 {
+			contract.KillMethod = killMethods[rng.R.Intn(len(killMethods))]
+		}
+	case 2:
+		if itemTypes := contractWeaponTypes(missionMap); len(itemTypes) > 0 {
+			contract.KillItemType = itemTypes[rng.R.Intn(len(itemTypes))]
+			contract.RequiresItemType = true
+		}
+	}
+
+	if exits := exitZones(missionMap); len(exits) > 1 && rng.R.Intn(2) == 0 {
+		contract.RequiredExit = exits[rng.R.Intn(len(exits))]
+	}
+
+	contract.Briefing = contract.createBriefing(missionMap)
+	return contract
+}
+
+// IsKillAllowed is true when the cause of death fulfills the kill method of the contract.
+func (c *Contract) IsKillAllowed(cod core.CauseOfDeath) bool {
+	if c.KillMethod != "" && cod.Description != c.KillMethod {
+		return false
+	}
+	if c.RequiresItemType && (cod.Source.Item == nil || cod.Source.Item.Type != c.KillItemType) {
+		return false
+	}
+	return true
+}
+
+// ApplyTo replaces the targets of a freshly loaded map with the targets of the contract, before it is initialized.
+func (c *Contract) ApplyTo(loadedMap *gridmap.GridMap[*core.Actor, *core.Item, Object]) {
+	for _, actor := range loadedMap.Actors() {
+		actor.IsTarget = false
+		for _, targetName := range c.Targets {
+			if actor.Name == targetName {
+				actor.IsTarget = true
+			}
+		}
+	}
+}
+
+func (c *Contract) createBriefing(missionMap *gridmap.GridMap[*core.Actor, *core.Item, Object]) []string {
+	briefing := []string{fmt.Sprintf("Contract %s", c.Code), ""}
+	for _, actor := range missionMap.Actors() {
+		for _, targetName := range c.Targets {
+			if actor.Name != targetName {
+				continue
+			}
+			description := fmt.Sprintf("Eliminate %s (%s)", actor.Name, actor.Type)
+			if actor.Team != "" {
+				description += fmt.Sprintf(" of the %s", actor.Team)
+			}
+			if zone := missionMap.ZoneAt(actor.Pos()); zone != nil && zone.Name != "" {
+				description += fmt.Sprintf(", last seen in %s", zone.Name)
+			}
+			briefing = append(briefing, description+".")
+		}
+	}
+	briefing = append(briefing, "")
+	if c.KillMethod != "" {
+		killMethod := strings.TrimPrefix(string(c.KillMethod), "was ")
+		if c.KillMethod == core.CoDFalling {
+			killMethod = "pushed to their death"
+		}
+		briefing = append(briefing, fmt.Sprintf("The client wants the targets to be %s.", killMethod))
+	}
+	if c.RequiresItemType {
+		briefing = append(briefing, fmt.Sprintf("Use a weapon of the type %s.", c.KillItemType.ToString()))
+	}
+	if c.RequiredExit != "" {
+		briefing = append(briefing, fmt.Sprintf("Leave through the exit in %s.", c.RequiredExit))
+	}
+	return briefing
+}
+
+// contractTargetCandidates returns the actors that can be chosen as targets. Actors with a
+// real name are preferred, the generic "actor #" ones are only used when there are no others.
+func contractTargetCandidates(missionMap *gridmap.GridMap[*core.Actor, *core.Item, Object]) []*core.Actor {
+	named := make([]*core.Actor, 0)
+	generic := make([]*core.Actor, 0)
+	for _, actor := range missionMap.Actors() {
+		if actor.IsPlayer() || actor.IsDead() {
+			continue
+		}
+		if strings.HasPrefix(actor.Name, "actor #") {
+			generic = append(generic, actor)
+		} else {
+			named = append(named, actor)
+		}
+	}
+	if len(named) > 0 {
+		return named
+	}
+	return generic
+}
+
+// contractKillMethodsFor returns the kill methods the map offers the means for, in a stable order.
+func contractKillMethodsFor(missionMap *gridmap.GridMap[*core.Actor, *core.Item, Object]) []core.CoDDescription {
+	killMethods := make([]core.CoDDescription, 0, len(contractKillMethods))
+	for _, killMethod := range contractKillMethods {
+		if killMethod.isPossibleOn(missionMap) {
+			killMethods = append(killMethods, killMethod.description)
+		}
+	}
+	return killMethods
+}
+
+func mapHasItemOfType(itemType core.ItemType) func(missionMap *gridmap.GridMap[*core.Actor, *core.Item, Object]) bool {
+	return func(missionMap *gridmap.GridMap[*core.Actor, *core.Item, Object]) bool {
+		for _, item := range itemsOnMap(missionMap) {
+			if item.Type == itemType {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// mapHasStimulus is true when a tile carries the stimulus or an item on the map can cause it.
+func mapHasStimulus(stimType stimuli.StimulusType) func(missionMap *gridmap.GridMap[*core.Actor, *core.Item, Object]) bool {
+	return func(missionMap *gridmap.GridMap[*core.Actor, *core.Item, Object]) bool {
+		for _, cell := range missionMap.Cells {
+			if _, ok := cell.Stimuli[stimType]; ok {
+				return true
+			}
+		}
+		for _, item := range itemsOnMap(missionMap) {
+			for _, effect := range item.TriggerEffects {
+				for _, stim := range effect.Stimuli {
+					if stim.Type() == stimType {
+						return true
+					}
+				}
+			}
+		}
+		return false
+	}
+}
+
+func mapHasSpecialTile(special gridmap.SpecialTileType) func(missionMap *gridmap.GridMap[*core.Actor, *core.Item, Object]) bool {
+	return func(missionMap *gridmap.GridMap[*core.Actor, *core.Item, Object]) bool {
+		return len(missionMap.GetAllSpecialTilePositions(special)) > 0
+	}
+}
+
+// itemsOnMap returns the items lying on the map and those carried by the other actors,
+// the player's inventory is left out because it is replaced by the loadout.
+func itemsOnMap(missionMap *gridmap.GridMap[*core.Actor, *core.Item, Object]) []*core.Item {
+	items := append([]*core.Item{}, missionMap.Items()...)
+	for _, actor := range missionMap.Actors() {
+		if actor.IsPlayer() || actor.Inventory == nil {
+			continue
+		}
+		items = append(items, actor.Inventory.Items...)
+	}
+	return items
+}
+
+// contractWeaponTypes returns the types of the weapons that can be found on the map, in a stable order.
+func contractWeaponTypes(missionMap *gridmap.GridMap[*core.Actor, *core.Item, Object]) []core.ItemType {
+	found := make(map[core.ItemType]bool)
+	for _, item := range itemsOnMap(missionMap) {
+		if item.Type.HasRangedAction() || item.Type.HasMeleeAction() {
+			found[item.Type] = true
+		}
+	}
+	itemTypes := make([]core.ItemType, 0, len(found))
+	for itemType := range found {
+		itemTypes = append(itemTypes, itemType)
+	}
+	sort.Slice(itemTypes, func(i, j int) bool { return itemTypes[i] < itemTypes[j] })
+	return itemTypes
+}
+
+// exitZones returns the names of the zones that contain a player exit, in alphabetical order.
+func exitZones(missionMap *gridmap.GridMap[*core.Actor, *core.Item, Object]) []string {
+	names := make(map[string]bool)
+	for _, pos := range missionMap.GetAllSpecialTilePositions(gridmap.SpecialTilePlayerExit) {
+		if zone := missionMap.ZoneAt(pos); zone != nil && zone.Name != "" {
+			names[zone.Name] = true
+		}
+	}
+	exits := make([]string, 0, len(names))
+	for name := range names {
+		exits = append(exits, name)
+	}
+	sort.Strings(exits)
+	return exits
+}
@@ -0,0 +1,59 @@
+package services
+
+import "testing"
+
+func TestContractCodeRoundTrip(t *testing.T) {
+	for _, seed := range []int64{0, 1, 35, 36, 123456, contractSeedRange - 1} {
+		code := ContractCode(seed)
+		parsed, err := ParseContractCode(code)
+		if err != nil {
+			t.Errorf("ParseContractCode(%q) failed: %v", code, err)
+			continue
+		}
+		if parsed != seed {
+			t.Errorf("ParseContractCode(ContractCode(%d)) = %d", seed, parsed)
+		}
+	}
+}
+
+func TestParseContractCode(t *testing.T) {
+	tests := []struct {
+		code    string
+		want    int64
+		wantErr bool
+	}{
+		{code: "C-0", want: 0},
+		{code: "C-Z", want: 35},
+		{code: "C-10", want: 36},
+		{code: "c-zzzzzz", want: contractSeedRange - 1},
+		{code: "ZZZZZZ", want: contractSeedRange - 1},
+		{code: "  C-2N9C  ", want: 123456},
+		{code: "C-1000000", wantErr: true},
+		{code: "C--1", wantErr: true},
+		{code: "C-!?", wantErr: true},
+		{code: "", wantErr: true},
+	}
+	for _, test := range tests {
+		t.Run(test.code, func(t *testing.T) {
+			got, err := ParseContractCode(test.code)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("ParseContractCode(%q) error = %v, want error %v", test.code, err, test.wantErr)
+			}
+			if !test.wantErr && got != test.want {
+				t.Errorf("ParseContractCode(%q) = %d, want %d", test.code, got, test.want)
+			}
+		})
+	}
+}
+
+func TestNewContractSeedIsInRange(t *testing.T) {
+	for _, entropy := range []int64{0, 1, -1, contractSeedRange, -contractSeedRange - 5, 1<<62 + 7} {
+		seed := NewContractSeed(entropy)
+		if seed < 0 || seed >= contractSeedRange {
+			t.Errorf("NewContractSeed(%d) = %d is out of range", entropy, seed)
+		}
+		if _, err := ParseContractCode(ContractCode(seed)); err != nil {
+			t.Errorf("the code of NewContractSeed(%d) does not parse: %v", entropy, err)
+		}
+	}
+}
@@ -29,6 +29,7 @@ func NewExternalDataFromDisk(files DataSource) *ExternalData {
 	e := &ExternalData{
 		items: []*core.Item{},
 		tiles: []*gridmap.Tile{},
+		files: files,
 	}
 	e.LoadCoreData(files)
 	return e
@@ -45,6 +46,11 @@ type ExternalData struct {
 	definedStims           map[string]ParametrizedStimuliRecord
 	definedTrigger         map[string]ParametrizedTriggerRecord
 	definedReactionTrigger map[string]ParametrizedTriggerRecord
+	riggables              []RiggableDefinition
+	objectDefinitions      []ObjectDefinition
+	recipes                []RecipeDefinition
+	campaignObjects        map[string][]ObjectDefinition
+	files                  DataSource
 }
 
 func (e *ExternalData) GroundTile() gridmap.Tile {
@@ -110,6 +116,9 @@ func (e *ExternalData) loadCoreDataFromDirectory(files DataSource, dataFilesSubD
 	e.definedReactionTrigger = merge(e.definedReactionTrigger, e.LoadCustomReactionTriggers(files, dataFilesSubDir))
 	e.items = append(e.items, e.LoadListOfCustomItems(files, dataFilesSubDir)...)
 	e.tiles = append(e.tiles, e.LoadListOfCustomTiles(files, dataFilesSubDir)...)
+	e.riggables = append(e.riggables, e.LoadListOfRiggables(files, dataFilesSubDir)...)
+	e.objectDefinitions = append(e.objectDefinitions, e.LoadListOfObjectDefinitions(files, dataFilesSubDir)...)
+	e.recipes = append(e.recipes, e.LoadListOfRecipes(files, dataFilesSubDir)...)
 }
 
 func (e *ExternalData) LoadCustomReactionTriggers(files DataSource, dataDir string) map[string]ParametrizedTriggerRecord {
@@ -581,6 +590,14 @@ func NewItemFromRecord(record map[string]string, context *EvalContext) *core.Ite
 	item.AudioCue = record["audio_cue"]
 	item.KeyString = record["key_string"]
 	item.LootValue, _ = strconv.Atoi(record["loot_value"])
+	for _, typeName := range trimmedSplit(record["fits"], ",") {
+		if typeName != "" {
+			item.FitsTypes = append(item.FitsTypes, core.NewItemTypeFromString(typeName))
+		}
+	}
+	item.AttachmentSlot = core.AttachmentSlot(record["slot"])
+	item.ScopeDegrees, _ = strconv.ParseFloat(record["scope_fov"], 64)
+	item.ExtraUses, _ = strconv.Atoi(record["extra_uses"])
 
 	// Evaluate TriggerEffects
 	name, callArgs := core.GetNameAndArgs(record["trigger_effects"])
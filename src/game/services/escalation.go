@@ -0,0 +1,174 @@
+package services
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/memmaker/terminal-assassin/game/core"
+	"github.com/memmaker/terminal-assassin/gridmap"
+	rec_files "github.com/memmaker/terminal-assassin/rec-files"
+)
+
+/* EXAMPLE ESCALATION FILE CONTENTS (escalation.txt in the map folder):
+
+Level: 1
+Title: The Lotus Blossom
+
+Level: 2
+Title: Watchful Eyes
+Target: Blue Lotus Guard 1
+Spotted: forbidden
+
+Level: 3
+Title: Against the Clock
+Time_Limit: 300
+Bodies_Found: forbidden
+
+Level: 3
+Name: Blue Lotus Guard 3
+ActorType: guard
+Team: Blue lotus
+Position: (12,20)
+Inventory: Pistol
+
+Records with a Title define a level, records with an ActorType add a guard from that level on.
+Every level keeps the complications of the levels before it.
+*/
+
+// EscalationLevel is one step of an escalation contract, including the complications of all earlier levels.
+type EscalationLevel struct {
+	Level        int
+	Title        string
+	ExtraTargets []string
+	ExtraGuards  []core.ActorOnDisk
+	// TimeLimit is the mission time in seconds the player has, 0 means no limit.
+	TimeLimit            float64
+	SpottingForbidden    bool
+	BodiesFoundForbidden bool
+}
+
+// Complications returns a one line description of every complication of the level.
+func (l *EscalationLevel) Complications() []string {
+	complications := make([]string, 0)
+	for _, target := range l.ExtraTargets {
+		complications = append(complications, "Additional target: "+target)
+	}
+	if len(l.ExtraGuards) > 0 {
+		complications = append(complications, fmt.Sprintf("Additional guards: %d", len(l.ExtraGuards)))
+	}
+	if l.TimeLimit > 0 {
+		complications = append(complications, fmt.Sprintf("Time limit: %d:%02d", int(l.TimeLimit)/60, int(l.TimeLimit)%60))
+	}
+	if l.SpottingForbidden {
+		complications = append(complications, "Don't get spotted")
+	}
+	if l.BodiesFoundForbidden {
+		complications = append(complications, "No bodies found")
+	}
+	return complications
+}
+
+// ApplyTo adds the complications of the level to a freshly loaded map, before it is initialized.
+func (l *EscalationLevel) ApplyTo(engine Engine, loadedMap *gridmap.GridMap[*core.Actor, *core.Item, Object]) {
+	for _, targetName := range l.ExtraTargets {
+		for _, actor := range loadedMap.Actors() {
+			if actor.Name == targetName {
+				actor.IsTarget = true
+			}
+		}
+	}
+	for _, guard := range l.ExtraGuards {
+		newGuard := engine.GetData().NewActorFromDisk(engine.GetItemFactory(), guard)
+		loadedMap.AddActor(newGuard, newGuard.Pos())
+	}
+}
+
+// LoadEscalation reads the escalation.txt of the map folder. Maps without one have no escalation levels.
+func LoadEscalation(files FileInterface, mapFolder string) []*EscalationLevel {
+	escalationFilename := path.Join(mapFolder, "escalation.txt")
+	if !files.FileExists(escalationFilename) {
+		return nil
+	}
+	file, err := files.Open(escalationFilename)
+	if err != nil {
+		println("Error opening escalation file: " + escalationFilename)
+		return nil
+	}
+	defer file.Close()
+
+	levelsByNumber := make(map[int]*EscalationLevel)
+	guardsByLevel := make(map[int][]core.ActorOnDisk)
+	for _, record := range rec_files.Read(file) {
+		fields := record.ToMap()
+		levelNumber, err := strconv.Atoi(fields["Level"])
+		if err != nil || levelNumber < 1 {
+			println("Escalation record without a valid level in " + escalationFilename)
+			continue
+		}
+		if _, isGuard := fields["ActorType"]; isGuard {
+			guardsByLevel[levelNumber] = append(guardsByLevel[levelNumber], core.ActorOnDiskFromRecord(record))
+			continue
+		}
+		level := &EscalationLevel{Level: levelNumber, Title: fields["Title"]}
+		for _, field := range record {
+			switch field.Name {
+			case "Target":
+				level.ExtraTargets = append(level.ExtraTargets, strings.TrimSpace(field.Value))
+			case "Time_Limit":
+				level.TimeLimit, _ = strconv.ParseFloat(field.Value, 64)
+			case "Spotted":
+				level.SpottingForbidden = field.Value == "forbidden"
+			case "Bodies_Found":
+				level.BodiesFoundForbidden = field.Value == "forbidden"
+			}
+		}
+		levelsByNumber[levelNumber] = level
+	}
+
+	numbers := make([]int, 0, len(levelsByNumber))
+	for number := range levelsByNumber {
+		numbers = append(numbers, number)
+	}
+	sort.Ints(numbers)
+
+	levels := make([]*EscalationLevel, 0, len(numbers))
+	var previous *EscalationLevel
+	for _, number := range numbers {
+		level := levelsByNumber[number]
+		level.ExtraGuards = append(level.ExtraGuards, guardsByLevel[number]...)
+		if previous != nil {
+			level.inherit(previous)
+		}
+		levels = append(levels, level)
+		previous = level
+	}
+	return levels
+}
+
+// inherit keeps the complications of the previous level.
+func (l *EscalationLevel) inherit(previous *EscalationLevel) {
+	l.ExtraTargets = append(append([]string{}, previous.ExtraTargets...), l.ExtraTargets...)
+	l.ExtraGuards = append(append([]core.ActorOnDisk{}, previous.ExtraGuards...), l.ExtraGuards...)
+	l.SpottingForbidden = l.SpottingForbidden || previous.SpottingForbidden
+	l.BodiesFoundForbidden = l.BodiesFoundForbidden || previous.BodiesFoundForbidden
+	if l.TimeLimit == 0 || (previous.TimeLimit > 0 && previous.TimeLimit < l.TimeLimit) {
+		l.TimeLimit = previous.TimeLimit
+	}
+}
+
+// EscalationLevelFor returns the highest escalation level of the map the player has completed.
+func (c *CareerData) EscalationLevelFor(missionMap *gridmap.GridMap[*core.Actor, *core.Item, Object]) int {
+	stats, ok := c.MapStatistics[missionMap.MapHash()]
+	if !ok {
+		return 0
+	}
+	return stats.EscalationLevel
+}
+
+func (c *CareerData) CompleteEscalationLevel(missionMap *gridmap.GridMap[*core.Actor, *core.Item, Object], level int) {
+	stats := c.mapStatisticsFor(missionMap.MapHash(), missionMap.MapFileName())
+	stats.EscalationLevel = max(stats.EscalationLevel, level)
+}
@@ -55,6 +55,57 @@ type Removable interface {
 	OnRemoved(engine Engine)
 }
 
+// Breakable is implemented by objects that can be damaged beyond use while staying on the map.
+type Breakable interface {
+	IsBroken() bool
+}
+
+// Flammable is implemented by objects that feed a fire on their tile.
+// They burn away once their fuel is used up.
+type Flammable interface {
+	Fuel() int
+}
+
+// FireSuppressor is implemented by sprinklers that put out fires within their range.
+type FireSuppressor interface {
+	SuppressionRange() int
+	IsReadyToSuppress() bool
+	SuppressFire(engine Engine)
+}
+
+// Riggable is implemented by appliances that can be sabotaged with a tool.
+// A rigged appliance goes off when a person uses it the next time.
+type Riggable interface {
+	IsRigged() bool
+	CanBeRiggedWith(tool *core.Item) bool
+	Rig(engine Engine, person *core.Actor, tool *core.Item)
+	UseAppliance(engine Engine, person *core.Actor)
+	UseDurationInSeconds() float64
+}
+
+// Wired is implemented by objects that draw power from an electrical circuit.
+// Circuits are named like paths, "main/kitchen" is fed by "main".
+// Objects without a circuit are wired to the fuse box in their zone.
+type Wired interface {
+	GetCircuit() string
+	SetCircuit(circuit string)
+	SetPowered(engine Engine, powered bool)
+}
+
+// CircuitBreaker is implemented by fuse boxes. A circuit is live as long as
+// all breakers on it and on the circuits feeding it are closed.
+type CircuitBreaker interface {
+	Wired
+	IsBreakerClosed() bool
+}
+
+// FailSafeLock is implemented by electronic locks. Without power a fail-open
+// lock releases, a fail-closed lock stays locked and cannot be operated.
+type FailSafeLock interface {
+	IsFailOpen() bool
+	SetFailOpen(failOpen bool)
+}
+
 // ContentHolder is implemented by objects that contain items (e.g. a safe).
 // Each content entry is an item name that can be decoded by the item factory.
 // The serialiser writes one "Content" field per entry and restores them on load.
@@ -100,7 +151,7 @@ type UIInterface interface {
 	ShowTextInput(prompt string, prefilled string, onClose func(string), onAbort func())
 	PopAll()
 	ShowPager(title string, lines []core.StyledText, quit func())
-	OpenItemRingMenu(currentItem *core.Item, listOfItems []*core.Item, selectedFunc func(*core.Item), cancelFunc func(), dropFunc func(*core.Item))
+	OpenItemRingMenu(currentItem *core.Item, listOfItems []*core.Item, selectedFunc func(*core.Item), cancelFunc func(), dropFunc func(*core.Item), combineFunc func(*core.Item))
 	HideModal()
 	ShowModal()
 	ShowWidget(widget UIWidget)
@@ -172,8 +223,28 @@ type GameInterface interface {
 	ApplyStimulusToThings(location geometry.Point, source core.EffectSource, stimulus stimuli.Stimulus)
 	ApplyStimulusToTile(location geometry.Point, source core.EffectSource, stimulus stimuli.Stimulus)
 	ApplyStimulusToActor(person *core.Actor, source core.EffectSource, stimulus stimuli.Stimulus)
+	UpdateFire()
+	UpdateLiquids()
+	UpdateGas()
+	CombineItem(person *core.Actor, item *core.Item)
+	UpdatePowerGrid()
+	IsPoweredAt(pos geometry.Point) bool
 
 	GetStats() *core.MissionStats
+	LoadObjectives()
+	GetObjectives() *MissionObjectives
+	UpdateObjectives()
+	SetEscalation(level *EscalationLevel)
+	GetEscalation() *EscalationLevel
+	SetContract(contract *Contract)
+	GetContract() *Contract
+	SetPlayerSkills(skills PlayerSkills)
+	GetPlayerSkills() PlayerSkills
+	SetCampaignFlags(flags []string, isLive bool)
+	GetCampaignFlags() []string
+	IsCampaignFlagSet(flag string) bool
+	SetCampaignFlag(flag string)
+	QueuedCampaignFlags() []string
 	GetActions() ActionsInterface
 
 	IllegalPlayerEngagementWithActorAtPos(position geometry.Point, icon rune, timeInSeconds float64, engagementFinishedAction func(), engagementCancelledAction func())
@@ -253,6 +324,11 @@ type DataInterface interface {
 	Items() []*core.Item
 	ItemByName(name string) (*core.Item, bool)
 	Tiles() []*gridmap.Tile
+	TileFromIcon(icon rune) gridmap.Tile
+	Riggables() []RiggableDefinition
+	ObjectDefinitions(campaignDir string) []ObjectDefinition
+	Recipes() []RecipeDefinition
+	NewActorFromDisk(factory *ItemFactory, diskData core.ActorOnDisk) *core.Actor
 }
 
 type AIInterface interface {
@@ -344,6 +420,8 @@ type Engine interface {
 	GetData() DataInterface
 	GetAI() AIInterface
 	GetCareer() *CareerData
+	// SetCareer switches to another career profile.
+	SetCareer(career *CareerData)
 	GetItemFactory() *ItemFactory
 	GetObjectFactory() ObjectFactoryInterface
 
@@ -366,10 +444,19 @@ type Engine interface {
 
 	SaveMap(currentMap *gridmap.GridMap[*core.Actor, *core.Item, Object], folder string) error
 	LoadMap(name string) (*gridmap.GridMap[*core.Actor, *core.Item, Object], error)
+	// ExportTiledMap & ImportTiledMap convert between the map and a Tiled JSON map file.
+	ExportTiledMap(currentMap *gridmap.GridMap[*core.Actor, *core.Item, Object], filename string) error
+	ImportTiledMap(filename string) (*gridmap.GridMap[*core.Actor, *core.Item, Object], error)
+	// DiffMapFolders & MergeMapFolders compare saved map folders, they return one report line per change or conflict.
+	DiffMapFolders(oldFolder, newFolder string) ([]string, error)
+	MergeMapFolders(baseFolder, oursFolder, theirsFolder, outputFolder string) ([]string, error)
 	Reset()
 
 	GetRecorder() *Recorder
 	SetInputOverride(InputInterface)
+	// SetHeadless runs the simulation as fast as possible without drawing and sound, eg. to verify a replay.
+	SetHeadless(headless bool)
+	IsHeadless() bool
 
 	GetAvailableTextFonts() []string
 	SetTextFont(fontName string)
@@ -7,6 +7,7 @@ import (
     "path/filepath"
     "regexp"
     "strconv"
+    "strings"
     "time"
 
     "github.com/memmaker/terminal-assassin/common"
@@ -88,7 +89,17 @@ func (f ItemFactory) DecodeStringToItem(name string) core.Item {
     paperPattern := regexp.MustCompile(`^Message\((.*)\): (.*)$`)
     lockpickPattern := regexp.MustCompile(`^Lockpick\((\d+)\)$`)
     lockpickElectronicPattern := regexp.MustCompile(`^LockpickElectronic\((\d+)\)$`)
-    if keyPattern.MatchString(name) {
+    fittedPattern := regexp.MustCompile(`^Fitted\((.*)\)$`)
+    if fittedPattern.MatchString(name) {
+        submatches := fittedPattern.FindStringSubmatch(name)
+        names := strings.Split(submatches[1], fittedPartSeparator)
+        weapon := f.DecodeStringToItem(names[0])
+        for _, partName := range names[1:] {
+            part := f.DecodeStringToItem(partName)
+            weapon.Fit(&part)
+        }
+        return weapon
+    } else if keyPattern.MatchString(name) {
         submatches := keyPattern.FindStringSubmatch(name)
         keyString := submatches[1]
         return *core.NewKey(keyString)
@@ -153,9 +164,19 @@ func (f ItemFactory) CreatePieceOfPaper(name string, filename string) *core.Item
     return emptyPaper
 }
 
+// fittedPartSeparator separates the weapon from its parts in the encoded form,
+// eg. Fitted(Pistol; Pistol Silencer; Tranquilizer Rounds)
+const fittedPartSeparator = "; "
+
 // need a way to convert items to strings
 func EncodeItemAsString(item *core.Item) string {
-    if item.Type == core.ItemTypeKeyCard {
+    if len(item.Attachments) > 0 {
+        names := []string{item.Name}
+        for _, part := range item.Attachments {
+            names = append(names, EncodeItemAsString(part))
+        }
+        return fmt.Sprintf("Fitted(%s)", strings.Join(names, fittedPartSeparator))
+    } else if item.Type == core.ItemTypeKeyCard {
         return fmt.Sprintf("KeyCard(%s)", item.KeyString)
     } else if item.Type == core.ItemTypeKey {
         return fmt.Sprintf("Key(%s)", item.KeyString)
@@ -0,0 +1,190 @@
+package services
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	rec_files "github.com/memmaker/terminal-assassin/rec-files"
+)
+
+const LeaderboardDirectory = "leaderboards"
+
+// LeaderboardSize is the number of entries a ranking shows.
+const LeaderboardSize = 10
+
+type LeaderboardCategory string
+
+const (
+	LeaderboardFastest       LeaderboardCategory = "Fastest completion"
+	LeaderboardHighestScore  LeaderboardCategory = "Highest score"
+	LeaderboardFewestAlerted LeaderboardCategory = "Fewest NPCs alerted"
+)
+
+var LeaderboardCategories = []LeaderboardCategory{LeaderboardFastest, LeaderboardHighestScore, LeaderboardFewestAlerted}
+
+// LeaderboardEntry is a successful mission whose replay was verified by re-simulating it.
+type LeaderboardEntry struct {
+	PlayerName string
+	ReplayPath string
+	RecordedAt time.Time
+	Outcome    ReplayOutcome
+}
+
+// Leaderboard holds the verified entries of one map, it is stored in LeaderboardDirectory.
+type Leaderboard struct {
+	MapHash string
+	MapPath string
+	Entries []LeaderboardEntry
+}
+
+func leaderboardFilename(mapHash string) string {
+	return filepath.Join(LeaderboardDirectory, mapHash+".txt")
+}
+
+// LoadLeaderboard returns the leaderboard of the map, it is empty if there is none yet.
+func LoadLeaderboard(mapHash, mapPath string) *Leaderboard {
+	leaderboard, err := LoadLeaderboardFile(leaderboardFilename(mapHash))
+	if err != nil {
+		return &Leaderboard{MapHash: mapHash, MapPath: mapPath}
+	}
+	return leaderboard
+}
+
+// LoadLeaderboardFile reads a leaderboard file, eg. one that was shared by a friend.
+func LoadLeaderboardFile(filename string) (*Leaderboard, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	records := rec_files.Read(file)
+	if len(records) < 1 {
+		return nil, fmt.Errorf("empty leaderboard file")
+	}
+	header := records[0].ToMap()
+	leaderboard := &Leaderboard{MapHash: header["MapHash"], MapPath: header["MapPath"]}
+	for _, record := range records[1:] {
+		fields := record.ToMap()
+		recordedAt, _ := time.Parse(time.RFC3339, fields["RecordedAt"])
+		leaderboard.Entries = append(leaderboard.Entries, LeaderboardEntry{
+			PlayerName: fields["Player"],
+			ReplayPath: fields["Replay"],
+			RecordedAt: recordedAt,
+			Outcome:    replayOutcomeFromFields("", fields),
+		})
+	}
+	return leaderboard, nil
+}
+
+// ListLeaderboards returns the leaderboards of all maps that have one.
+func ListLeaderboards() []*Leaderboard {
+	entries, err := os.ReadDir(LeaderboardDirectory)
+	if err != nil {
+		return nil
+	}
+	leaderboards := make([]*Leaderboard, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".txt") {
+			continue
+		}
+		leaderboard, err := LoadLeaderboardFile(filepath.Join(LeaderboardDirectory, entry.Name()))
+		if err != nil {
+			println("Error reading leaderboard: " + err.Error())
+			continue
+		}
+		leaderboards = append(leaderboards, leaderboard)
+	}
+	sort.Slice(leaderboards, func(i, j int) bool { return leaderboards[i].MapPath < leaderboards[j].MapPath })
+	return leaderboards
+}
+
+// Save writes the leaderboard to LeaderboardDirectory.
+func (l *Leaderboard) Save() error {
+	if err := os.MkdirAll(LeaderboardDirectory, 0755); err != nil {
+		return err
+	}
+	file, err := os.Create(leaderboardFilename(l.MapHash))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	records := []rec_files.Record{{
+		{Name: "MapHash", Value: l.MapHash},
+		{Name: "MapPath", Value: l.MapPath},
+	}}
+	for _, entry := range l.Entries {
+		record := rec_files.Record{
+			{Name: "Player", Value: entry.PlayerName},
+			{Name: "Replay", Value: entry.ReplayPath},
+			{Name: "RecordedAt", Value: entry.RecordedAt.Format(time.RFC3339)},
+		}
+		records = append(records, append(record, entry.Outcome.toFields("")...))
+	}
+	return rec_files.Write(file, records)
+}
+
+// Contains is true when the replay already has an entry on the leaderboard.
+func (l *Leaderboard) Contains(replayPath string) bool {
+	for _, entry := range l.Entries {
+		if entry.ReplayPath == replayPath {
+			return true
+		}
+	}
+	return false
+}
+
+// Add puts a verified entry on the leaderboard. Only successful missions are accepted.
+func (l *Leaderboard) Add(entry LeaderboardEntry) bool {
+	if !entry.Outcome.Success || l.Contains(entry.ReplayPath) {
+		return false
+	}
+	l.Entries = append(l.Entries, entry)
+	return true
+}
+
+// Ranking returns the best entries of the category. A challenge ID above zero only ranks
+// the missions that completed this challenge.
+func (l *Leaderboard) Ranking(category LeaderboardCategory, challengeID int) []LeaderboardEntry {
+	ranking := make([]LeaderboardEntry, 0, len(l.Entries))
+	for _, entry := range l.Entries {
+		if challengeID > 0 && !entry.Outcome.HasChallenge(challengeID) {
+			continue
+		}
+		ranking = append(ranking, entry)
+	}
+	sort.SliceStable(ranking, func(i, j int) bool {
+		a, b := ranking[i].Outcome, ranking[j].Outcome
+		switch category {
+		case LeaderboardHighestScore:
+			return a.Score > b.Score
+		case LeaderboardFewestAlerted:
+			if a.NPCsAlerted != b.NPCsAlerted {
+				return a.NPCsAlerted < b.NPCsAlerted
+			}
+		}
+		return a.Seconds < b.Seconds
+	})
+	return ranking[:min(len(ranking), LeaderboardSize)]
+}
+
+// ChallengeIDs returns the IDs of all challenges that at least one entry completed, in ascending order.
+func (l *Leaderboard) ChallengeIDs() []int {
+	found := make(map[int]bool)
+	for _, entry := range l.Entries {
+		for _, id := range entry.Outcome.Challenges {
+			found[id] = true
+		}
+	}
+	ids := make([]int, 0, len(found))
+	for id := range found {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	return ids
+}
@@ -0,0 +1,107 @@
+package services
+
+import (
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/memmaker/terminal-assassin/game/core"
+	"github.com/memmaker/terminal-assassin/gridmap"
+)
+
+// LoadoutGearSlots is the number of unlocked items the player can take into a mission,
+// the Deep Pockets skill adds one more.
+const LoadoutGearSlots = 3
+
+// StashPointPrefix marks the named locations where an item can be smuggled in, eg. "Stash: Kitchen".
+const StashPointPrefix = "Stash"
+
+// Loadout is the plan for a mission that the player makes in the briefing.
+// Empty fields mean the default: the spawn of the map, no gear and no stash.
+type Loadout struct {
+	StartLocation string
+	Gear          []string
+	StashItem     string
+	StashPoint    string
+}
+
+// StashPoints returns the names of the named locations that can be used as stash points.
+func StashPoints(missionMap *gridmap.GridMap[*core.Actor, *core.Item, Object]) []string {
+	stashPoints := make([]string, 0)
+	for name := range missionMap.NamedLocations {
+		if strings.HasPrefix(name, StashPointPrefix) {
+			stashPoints = append(stashPoints, name)
+		}
+	}
+	sort.Strings(stashPoints)
+	return stashPoints
+}
+
+func (c *CareerData) AddUnlockedItem(missionMap *gridmap.GridMap[*core.Actor, *core.Item, Object], itemName string) {
+	c.mapStatisticsFor(missionMap.MapHash(), missionMap.MapFileName()).UnlockedItems.Add(itemName)
+}
+
+// UnlockedItemsFor returns the items unlocked by mastering the map in alphabetical order.
+func (c *CareerData) UnlockedItemsFor(missionMap *gridmap.GridMap[*core.Actor, *core.Item, Object]) []string {
+	stats, ok := c.MapStatistics[missionMap.MapHash()]
+	if !ok || stats.UnlockedItems == nil {
+		return nil
+	}
+	items := stats.UnlockedItems.ToSlice()
+	sort.Strings(items)
+	return items
+}
+
+// UnlockedStartLocationsFor returns the start locations of the map that were unlocked
+// and exist as named locations, in alphabetical order.
+func (c *CareerData) UnlockedStartLocationsFor(missionMap *gridmap.GridMap[*core.Actor, *core.Item, Object]) []string {
+	stats, ok := c.MapStatistics[missionMap.MapHash()]
+	if !ok || stats.UnlockedLocations == nil {
+		return nil
+	}
+	locations := make([]string, 0)
+	for _, name := range stats.UnlockedLocations.ToSlice() {
+		if _, exists := missionMap.NamedLocations[name]; exists {
+			locations = append(locations, name)
+		}
+	}
+	sort.Strings(locations)
+	return locations
+}
+
+// GearSlots returns the number of unlocked items the player can take into a mission.
+func (c *CareerData) GearSlots() int {
+	if c.UnlockedSkills.DeepPockets {
+		return LoadoutGearSlots + 1
+	}
+	return LoadoutGearSlots
+}
+
+func (c *CareerData) SetLoadout(missionMap *gridmap.GridMap[*core.Actor, *core.Item, Object], loadout Loadout) {
+	c.mapStatisticsFor(missionMap.MapHash(), missionMap.MapFileName()).Loadout = loadout
+}
+
+// LoadoutFor returns the saved loadout of the map without the choices that are no longer
+// available, eg. because the map was changed.
+func (c *CareerData) LoadoutFor(missionMap *gridmap.GridMap[*core.Actor, *core.Item, Object]) Loadout {
+	stats, ok := c.MapStatistics[missionMap.MapHash()]
+	if !ok {
+		return Loadout{}
+	}
+	saved := stats.Loadout
+	unlockedItems := c.UnlockedItemsFor(missionMap)
+	valid := Loadout{}
+	if slices.Contains(c.UnlockedStartLocationsFor(missionMap), saved.StartLocation) {
+		valid.StartLocation = saved.StartLocation
+	}
+	for _, item := range saved.Gear {
+		if slices.Contains(unlockedItems, item) && len(valid.Gear) < c.GearSlots() {
+			valid.Gear = append(valid.Gear, item)
+		}
+	}
+	if slices.Contains(unlockedItems, saved.StashItem) && slices.Contains(StashPoints(missionMap), saved.StashPoint) {
+		valid.StashItem = saved.StashItem
+		valid.StashPoint = saved.StashPoint
+	}
+	return valid
+}
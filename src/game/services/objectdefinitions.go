@@ -0,0 +1,352 @@
+package services
+
+import (
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/memmaker/terminal-assassin/game/core"
+	"github.com/memmaker/terminal-assassin/game/stimuli"
+	rec_files "github.com/memmaker/terminal-assassin/rec-files"
+)
+
+// ObjectDefinition describes a map object in objects.txt. eg:
+//
+//	name: vending machine
+//	icon: V
+//	walkable: false
+//	transparent: true
+//	initial_state: stocked
+//	state: stocked | V | a vending machine
+//	state: empty | v | an empty vending machine
+//	state: broken | % | a broken vending machine | walkable
+//	action: stocked -> empty | DispenseItem(Soda Can); Noise(5)
+//	reaction: blunt_damage 50: * -> broken | Stims(ElectricStims(60))
+//
+// States inherit icon, description, walkable, transparent and projectile_passable
+// from the object, the flags walkable, blocking, transparent, opaque, passable
+// and impassable override them. An object without states has a single "default" state.
+//
+// Actions are used by persons, reactions are triggered by stimuli with at least
+// the given force. Both may start in any state with "*". Known effects are
+// Message(text), DispenseItem(item name), Search, Stims(StimsCall(args)),
+// Noise(radius) and Illegal.
+//
+// Containers ("container: true") hold items that are taken with the Search effect.
+// Locked objects ("lock: mechanical" or "lock: electronic", "lock_difficulty: hard")
+// have to be unlocked before their actions can be used.
+type ObjectDefinition struct {
+	Name           string
+	Icon           rune
+	InitialState   string
+	States         map[string]ObjectStateDefinition
+	Actions        []ObjectTransitionDefinition
+	Reactions      []ObjectReactionDefinition
+	Container      bool
+	HasLock        bool
+	LockType       core.LockType
+	LockDifficulty core.LockDifficulty
+	Fuel           int
+}
+
+type ObjectStateDefinition struct {
+	Name               string
+	Icon               rune
+	Description        string
+	Walkable           bool
+	Transparent        bool
+	ProjectilePassable bool
+}
+
+type ObjectTransitionDefinition struct {
+	From    string
+	To      string
+	Effects []ObjectEffectDefinition
+}
+
+// IsPossibleIn is true if the transition can start in the given state.
+func (t ObjectTransitionDefinition) IsPossibleIn(state string) bool {
+	return t.From == "*" || t.From == state
+}
+
+type ObjectReactionDefinition struct {
+	Stimulus  stimuli.StimulusType
+	Threshold int
+	ObjectTransitionDefinition
+}
+
+type ObjectEffectDefinition struct {
+	Kind     string
+	Argument string
+	// Stims is only set for the Stims effect
+	Stims stimuli.StimEffect
+}
+
+const defaultObjectState = "default"
+
+func NewObjectDefinitionFromRecord(record rec_files.Record, context *EvalContext) (ObjectDefinition, error) {
+	values := record.ToMap()
+	definition := ObjectDefinition{
+		Name:           values["name"],
+		Icon:           '?',
+		States:         make(map[string]ObjectStateDefinition),
+		LockDifficulty: core.NewLockDifficultyFromString(values["lock_difficulty"]),
+		Container:      values["container"] == "true",
+	}
+	if definition.Name == "" {
+		return definition, fmt.Errorf("object definition without name")
+	}
+	if runes := []rune(values["icon"]); len(runes) > 0 {
+		definition.Icon = runes[0]
+	}
+	switch values["lock"] {
+	case "mechanical":
+		definition.HasLock = true
+		definition.LockType = core.LockTypeMechanical
+	case "electronic":
+		definition.HasLock = true
+		definition.LockType = core.LockTypeElectronic
+	}
+	definition.Fuel, _ = strconv.Atoi(values["fuel"])
+	description := values["description"]
+	if description == "" {
+		description = definition.Name
+	}
+	baseState := ObjectStateDefinition{
+		Name:               defaultObjectState,
+		Icon:               definition.Icon,
+		Description:        description,
+		Walkable:           values["walkable"] == "true",
+		Transparent:        values["transparent"] != "false",
+		ProjectilePassable: values["projectile_passable"] == "true",
+	}
+
+	for _, field := range record {
+		var err error
+		switch field.Name {
+		case "state":
+			var state ObjectStateDefinition
+			state, err = parseObjectState(field.Value, baseState)
+			if err == nil {
+				definition.States[state.Name] = state
+				if definition.InitialState == "" {
+					definition.InitialState = state.Name
+				}
+			}
+		case "action":
+			var action ObjectTransitionDefinition
+			action, err = parseObjectTransition(field.Value, context)
+			definition.Actions = append(definition.Actions, action)
+		case "reaction":
+			var reaction ObjectReactionDefinition
+			reaction, err = parseObjectReaction(field.Value, context)
+			definition.Reactions = append(definition.Reactions, reaction)
+		}
+		if err != nil {
+			return definition, fmt.Errorf("object '%s': %s", definition.Name, err.Error())
+		}
+	}
+
+	if len(definition.States) == 0 {
+		definition.States[defaultObjectState] = baseState
+		definition.InitialState = defaultObjectState
+	}
+	if initialState := values["initial_state"]; initialState != "" {
+		definition.InitialState = initialState
+	}
+	if _, ok := definition.States[definition.InitialState]; !ok {
+		return definition, fmt.Errorf("object '%s': unknown initial state '%s'", definition.Name, definition.InitialState)
+	}
+	for _, transition := range definition.allTransitions() {
+		if _, ok := definition.States[transition.To]; !ok {
+			return definition, fmt.Errorf("object '%s': unknown state '%s'", definition.Name, transition.To)
+		}
+	}
+	return definition, nil
+}
+
+func (d ObjectDefinition) allTransitions() []ObjectTransitionDefinition {
+	transitions := append([]ObjectTransitionDefinition{}, d.Actions...)
+	for _, reaction := range d.Reactions {
+		transitions = append(transitions, reaction.ObjectTransitionDefinition)
+	}
+	return transitions
+}
+
+// state | icon | description | flags...
+func parseObjectState(value string, base ObjectStateDefinition) (ObjectStateDefinition, error) {
+	parts := trimmedSplit(value, "|")
+	if parts[0] == "" {
+		return base, fmt.Errorf("state without name: %s", value)
+	}
+	state := base
+	state.Name = parts[0]
+	if len(parts) > 1 && parts[1] != "" {
+		state.Icon = []rune(parts[1])[0]
+	}
+	if len(parts) > 2 && parts[2] != "" {
+		state.Description = parts[2]
+	}
+	for _, flag := range parts[min(3, len(parts)):] {
+		switch flag {
+		case "walkable":
+			state.Walkable = true
+		case "blocking":
+			state.Walkable = false
+		case "transparent":
+			state.Transparent = true
+		case "opaque":
+			state.Transparent = false
+		case "passable":
+			state.ProjectilePassable = true
+		case "impassable":
+			state.ProjectilePassable = false
+		default:
+			return state, fmt.Errorf("unknown state flag '%s'", flag)
+		}
+	}
+	return state, nil
+}
+
+// from -> to | effect; effect
+func parseObjectTransition(value string, context *EvalContext) (ObjectTransitionDefinition, error) {
+	transitionPart, effectPart, _ := strings.Cut(value, "|")
+	from, to, found := strings.Cut(transitionPart, "->")
+	if !found {
+		return ObjectTransitionDefinition{}, fmt.Errorf("transition without '->': %s", value)
+	}
+	transition := ObjectTransitionDefinition{
+		From: strings.TrimSpace(from),
+		To:   strings.TrimSpace(to),
+	}
+	for _, call := range trimmedSplit(effectPart, ";") {
+		if call == "" {
+			continue
+		}
+		effect, err := parseObjectEffect(call, context)
+		if err != nil {
+			return transition, err
+		}
+		transition.Effects = append(transition.Effects, effect)
+	}
+	return transition, nil
+}
+
+// stim_type threshold: from -> to | effects
+func parseObjectReaction(value string, context *EvalContext) (ObjectReactionDefinition, error) {
+	trigger, transitionPart, found := strings.Cut(value, ":")
+	if !found {
+		return ObjectReactionDefinition{}, fmt.Errorf("reaction without ':': %s", value)
+	}
+	triggerParts := strings.Fields(trigger)
+	if len(triggerParts) != 2 {
+		return ObjectReactionDefinition{}, fmt.Errorf("reaction needs a stimulus and a threshold: %s", value)
+	}
+	stimulus := stimuli.StimulusType(triggerParts[0])
+	if !stimulus.IsKnown() {
+		return ObjectReactionDefinition{}, fmt.Errorf("unknown stimulus '%s' in reaction: %s", triggerParts[0], value)
+	}
+	threshold, err := strconv.Atoi(triggerParts[1])
+	if err != nil {
+		return ObjectReactionDefinition{}, fmt.Errorf("invalid threshold in reaction: %s", value)
+	}
+	transition, err := parseObjectTransition(transitionPart, context)
+	return ObjectReactionDefinition{
+		Stimulus:                   stimulus,
+		Threshold:                  threshold,
+		ObjectTransitionDefinition: transition,
+	}, err
+}
+
+// Name(argument), the argument may contain further calls
+func parseObjectEffect(call string, context *EvalContext) (ObjectEffectDefinition, error) {
+	effect := ObjectEffectDefinition{Kind: call}
+	if open := strings.Index(call, "("); open >= 0 {
+		if !strings.HasSuffix(call, ")") {
+			return effect, fmt.Errorf("missing ')' in effect: %s", call)
+		}
+		effect.Kind = strings.TrimSpace(call[:open])
+		effect.Argument = strings.TrimSpace(call[open+1 : len(call)-1])
+	}
+	switch effect.Kind {
+	case "Message", "DispenseItem", "Search", "Illegal":
+	case "Noise":
+		if _, err := strconv.Atoi(effect.Argument); err != nil {
+			return effect, fmt.Errorf("invalid noise radius: %s", call)
+		}
+	case "Stims":
+		stimName, _ := core.GetNameAndArgs(effect.Argument)
+		if _, ok := context.DefinedStimuli[stimName]; !ok {
+			return effect, fmt.Errorf("unknown stims: %s", stimName)
+		}
+		effect.Stims = resolveStimEffect(effect.Argument, context)
+	default:
+		return effect, fmt.Errorf("unknown effect: %s", effect.Kind)
+	}
+	return effect, nil
+}
+
+func trimmedSplit(value, separator string) []string {
+	parts := strings.Split(value, separator)
+	for i, part := range parts {
+		parts[i] = strings.TrimSpace(part)
+	}
+	return parts
+}
+
+func (e *ExternalData) LoadListOfObjectDefinitions(files DataSource, dataDir string) []ObjectDefinition {
+	definedObjects := make([]ObjectDefinition, 0)
+	evalContext := NewEvalContext(e.definedStims, e.definedTrigger, e.definedReactionTrigger)
+
+	objectFileName := path.Join(dataDir, "objects.txt")
+	file, err := files.Open(objectFileName)
+	if err != nil {
+		println(fmt.Sprintf("Could not open object definition file %s: %s", objectFileName, err.Error()))
+		return definedObjects
+	}
+	defer file.Close()
+
+	records := rec_files.Read(file)
+	for _, record := range records {
+		definition, defErr := NewObjectDefinitionFromRecord(record, evalContext)
+		if defErr != nil {
+			println("Invalid object definition: " + defErr.Error())
+			continue
+		}
+		definedObjects = append(definedObjects, definition)
+	}
+
+	println(fmt.Sprintf("Loaded %d object definitions from %s", len(definedObjects), objectFileName))
+	return definedObjects
+}
+
+// ObjectDefinitions returns the objects defined in the core data and in the
+// objects.txt of the campaign directory. Campaign objects replace core objects
+// with the same name.
+func (e *ExternalData) ObjectDefinitions(campaignDir string) []ObjectDefinition {
+	if e.campaignObjects == nil {
+		e.campaignObjects = make(map[string][]ObjectDefinition)
+	}
+	campaignObjects, loaded := e.campaignObjects[campaignDir]
+	if !loaded {
+		campaignObjects = e.LoadListOfObjectDefinitions(e.files, campaignDir)
+		e.campaignObjects[campaignDir] = campaignObjects
+	}
+	result := make([]ObjectDefinition, 0, len(e.objectDefinitions)+len(campaignObjects))
+	for _, definition := range e.objectDefinitions {
+		if !containsObjectDefinition(campaignObjects, definition.Name) {
+			result = append(result, definition)
+		}
+	}
+	return append(result, campaignObjects...)
+}
+
+func containsObjectDefinition(definitions []ObjectDefinition, name string) bool {
+	for _, definition := range definitions {
+		if definition.Name == name {
+			return true
+		}
+	}
+	return false
+}
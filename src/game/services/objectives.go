@@ -0,0 +1,313 @@
+package services
+
+import (
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/memmaker/terminal-assassin/game/core"
+	"github.com/memmaker/terminal-assassin/geometry"
+	"github.com/memmaker/terminal-assassin/gridmap"
+)
+
+// ObjectiveCheckSeconds is the game time between two evaluations of the mission objectives.
+const ObjectiveCheckSeconds = 0.5
+
+/* EXAMPLE OBJECTIVES FILE CONTENTS (objectives.txt in the map folder):
+
+# required - Steal the ledger
+Steal(Ledger)
+
+# ordered - Eliminate the broker
+Eliminate(Viktor Petrov)
+
+# optional - Photograph the contact
+## OR-CONDITIONS
+Photograph(Mr. Grey)
+Photograph(Mrs. Grey)
+
+Ordered objectives can only be completed after all required objectives above them.
+Optional objectives don't have to be completed to leave the map.
+Maps without an objectives file have one required objective per target.
+*/
+
+type ObjectiveKind string
+
+const (
+	ObjectiveRequired ObjectiveKind = "required"
+	ObjectiveOrdered  ObjectiveKind = "ordered"
+	ObjectiveOptional ObjectiveKind = "optional"
+)
+
+type Objective struct {
+	Title     string
+	Kind      ObjectiveKind
+	condition core.CombinedPredicate
+	completed bool
+}
+
+func (o *Objective) IsCompleted() bool {
+	return o.completed
+}
+
+func (o *Objective) IsOptional() bool {
+	return o.Kind == ObjectiveOptional
+}
+
+// MissionObjectives are the goals of the current mission. Once completed,
+// an objective stays completed, even if its conditions don't hold anymore.
+type MissionObjectives struct {
+	Objectives []*Objective
+}
+
+// Update evaluates all open objectives and returns the ones that were completed just now.
+func (o *MissionObjectives) Update() []*Objective {
+	newlyCompleted := make([]*Objective, 0)
+	previousCompleted := true
+	for _, objective := range o.Objectives {
+		isBlocked := objective.Kind == ObjectiveOrdered && !previousCompleted
+		if !objective.completed && !isBlocked && objective.condition.Evaluate() {
+			objective.completed = true
+			newlyCompleted = append(newlyCompleted, objective)
+		}
+		if !objective.IsOptional() {
+			previousCompleted = previousCompleted && objective.completed
+		}
+	}
+	return newlyCompleted
+}
+
+// RequiredCompleted is true once all objectives that are not optional have been completed.
+func (o *MissionObjectives) RequiredCompleted() bool {
+	for _, objective := range o.Objectives {
+		if !objective.IsOptional() && !objective.completed {
+			return false
+		}
+	}
+	return true
+}
+
+// Progress returns the number of completed objectives and the number of all objectives.
+func (o *MissionObjectives) Progress() (int, int) {
+	completed := 0
+	for _, objective := range o.Objectives {
+		if objective.completed {
+			completed++
+		}
+	}
+	return completed, len(o.Objectives)
+}
+
+type ObjectiveParser struct {
+	*core.Logic
+	// destroyTargets are the objects at the named locations when the mission starts.
+	destroyTargets map[string]Object
+}
+
+func NewObjectiveParser(engine Engine) *ObjectiveParser {
+	currentMap := engine.GetGame().GetMap()
+	parser := &ObjectiveParser{
+		Logic:          core.NewLogicCore(currentMap.Player),
+		destroyTargets: make(map[string]Object),
+	}
+	for name, location := range currentMap.NamedLocations {
+		if currentMap.IsObjectAt(location) {
+			parser.destroyTargets[name] = currentMap.ObjectAt(location)
+		}
+	}
+	parser.registerObjectivePredicates(engine)
+	return parser
+}
+
+func (p *ObjectiveParser) registerObjectivePredicates(engine Engine) {
+	game := engine.GetGame()
+	currentMap := game.GetMap()
+	findActor := func(name string) *core.Actor {
+		for _, actor := range currentMap.Actors() {
+			if actor.Name == name {
+				return actor
+			}
+		}
+		for _, actor := range currentMap.DownedActors() {
+			if actor.Name == name {
+				return actor
+			}
+		}
+		return nil
+	}
+
+	p.RegisterPredicate("Eliminate", func(args ...any) bool {
+		actorName := args[0].(string)
+		for _, kill := range game.GetStats().Kills {
+			if kill.VictimName == actorName {
+				return true
+			}
+		}
+		return false
+	})
+
+	// Extract is completed when the actor, dead or alive, is in a drop-off zone.
+	p.RegisterPredicate("Extract", func(args ...any) bool {
+		actor := findActor(args[0].(string))
+		if actor == nil {
+			return false
+		}
+		zone := currentMap.ZoneAt(actor.Pos())
+		return zone != nil && zone.IsDropOff()
+	})
+
+	// Destroy refers to the object at a named location when the mission starts.
+	// It is completed when the object is gone or broken.
+	p.RegisterPredicate("Destroy", func(args ...any) bool {
+		locationName := args[0].(string)
+		target, wasPresent := p.destroyTargets[locationName]
+		if !wasPresent {
+			return false
+		}
+		location := currentMap.NamedLocations[locationName]
+		if !currentMap.IsObjectAt(location) || currentMap.ObjectAt(location) != target {
+			return true
+		}
+		breakable, isBreakable := target.(Breakable)
+		return isBreakable && breakable.IsBroken()
+	})
+
+	p.RegisterPredicate("Steal", func(args ...any) bool {
+		itemName := args[0].(string)
+		if currentMap.Player.Inventory == nil {
+			return false
+		}
+		for _, item := range currentMap.Player.Inventory.Items {
+			if item.Name == itemName {
+				return true
+			}
+		}
+		return false
+	})
+
+	p.RegisterPredicate("Photograph", func(args ...any) bool {
+		actorName := args[0].(string)
+		for _, photo := range game.GetStats().Photos {
+			for _, sighting := range photo.VisibleActors {
+				if sighting.Name == actorName {
+					return true
+				}
+			}
+		}
+		return false
+	})
+
+	// Reach accepts the name of a named location or of a zone.
+	p.RegisterPredicate("Reach", func(args ...any) bool {
+		locationName := args[0].(string)
+		playerPos := currentMap.Player.Pos()
+		if location, isNamed := currentMap.NamedLocations[locationName]; isNamed {
+			return geometry.DistanceChebyshev(playerPos, location) <= 1
+		}
+		zone := currentMap.ZoneAt(playerPos)
+		return zone != nil && zone.Name == locationName
+	})
+}
+
+func (p *ObjectiveParser) parse(contents string) *MissionObjectives {
+	objectives := &MissionObjectives{}
+	objectiveTitlePattern := regexp.MustCompile(`^# (required|ordered|optional) - (.+)$`)
+	var current *Objective
+	state := ChallengeReadStateAndConditions
+
+	for _, line := range strings.Split(contents, "\n") {
+		line = strings.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		} else if line == "## AND-CONDITIONS" {
+			state = ChallengeReadStateAndConditions
+		} else if line == "## OR-CONDITIONS" {
+			state = ChallengeReadStateOrConditions
+		} else if objectiveTitlePattern.MatchString(line) {
+			matches := objectiveTitlePattern.FindStringSubmatch(line)
+			current = &Objective{Title: matches[2], Kind: ObjectiveKind(matches[1])}
+			objectives.Objectives = append(objectives.Objectives, current)
+			state = ChallengeReadStateAndConditions
+		} else if current != nil && core.LooksLikeAFunction(line) {
+			if state == ChallengeReadStateOrConditions {
+				current.condition = current.condition.Or(p.LineToPredicate(line))
+			} else {
+				current.condition = current.condition.And(p.LineToPredicate(line))
+			}
+		}
+	}
+
+	for _, objective := range objectives.Objectives {
+		if objective.condition.IsEmpty() {
+			println("WARNING: Objective '" + objective.Title + "' has no conditions and can never be completed")
+			objective.condition = objective.condition.And(func() bool { return false })
+		}
+	}
+	return objectives
+}
+
+// targetObjectives are used for maps without an objectives file.
+func (p *ObjectiveParser) targetObjectives(currentMap *gridmap.GridMap[*core.Actor, *core.Item, Object]) *MissionObjectives {
+	objectives := &MissionObjectives{}
+	for _, actor := range append(currentMap.Actors(), currentMap.DownedActors()...) {
+		if !actor.IsTarget {
+			continue
+		}
+		objectives.Objectives = append(objectives.Objectives, p.eliminateObjective(actor.Name))
+	}
+	return objectives
+}
+
+func (p *ObjectiveParser) eliminateObjective(actorName string) *Objective {
+	return &Objective{
+		Title:     "Eliminate " + actorName,
+		Kind:      ObjectiveRequired,
+		condition: core.CombinedPredicate{}.And(p.LineToPredicate(fmt.Sprintf("Eliminate(%s)", actorName))),
+	}
+}
+
+// addEscalationTargets makes eliminating the extra targets of the escalation level required,
+// unless the objectives file already has an objective for them.
+func (p *ObjectiveParser) addEscalationTargets(objectives *MissionObjectives, level *EscalationLevel) {
+	if level == nil {
+		return
+	}
+	for _, targetName := range level.ExtraTargets {
+		objective := p.eliminateObjective(targetName)
+		if !slices.ContainsFunc(objectives.Objectives, func(o *Objective) bool { return o.Title == objective.Title }) {
+			objectives.Objectives = append(objectives.Objectives, objective)
+		}
+	}
+}
+
+// LoadMissionObjectives reads the objectives.txt of the current map.
+// Without one, every target has to be eliminated.
+// The extra targets of an escalation level always have to be eliminated.
+func LoadMissionObjectives(engine Engine) *MissionObjectives {
+	files := engine.GetFiles()
+	currentMap := engine.GetGame().GetMap()
+	parser := NewObjectiveParser(engine)
+	objectivesFilename := path.Join(currentMap.MapFileName(), "objectives.txt")
+	// contracts bring their own targets, the objectives of the map don't apply to them
+	if engine.GetGame().GetContract() != nil || !files.FileExists(objectivesFilename) {
+		return parser.targetObjectives(currentMap)
+	}
+	objectivesFile, err := files.Open(objectivesFilename)
+	if err != nil {
+		println("Error opening objectives file: " + objectivesFilename)
+		return parser.targetObjectives(currentMap)
+	}
+	defer objectivesFile.Close()
+	contents, err := io.ReadAll(objectivesFile)
+	if err != nil {
+		println("Error reading objectives file: " + objectivesFilename)
+		return parser.targetObjectives(currentMap)
+	}
+	objectives := parser.parse(string(contents))
+	parser.addEscalationTargets(objectives, engine.GetGame().GetEscalation())
+	println(fmt.Sprintf("Loaded %d objectives from %s", len(objectives.Objectives), objectivesFilename))
+	return objectives
+}
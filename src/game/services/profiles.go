@@ -0,0 +1,288 @@
+package services
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/memmaker/terminal-assassin/mapset"
+	rec_files "github.com/memmaker/terminal-assassin/rec-files"
+)
+
+// CareerFormatVersion is the version of the profile format written by SaveToFile.
+// Version 1 was the gob encoded career.gob, see ImportLegacyCareer.
+//
+// A profile is a rec file with a header record, one record per played map
+// and one record per completed challenge, eg:
+//
+//	version: 2
+//	player_name: 47
+//	campaign: first blood
+//	experience: 1200
+//	money: 300
+//	double_assassination: true
+//
+//	map_hash: 3f2a...
+//	file_name: mansion
+//	finish_count: 2
+//	...
+const CareerFormatVersion = 2
+
+// LegacyCareerFile is where single player installs kept their career.
+const LegacyCareerFile = "career.gob"
+
+const profileExtension = ".txt"
+
+// careerMigrations upgrade the records of a profile from the version of the key to the next one.
+// When the format changes, bump CareerFormatVersion and add the step from the previous version here.
+var careerMigrations = map[int]func(records []rec_files.Record) []rec_files.Record{}
+
+// ProfileDirectory returns the directory of the player profiles in the user's config directory.
+// Without one, eg. in the browser, the profiles are kept in the working directory.
+func ProfileDirectory() string {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "profiles"
+	}
+	return filepath.Join(configDir, "terminal-assassin", "profiles")
+}
+
+func profilePath(name string) string {
+	return filepath.Join(ProfileDirectory(), name+profileExtension)
+}
+
+// NewCareer returns a fresh career for the player.
+func NewCareer(playerName string) *CareerData {
+	return &CareerData{
+		PlayerName:       playerName,
+		ExperiencePoints: 0,
+		MapStatistics:    make(map[string]*MapStatistics),
+		UnlockedSkills:   PlayerSkills{},
+		CampaignFlags:    mapset.NewSet[string](),
+		SeenInterludes:   mapset.NewSet[string](),
+	}
+}
+
+// ListProfiles returns the names of all saved profiles in alphabetical order.
+func ListProfiles() []string {
+	entries, err := os.ReadDir(ProfileDirectory())
+	if err != nil {
+		return nil
+	}
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != profileExtension {
+			continue
+		}
+		names = append(names, strings.TrimSuffix(entry.Name(), profileExtension))
+	}
+	sort.Strings(names)
+	return names
+}
+
+// IsValidProfileName is true for names that can be used as file names.
+func IsValidProfileName(name string) bool {
+	return name != "" && !strings.ContainsAny(name, `/\:*?"<>|.`)
+}
+
+// DeleteProfile removes the saved profile.
+func DeleteProfile(name string) error {
+	return os.Remove(profilePath(name))
+}
+
+// LoadProfile reads the profile and migrates it to the current format version.
+func LoadProfile(name string) (*CareerData, error) {
+	file, err := os.Open(profilePath(name))
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	records := rec_files.Read(file)
+	if len(records) == 0 {
+		return nil, fmt.Errorf("profile '%s' is empty", name)
+	}
+	version, err := strconv.Atoi(records[0].ToMap()["version"])
+	if err != nil {
+		return nil, fmt.Errorf("profile '%s' has no valid version header", name)
+	}
+	if version > CareerFormatVersion {
+		return nil, fmt.Errorf("profile '%s' was saved by a newer version of the game (format %d)", name, version)
+	}
+	for ; version < CareerFormatVersion; version++ {
+		migrate, ok := careerMigrations[version]
+		if !ok {
+			return nil, fmt.Errorf("profile '%s' can't be migrated from format %d", name, version)
+		}
+		records = migrate(records)
+		println(fmt.Sprintf("Migrated profile '%s' from format %d to %d", name, version, version+1))
+	}
+	return newCareerFromRecords(records), nil
+}
+
+func newCareerFromRecords(records []rec_files.Record) *CareerData {
+	header := records[0].ToMap()
+	career := NewCareer(header["player_name"])
+	career.CurrentCampaignFolder = header["campaign"]
+	career.ExperiencePoints, _ = strconv.ParseUint(header["experience"], 10, 64)
+	career.Money, _ = strconv.ParseUint(header["money"], 10, 64)
+	career.SkillPointsSpent, _ = strconv.ParseUint(header["skill_points_spent"], 10, 64)
+	for _, skill := range SkillTree {
+		*skill.unlocked(&career.UnlockedSkills) = header[skill.Key] == "true"
+	}
+	for _, flag := range trimmedSplit(header["campaign_flags"], ";") {
+		if flag != "" {
+			career.CampaignFlags.Add(flag)
+		}
+	}
+	for _, interlude := range trimmedSplit(header["seen_interludes"], ";") {
+		if interlude != "" {
+			career.SeenInterludes.Add(interlude)
+		}
+	}
+
+	for _, record := range records[1:] {
+		fields := record.ToMap()
+		if hash, isMap := fields["map_hash"]; isMap {
+			stats := career.mapStatisticsFor(hash, fields["file_name"])
+			stats.FinishCount, _ = strconv.Atoi(fields["finish_count"])
+			stats.EscalationLevel, _ = strconv.Atoi(fields["escalation_level"])
+			stats.BestScore, _ = strconv.Atoi(fields["best_score"])
+			stats.BestRating = fields["best_rating"]
+			stats.TotalBodyCount, _ = strconv.Atoi(fields["total_body_count"])
+			stats.TotalDuration, _ = time.ParseDuration(fields["total_duration"])
+			if fastest, err := time.ParseDuration(fields["fastest_duration"]); err == nil {
+				stats.FastestDuration = fastest
+			}
+			for _, location := range trimmedSplit(fields["unlocked_locations"], ";") {
+				if location != "" {
+					stats.UnlockedLocations.Add(location)
+				}
+			}
+			for _, item := range trimmedSplit(fields["unlocked_items"], ";") {
+				if item != "" {
+					stats.UnlockedItems.Add(item)
+				}
+			}
+			stats.Loadout = Loadout{
+				StartLocation: fields["loadout_start"],
+				StashItem:     fields["loadout_stash_item"],
+				StashPoint:    fields["loadout_stash_point"],
+			}
+			for _, item := range trimmedSplit(fields["loadout_gear"], ";") {
+				if item != "" {
+					stats.Loadout.Gear = append(stats.Loadout.Gear, item)
+				}
+			}
+		} else if hash, isChallenge := fields["challenge_map"]; isChallenge {
+			challenge := DiskChallenge{ChallengeName: fields["name"]}
+			challenge.Identifier, _ = strconv.Atoi(fields["identifier"])
+			challenge.ChallengeReward, _ = strconv.Atoi(fields["reward"])
+			challenge.FastestTime, _ = time.ParseDuration(fields["fastest_time"])
+			career.mapStatisticsFor(hash, "").CompletedChallenges[challenge.Identifier] = challenge
+		}
+	}
+	return career
+}
+
+// mapStatisticsFor returns the statistics of the map, they are created on first use.
+// The file name is only set if the statistics don't have one yet.
+func (c *CareerData) mapStatisticsFor(hash string, fileName string) *MapStatistics {
+	stats, ok := c.MapStatistics[hash]
+	if !ok {
+		stats = &MapStatistics{
+			FileHash:            hash,
+			FastestDuration:     math.MaxInt64,
+			CompletedChallenges: make(map[int]DiskChallenge),
+			UnlockedLocations:   mapset.NewSet[string](),
+			UnlockedItems:       mapset.NewSet[string](),
+		}
+		c.MapStatistics[hash] = stats
+	}
+	if stats.FileName == "" {
+		stats.FileName = fileName
+	}
+	if stats.CompletedChallenges == nil {
+		stats.CompletedChallenges = make(map[int]DiskChallenge)
+	}
+	if stats.UnlockedLocations == nil {
+		stats.UnlockedLocations = mapset.NewSet[string]()
+	}
+	if stats.UnlockedItems == nil {
+		stats.UnlockedItems = mapset.NewSet[string]()
+	}
+	return stats
+}
+
+func (c *CareerData) toRecords() []rec_files.Record {
+	records := []rec_files.Record{{
+		{Name: "version", Value: strconv.Itoa(CareerFormatVersion)},
+		{Name: "player_name", Value: c.PlayerName},
+		{Name: "campaign", Value: c.CurrentCampaignFolder},
+		{Name: "experience", Value: strconv.FormatUint(c.ExperiencePoints, 10)},
+		{Name: "money", Value: strconv.FormatUint(c.Money, 10)},
+		{Name: "skill_points_spent", Value: strconv.FormatUint(c.SkillPointsSpent, 10)},
+		{Name: "campaign_flags", Value: strings.Join(sortedSetValues(c.CampaignFlags), "; ")},
+		{Name: "seen_interludes", Value: strings.Join(sortedSetValues(c.SeenInterludes), "; ")},
+	}}
+	for _, skill := range SkillTree {
+		records[0] = append(records[0], rec_files.Field{Name: skill.Key, Value: strconv.FormatBool(skill.IsUnlocked(c.UnlockedSkills))})
+	}
+	hashes := make([]string, 0, len(c.MapStatistics))
+	for hash := range c.MapStatistics {
+		hashes = append(hashes, hash)
+	}
+	sort.Strings(hashes)
+	for _, hash := range hashes {
+		stats := c.MapStatistics[hash]
+		locations := sortedSetValues(stats.UnlockedLocations)
+		items := sortedSetValues(stats.UnlockedItems)
+		records = append(records, rec_files.Record{
+			{Name: "map_hash", Value: hash},
+			{Name: "file_name", Value: stats.FileName},
+			{Name: "finish_count", Value: strconv.Itoa(stats.FinishCount)},
+			{Name: "escalation_level", Value: strconv.Itoa(stats.EscalationLevel)},
+			{Name: "best_score", Value: strconv.Itoa(stats.BestScore)},
+			{Name: "best_rating", Value: stats.BestRating},
+			{Name: "total_body_count", Value: strconv.Itoa(stats.TotalBodyCount)},
+			{Name: "total_duration", Value: stats.TotalDuration.String()},
+			{Name: "fastest_duration", Value: stats.FastestDuration.String()},
+			{Name: "unlocked_locations", Value: strings.Join(locations, "; ")},
+			{Name: "unlocked_items", Value: strings.Join(items, "; ")},
+			{Name: "loadout_start", Value: stats.Loadout.StartLocation},
+			{Name: "loadout_gear", Value: strings.Join(stats.Loadout.Gear, "; ")},
+			{Name: "loadout_stash_item", Value: stats.Loadout.StashItem},
+			{Name: "loadout_stash_point", Value: stats.Loadout.StashPoint},
+		})
+		identifiers := make([]int, 0, len(stats.CompletedChallenges))
+		for identifier := range stats.CompletedChallenges {
+			identifiers = append(identifiers, identifier)
+		}
+		sort.Ints(identifiers)
+		for _, identifier := range identifiers {
+			challenge := stats.CompletedChallenges[identifier]
+			records = append(records, rec_files.Record{
+				{Name: "challenge_map", Value: hash},
+				{Name: "identifier", Value: strconv.Itoa(challenge.Identifier)},
+				{Name: "name", Value: challenge.ChallengeName},
+				{Name: "reward", Value: strconv.Itoa(challenge.ChallengeReward)},
+				{Name: "fastest_time", Value: challenge.FastestTime.String()},
+			})
+		}
+	}
+	return records
+}
+
+// sortedSetValues returns the values of the set in a stable order for writing them to a file.
+func sortedSetValues(set mapset.Set[string]) []string {
+	if set == nil {
+		return nil
+	}
+	values := set.ToSlice()
+	sort.Strings(values)
+	return values
+}
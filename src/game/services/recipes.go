@@ -0,0 +1,118 @@
+package services
+
+import (
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/memmaker/terminal-assassin/game/core"
+	rec_files "github.com/memmaker/terminal-assassin/rec-files"
+)
+
+// RecipeDefinition describes how items in the inventory can be combined into a new one.
+// They are defined in recipes.txt, eg:
+//
+//	name: gas bomb
+//	inputs: Bomb (remote), Gas Canister
+//	output: Gas Bomb (remote)
+//	seconds: 4
+//
+// Inputs are item names, an item that is needed twice is listed twice.
+// The output is decoded by the item factory, so Lockpick(3) works as well.
+type RecipeDefinition struct {
+	Name            string
+	Inputs          []string
+	Output          string
+	CraftingSeconds float64
+}
+
+func NewRecipeFromRecord(record map[string]string) (RecipeDefinition, error) {
+	definition := RecipeDefinition{
+		Name:            record["name"],
+		Output:          strings.TrimSpace(record["output"]),
+		CraftingSeconds: 2,
+	}
+	for _, input := range trimmedSplit(record["inputs"], ",") {
+		if input != "" {
+			definition.Inputs = append(definition.Inputs, input)
+		}
+	}
+	if seconds, err := strconv.ParseFloat(record["seconds"], 64); err == nil {
+		definition.CraftingSeconds = seconds
+	}
+	if len(definition.Inputs) < 2 {
+		return definition, fmt.Errorf("recipe '%s' needs at least two inputs", definition.Name)
+	}
+	if definition.Output == "" {
+		return definition, fmt.Errorf("recipe '%s' has no output", definition.Name)
+	}
+	return definition, nil
+}
+
+// Uses is true if the item is one of the inputs.
+func (r RecipeDefinition) Uses(item *core.Item) bool {
+	for _, input := range r.Inputs {
+		if input == item.Name {
+			return true
+		}
+	}
+	return false
+}
+
+// InputsFrom picks the items for all inputs from the inventory.
+// It returns false if any of them is missing.
+func (r RecipeDefinition) InputsFrom(inventory []*core.Item) ([]*core.Item, bool) {
+	picked := make([]*core.Item, 0, len(r.Inputs))
+	isPicked := func(item *core.Item) bool {
+		for _, p := range picked {
+			if p == item {
+				return true
+			}
+		}
+		return false
+	}
+	for _, input := range r.Inputs {
+		found := false
+		for _, item := range inventory {
+			if item.Name == input && !isPicked(item) {
+				picked = append(picked, item)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, false
+		}
+	}
+	return picked, true
+}
+
+func (e *ExternalData) LoadListOfRecipes(files DataSource, dataDir string) []RecipeDefinition {
+	definedRecipes := make([]RecipeDefinition, 0)
+
+	recipeFileName := path.Join(dataDir, "recipes.txt")
+	file, err := files.Open(recipeFileName)
+	if err != nil {
+		println(fmt.Sprintf("Could not open recipes file %s: %s", recipeFileName, err.Error()))
+		return definedRecipes
+	}
+	defer file.Close()
+
+	records := rec_files.Read(file)
+	for _, record := range records {
+		definition, defErr := NewRecipeFromRecord(record.ToMap())
+		if defErr != nil {
+			println("Invalid recipe: " + defErr.Error())
+			continue
+		}
+		definedRecipes = append(definedRecipes, definition)
+	}
+
+	println(fmt.Sprintf("Loaded %d recipes from %s", len(definedRecipes), recipeFileName))
+	return definedRecipes
+}
+
+func (e *ExternalData) Recipes() []RecipeDefinition {
+	return e.recipes
+}
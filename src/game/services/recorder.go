@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/memmaker/terminal-assassin/game/core"
@@ -26,6 +27,16 @@ type ReplayFile struct {
 	MapHash      string
 	Seed         int64
 	DurationTicks uint64 // total ticks recorded; 0 = truncated/incomplete
+	Loadout      Loadout
+	Skills       PlayerSkills
+	// CampaignFlags are the flags of the campaign the mission started with.
+	CampaignFlags []string
+	// EscalationLevel and ContractCode describe the variant of the mission, they are empty for the standard mission.
+	EscalationLevel int
+	ContractCode    string
+	// Outcome is the result the recording claims, it is only set when HasOutcome is true.
+	Outcome    ReplayOutcome
+	HasOutcome bool
 	Entries      []ReplayEntry
 }
 
@@ -39,7 +50,15 @@ type Recorder struct {
 	mapPath    string
 	mapHash    string
 	seed       int64
+	loadout    Loadout
+	skills     PlayerSkills
+	campaignFlags []string
 	entries    []ReplayEntry
+
+	escalationLevel int
+	contractCode    string
+	outcome         *ReplayOutcome
+	verification    func(outcome ReplayOutcome)
 }
 
 // SetTickSource wires the recorder to the engine's WorldTick counter.
@@ -47,12 +66,19 @@ func (r *Recorder) SetTickSource(f func() uint64) {
 	r.tickFunc = f
 }
 
-// StartRecording begins capturing inputs with the given map metadata and RNG seed.
-func (r *Recorder) StartRecording(mapPath, mapHash string, seed int64) {
+// StartRecording begins capturing inputs with the given map metadata, RNG seed, the loadout and skills of the player,
+// the campaign flags and the variant of the mission, which is an escalation level or a contract code.
+func (r *Recorder) StartRecording(mapPath, mapHash string, seed int64, loadout Loadout, skills PlayerSkills, campaignFlags []string, escalationLevel int, contractCode string) {
 	r.recording = true
 	r.mapPath = mapPath
 	r.mapHash = mapHash
 	r.seed = seed
+	r.loadout = loadout
+	r.skills = skills
+	r.campaignFlags = campaignFlags
+	r.escalationLevel = escalationLevel
+	r.contractCode = contractCode
+	r.outcome = nil
 	r.entries = make([]ReplayEntry, 0, 256)
 }
 
@@ -128,6 +154,17 @@ func (r *Recorder) StopAndSave() (string, error) {
 		{Name: "MapHash",       Value: r.mapHash},
 		{Name: "Seed",          Value: strconv.FormatInt(r.seed, 10)},
 		{Name: "DurationTicks", Value: strconv.FormatUint(durationTicks, 10)},
+		{Name: "LoadoutStart",      Value: r.loadout.StartLocation},
+		{Name: "LoadoutGear",       Value: strings.Join(r.loadout.Gear, "; ")},
+		{Name: "LoadoutStashItem",  Value: r.loadout.StashItem},
+		{Name: "LoadoutStashPoint", Value: r.loadout.StashPoint},
+		{Name: "Skills",            Value: strings.Join(r.skills.Keys(), "; ")},
+		{Name: "CampaignFlags",     Value: strings.Join(r.campaignFlags, "; ")},
+		{Name: "EscalationLevel",   Value: strconv.Itoa(r.escalationLevel)},
+		{Name: "ContractCode",      Value: r.contractCode},
+	}
+	if r.outcome != nil {
+		header = append(header, r.outcome.toFields("Outcome")...)
 	}
 	records := []rec_files.Record{header}
 	for _, entry := range r.entries {
@@ -193,6 +230,34 @@ func LoadReplayFile(path string) (*ReplayFile, error) {
 		Seed:          seed,
 		DurationTicks: durationTicks,
 		Entries:       make([]ReplayEntry, 0, len(records)-1),
+		Loadout: Loadout{
+			StartLocation: header["LoadoutStart"],
+			StashItem:     header["LoadoutStashItem"],
+			StashPoint:    header["LoadoutStashPoint"],
+		},
+	}
+	for _, item := range trimmedSplit(header["LoadoutGear"], ";") {
+		if item != "" {
+			rf.Loadout.Gear = append(rf.Loadout.Gear, item)
+		}
+	}
+	if skills, hasSkills := header["Skills"]; hasSkills {
+		rf.Skills = PlayerSkillsFromKeys(trimmedSplit(skills, ";"))
+	} else {
+		// recorded before the skill tree, when every player had the double assassination
+		rf.Skills = PlayerSkills{DoubleAssassination: true}
+	}
+	rf.CampaignFlags = make([]string, 0)
+	for _, flag := range trimmedSplit(header["CampaignFlags"], ";") {
+		if flag != "" {
+			rf.CampaignFlags = append(rf.CampaignFlags, flag)
+		}
+	}
+	rf.EscalationLevel, _ = strconv.Atoi(header["EscalationLevel"])
+	rf.ContractCode = header["ContractCode"]
+	if _, hasOutcome := header["OutcomeSuccess"]; hasOutcome {
+		rf.Outcome = replayOutcomeFromFields("Outcome", header)
+		rf.HasOutcome = true
 	}
 
 	for _, record := range records[1:] {
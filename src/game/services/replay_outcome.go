@@ -0,0 +1,89 @@
+package services
+
+import (
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+
+	rec_files "github.com/memmaker/terminal-assassin/rec-files"
+)
+
+// ReplayOutcome is the result of a recorded mission. It is stored with the replay as a claim
+// and compared with the result of re-simulating the replay to verify it.
+type ReplayOutcome struct {
+	Success     bool
+	Seconds     float64
+	Score       int
+	NPCsAlerted int
+	// Challenges are the IDs of the challenges the mission completed, in ascending order.
+	Challenges []int
+}
+
+// Matches is true when both outcomes describe the same mission result.
+func (o ReplayOutcome) Matches(other ReplayOutcome) bool {
+	return o.Success == other.Success &&
+		math.Abs(o.Seconds-other.Seconds) < 0.001 &&
+		o.Score == other.Score &&
+		o.NPCsAlerted == other.NPCsAlerted &&
+		slices.Equal(o.Challenges, other.Challenges)
+}
+
+// HasChallenge is true when the mission completed the challenge with the ID.
+func (o ReplayOutcome) HasChallenge(challengeID int) bool {
+	return slices.Contains(o.Challenges, challengeID)
+}
+
+func (o ReplayOutcome) toFields(prefix string) rec_files.Record {
+	challenges := make([]string, len(o.Challenges))
+	for i, id := range o.Challenges {
+		challenges[i] = strconv.Itoa(id)
+	}
+	return rec_files.Record{
+		{Name: prefix + "Success", Value: strconv.FormatBool(o.Success)},
+		{Name: prefix + "Seconds", Value: strconv.FormatFloat(o.Seconds, 'f', 4, 64)},
+		{Name: prefix + "Score", Value: strconv.Itoa(o.Score)},
+		{Name: prefix + "NPCsAlerted", Value: strconv.Itoa(o.NPCsAlerted)},
+		{Name: prefix + "Challenges", Value: strings.Join(challenges, "; ")},
+	}
+}
+
+func replayOutcomeFromFields(prefix string, fields map[string]string) ReplayOutcome {
+	outcome := ReplayOutcome{}
+	outcome.Success, _ = strconv.ParseBool(fields[prefix+"Success"])
+	outcome.Seconds, _ = strconv.ParseFloat(fields[prefix+"Seconds"], 64)
+	outcome.Score, _ = strconv.Atoi(fields[prefix+"Score"])
+	outcome.NPCsAlerted, _ = strconv.Atoi(fields[prefix+"NPCsAlerted"])
+	for _, id := range trimmedSplit(fields[prefix+"Challenges"], ";") {
+		if challengeID, err := strconv.Atoi(id); err == nil {
+			outcome.Challenges = append(outcome.Challenges, challengeID)
+		}
+	}
+	return outcome
+}
+
+// SetOutcome stores the result of the mission with the recording. Call it before StopAndSave.
+func (r *Recorder) SetOutcome(outcome ReplayOutcome) {
+	r.outcome = &outcome
+}
+
+// StartVerification marks the next replay as a verification run. The end of the mission
+// reports its outcome to onFinished instead of updating the career.
+func (r *Recorder) StartVerification(onFinished func(outcome ReplayOutcome)) {
+	r.verification = onFinished
+}
+
+// IsVerifying returns true while a replay is re-simulated to verify it.
+func (r *Recorder) IsVerifying() bool {
+	return r.verification != nil
+}
+
+// FinishVerification ends the verification run with the outcome of the re-simulated mission.
+// Calls after the first one are ignored.
+func (r *Recorder) FinishVerification(outcome ReplayOutcome) {
+	onFinished := r.verification
+	r.verification = nil
+	if onFinished != nil {
+		onFinished(outcome)
+	}
+}
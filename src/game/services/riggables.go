@@ -0,0 +1,81 @@
+package services
+
+import (
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/memmaker/terminal-assassin/game/core"
+	"github.com/memmaker/terminal-assassin/game/stimuli"
+	rec_files "github.com/memmaker/terminal-assassin/rec-files"
+)
+
+// RiggableDefinition describes an appliance that can be rigged with a tool.
+// They are defined in riggables.txt, eg:
+//
+//	name: gas stove
+//	icon: K
+//	rig_with: wrench, crowbar
+//	effect: ExplosionAreaStims(100, 2, 4)
+//	use_seconds: 3
+type RiggableDefinition struct {
+	Name string
+	Icon rune
+	// RigWith lists the item types that can be used to rig the appliance.
+	RigWith []core.ItemType
+	// Effect is applied when the rigged appliance is used.
+	// Direct effects hit the user, distributed effects spread from the appliance.
+	Effect     stimuli.StimEffect
+	UseSeconds float64
+}
+
+func NewRiggableFromRecord(record map[string]string, context *EvalContext) RiggableDefinition {
+	definition := RiggableDefinition{
+		Name:       record["name"],
+		Icon:       '?',
+		UseSeconds: 2,
+	}
+	if runes := []rune(record["icon"]); len(runes) > 0 {
+		definition.Icon = runes[0]
+	}
+	for _, typeName := range strings.Split(record["rig_with"], ",") {
+		typeName = strings.TrimSpace(typeName)
+		if typeName == "" {
+			continue
+		}
+		definition.RigWith = append(definition.RigWith, core.NewItemTypeFromString(typeName))
+	}
+	if useSeconds, err := strconv.ParseFloat(record["use_seconds"], 64); err == nil {
+		definition.UseSeconds = useSeconds
+	}
+	if effectCall := record["effect"]; effectCall != "" {
+		definition.Effect = resolveStimEffect(effectCall, context)
+	}
+	return definition
+}
+
+func (e *ExternalData) LoadListOfRiggables(files DataSource, dataDir string) []RiggableDefinition {
+	definedRiggables := make([]RiggableDefinition, 0)
+	evalContext := NewEvalContext(e.definedStims, e.definedTrigger, e.definedReactionTrigger)
+
+	riggableFileName := path.Join(dataDir, "riggables.txt")
+	file, err := files.Open(riggableFileName)
+	if err != nil {
+		println(fmt.Sprintf("Could not open riggables file %s: %s", riggableFileName, err.Error()))
+		return definedRiggables
+	}
+	defer file.Close()
+
+	records := rec_files.Read(file)
+	for _, record := range records {
+		definedRiggables = append(definedRiggables, NewRiggableFromRecord(record.ToMap(), evalContext))
+	}
+
+	println(fmt.Sprintf("Loaded %d riggables from %s", len(definedRiggables), riggableFileName))
+	return definedRiggables
+}
+
+func (e *ExternalData) Riggables() []RiggableDefinition {
+	return e.riggables
+}
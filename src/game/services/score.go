@@ -0,0 +1,162 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/memmaker/terminal-assassin/game/core"
+	"github.com/memmaker/terminal-assassin/gridmap"
+)
+
+const (
+	ScorePerTarget          = 5000
+	ScorePerTargetPhoto     = 500
+	ScoreSilentAssassin     = 5000
+	ScoreMaxTimeBonus       = 5000
+	PenaltyPerNonTargetKill = 2000
+	PenaltyPerWitness       = 1000
+	PenaltySpotted          = 3000
+	PenaltyBodiesFound      = 3000
+	PenaltyAlarmTriggered   = 2000
+)
+
+// ScoreParSeconds is the mission time after which there is no time bonus left.
+const ScoreParSeconds = 600
+
+const RatingSilentAssassin = "Silent Assassin"
+
+// ScoreLine is one line of the score breakdown in the debriefing.
+type ScoreLine struct {
+	Label  string
+	Points int
+}
+
+func (l ScoreLine) String() string {
+	return fmt.Sprintf("%-26s %+7d", l.Label, l.Points)
+}
+
+// MissionScore is the result of a finished mission.
+type MissionScore struct {
+	Lines  []ScoreLine
+	Total  int
+	Rating string
+}
+
+func (s *MissionScore) add(label string, points int) {
+	if points == 0 {
+		return
+	}
+	s.Lines = append(s.Lines, ScoreLine{Label: label, Points: points})
+	s.Total += points
+}
+
+// CountWitnesses returns the number of living actors that saw the player do something illegal.
+func CountWitnesses(missionMap *gridmap.GridMap[*core.Actor, *core.Item, Object]) int {
+	witnessCount := 0
+	for _, actor := range missionMap.Actors() {
+		if actor.IsEyeWitness {
+			witnessCount++
+		}
+	}
+	for _, downedActor := range missionMap.DownedActors() {
+		if downedActor.IsAlive() && downedActor.IsEyeWitness {
+			witnessCount++
+		}
+	}
+	return witnessCount
+}
+
+// CalculateScore rates a successfully finished mission. The silent assassin rating needs
+// a mission without being spotted, found bodies, alarms, witnesses or non-target kills.
+func CalculateScore(missionMap *gridmap.GridMap[*core.Actor, *core.Item, Object], stats core.MissionStats) MissionScore {
+	score := MissionScore{}
+
+	targetKills := 0
+	nonTargetKills := 0
+	for _, kill := range stats.Kills {
+		if kill.IsTarget {
+			targetKills++
+		} else {
+			nonTargetKills++
+		}
+	}
+	score.add(fmt.Sprintf("Targets eliminated (%d)", targetKills), targetKills*ScorePerTarget)
+	photographed := photographedTargets(missionMap, stats)
+	score.add(fmt.Sprintf("Targets photographed (%d)", photographed), photographed*ScorePerTargetPhoto)
+
+	timeBonus := int(float64(ScoreMaxTimeBonus) * (1 - stats.SecondsNeeded/ScoreParSeconds))
+	score.add("Time bonus", max(0, timeBonus))
+
+	score.add(fmt.Sprintf("Non-target kills (%d)", nonTargetKills), -nonTargetKills*PenaltyPerNonTargetKill)
+	witnesses := CountWitnesses(missionMap)
+	score.add(fmt.Sprintf("Witnesses left (%d)", witnesses), -witnesses*PenaltyPerWitness)
+	if stats.BeenSpotted {
+		score.add("Spotted", -PenaltySpotted)
+	}
+	if stats.BodiesFound {
+		score.add("Bodies found", -PenaltyBodiesFound)
+	}
+	if stats.AlarmTriggered {
+		score.add("Alarm triggered", -PenaltyAlarmTriggered)
+	}
+
+	silentAssassin := !stats.BeenSpotted && !stats.BodiesFound && !stats.AlarmTriggered && witnesses == 0 && nonTargetKills == 0
+	if silentAssassin {
+		score.add(RatingSilentAssassin, ScoreSilentAssassin)
+	}
+	score.Total = max(0, score.Total)
+	score.Rating = rating(score.Total, silentAssassin, nonTargetKills)
+	return score
+}
+
+func rating(total int, silentAssassin bool, nonTargetKills int) string {
+	switch {
+	case silentAssassin:
+		return RatingSilentAssassin
+	case nonTargetKills >= 5:
+		return "Butcher"
+	case total >= 12000:
+		return "Professional"
+	case total >= 7000:
+		return "Specialist"
+	case total >= 3000:
+		return "Contract Killer"
+	default:
+		return "Amateur"
+	}
+}
+
+// photographedTargets counts the targets of the map that are on at least one photo of the mission.
+func photographedTargets(missionMap *gridmap.GridMap[*core.Actor, *core.Item, Object], stats core.MissionStats) int {
+	count := 0
+	for _, actors := range [][]*core.Actor{missionMap.Actors(), missionMap.DownedActors()} {
+		for _, actor := range actors {
+			if actor.IsTarget && isOnAnyPhoto(actor.Name, stats.Photos) {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+func isOnAnyPhoto(name string, photos []core.PhotoMetadata) bool {
+	for _, photo := range photos {
+		for _, sighting := range photo.VisibleActors {
+			if sighting.Name == name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// RecordScore keeps the score and rating if they are the best of the map.
+// It returns true for a new best score.
+func (c *CareerData) RecordScore(missionMap *gridmap.GridMap[*core.Actor, *core.Item, Object], score MissionScore) bool {
+	stats := c.mapStatisticsFor(missionMap.MapHash(), missionMap.MapFileName())
+	if stats.BestRating != "" && score.Total <= stats.BestScore {
+		return false
+	}
+	stats.BestScore = score.Total
+	stats.BestRating = score.Rating
+	return true
+}
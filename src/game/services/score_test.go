@@ -0,0 +1,91 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/memmaker/terminal-assassin/game/core"
+	"github.com/memmaker/terminal-assassin/geometry"
+	"github.com/memmaker/terminal-assassin/gridmap"
+)
+
+func testKills(targets, nonTargets int) []core.KillStatistics {
+	kills := make([]core.KillStatistics, 0, targets+nonTargets)
+	for i := 0; i < targets; i++ {
+		kills = append(kills, core.KillStatistics{IsTarget: true})
+	}
+	for i := 0; i < nonTargets; i++ {
+		kills = append(kills, core.KillStatistics{})
+	}
+	return kills
+}
+
+func TestCalculateScore(t *testing.T) {
+	tests := []struct {
+		name       string
+		stats      core.MissionStats
+		witnesses  int
+		wantTotal  int
+		wantRating string
+	}{
+		{
+			name:       "clean kill at half the par time",
+			stats:      core.MissionStats{Kills: testKills(1, 0), SecondsNeeded: 300},
+			wantTotal:  ScorePerTarget + ScoreMaxTimeBonus/2 + ScoreSilentAssassin,
+			wantRating: RatingSilentAssassin,
+		},
+		{
+			name:       "spotted after the par time",
+			stats:      core.MissionStats{Kills: testKills(1, 0), SecondsNeeded: 900, BeenSpotted: true},
+			wantTotal:  ScorePerTarget - PenaltySpotted,
+			wantRating: "Amateur",
+		},
+		{
+			name:       "a witness is left",
+			stats:      core.MissionStats{Kills: testKills(1, 0), SecondsNeeded: 300},
+			witnesses:  1,
+			wantTotal:  ScorePerTarget + ScoreMaxTimeBonus/2 - PenaltyPerWitness,
+			wantRating: "Contract Killer",
+		},
+		{
+			name:       "non-target kill",
+			stats:      core.MissionStats{Kills: testKills(1, 1)},
+			wantTotal:  ScorePerTarget + ScoreMaxTimeBonus - PenaltyPerNonTargetKill,
+			wantRating: "Specialist",
+		},
+		{
+			name:       "bodies found and alarm",
+			stats:      core.MissionStats{Kills: testKills(2, 0), BodiesFound: true, AlarmTriggered: true},
+			wantTotal:  2*ScorePerTarget + ScoreMaxTimeBonus - PenaltyBodiesFound - PenaltyAlarmTriggered,
+			wantRating: "Specialist",
+		},
+		{
+			name:       "alarm only",
+			stats:      core.MissionStats{Kills: testKills(2, 0), AlarmTriggered: true},
+			wantTotal:  2*ScorePerTarget + ScoreMaxTimeBonus - PenaltyAlarmTriggered,
+			wantRating: "Professional",
+		},
+		{
+			name:       "the total is never negative",
+			stats:      core.MissionStats{Kills: testKills(1, 5), SecondsNeeded: 900},
+			wantTotal:  0,
+			wantRating: "Butcher",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			missionMap := gridmap.NewEmptyMap[*core.Actor, *core.Item, Object](10, 10, 10)
+			for i := 0; i < test.witnesses; i++ {
+				witness := core.NewActor("Witness")
+				witness.IsEyeWitness = true
+				missionMap.AddActor(witness, geometry.Point{X: i, Y: 0})
+			}
+			score := CalculateScore(missionMap, test.stats)
+			if score.Total != test.wantTotal {
+				t.Errorf("total is %d, want %d (%v)", score.Total, test.wantTotal, score.Lines)
+			}
+			if score.Rating != test.wantRating {
+				t.Errorf("rating is %s, want %s", score.Rating, test.wantRating)
+			}
+		})
+	}
+}
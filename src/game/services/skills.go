@@ -0,0 +1,174 @@
+package services
+
+import (
+	"fmt"
+)
+
+// SkillDefinition is a node of the skill tree. A skill is bought with money or with skill points,
+// the player earns one skill point per level.
+type SkillDefinition struct {
+	// Key names the skill in profiles and replays.
+	Key         string
+	Name        string
+	Description string
+	// Requires is the key of the skill that has to be unlocked first, it is empty for the roots of the tree.
+	Requires    string
+	Price       uint64
+	SkillPoints uint64
+	unlocked    func(skills *PlayerSkills) *bool
+}
+
+// SkillTree lists all skills, every skill comes after the skill it requires.
+var SkillTree = []*SkillDefinition{
+	{
+		Key:         "double_assassination",
+		Name:        "Double Assassination",
+		Description: "Kill two adjacent targets at once when you carry two piercing weapons.",
+		Price:       2000,
+		SkillPoints: 1,
+		unlocked:    func(skills *PlayerSkills) *bool { return &skills.DoubleAssassination },
+	},
+	{
+		Key:         "quick_picks",
+		Name:        "Quick Picks",
+		Description: fmt.Sprintf("Picking and forcing locks takes %.0f%% of the time.", SkillQuickPicksFactor*100),
+		Price:       4000,
+		SkillPoints: 1,
+		unlocked:    func(skills *PlayerSkills) *bool { return &skills.QuickPicks },
+	},
+	{
+		Key:         "light_feet",
+		Name:        "Light Feet",
+		Description: "The noises you make moving about, like stepping on shards or slipping, only carry half as far.",
+		Price:       3000,
+		SkillPoints: 1,
+		unlocked:    func(skills *PlayerSkills) *bool { return &skills.LightFeet },
+	},
+	{
+		Key:         "strong_back",
+		Name:        "Strong Back",
+		Description: "Drag bodies while walking, not only while sneaking.",
+		Requires:    "light_feet",
+		Price:       5000,
+		SkillPoints: 2,
+		unlocked:    func(skills *PlayerSkills) *bool { return &skills.StrongBack },
+	},
+	{
+		Key:         "blend_in",
+		Name:        "Blend In",
+		Description: fmt.Sprintf("Trespassing is only noticed from %d tiles or closer.", SkillBlendInDistance),
+		Requires:    "light_feet",
+		Price:       6000,
+		SkillPoints: 2,
+		unlocked:    func(skills *PlayerSkills) *bool { return &skills.BlendIn },
+	},
+	{
+		Key:         "deep_pockets",
+		Name:        "Deep Pockets",
+		Description: "Take one more item into the mission.",
+		Price:       5000,
+		SkillPoints: 1,
+		unlocked:    func(skills *PlayerSkills) *bool { return &skills.DeepPockets },
+	},
+	{
+		Key:         "corner_peek",
+		Name:        "Corner Peek",
+		Description: "Peek two tiles around corners instead of one.",
+		Requires:    "quick_picks",
+		Price:       3000,
+		SkillPoints: 1,
+		unlocked:    func(skills *PlayerSkills) *bool { return &skills.CornerPeek },
+	},
+}
+
+const (
+	// SkillQuickPicksFactor scales the time needed to open a lock.
+	SkillQuickPicksFactor = 0.6
+	// SkillLightFeetFactor scales the noise radius of the player's movement.
+	SkillLightFeetFactor = 0.5
+	// SkillBlendInDistance is the distance up to which trespassing is noticed.
+	SkillBlendInDistance = 3
+	// SkillCornerPeekDistance is the peek distance in tiles.
+	SkillCornerPeekDistance = 2
+)
+
+// SkillByKey returns the skill or nil if there is no skill with the key.
+func SkillByKey(key string) *SkillDefinition {
+	for _, skill := range SkillTree {
+		if skill.Key == key {
+			return skill
+		}
+	}
+	return nil
+}
+
+func (d *SkillDefinition) IsUnlocked(skills PlayerSkills) bool {
+	return *d.unlocked(&skills)
+}
+
+func (d *SkillDefinition) unlock(skills *PlayerSkills) {
+	*d.unlocked(skills) = true
+}
+
+// Keys returns the keys of the unlocked skills in the order of the skill tree.
+func (s PlayerSkills) Keys() []string {
+	keys := make([]string, 0, len(SkillTree))
+	for _, skill := range SkillTree {
+		if skill.IsUnlocked(s) {
+			keys = append(keys, skill.Key)
+		}
+	}
+	return keys
+}
+
+// PlayerSkillsFromKeys unlocks the skills with the keys, unknown keys are ignored.
+func PlayerSkillsFromKeys(keys []string) PlayerSkills {
+	skills := PlayerSkills{}
+	for _, key := range keys {
+		if skill := SkillByKey(key); skill != nil {
+			skill.unlock(&skills)
+		}
+	}
+	return skills
+}
+
+// SkillPoints returns the skill points that were earned with levels and not spent yet.
+func (c *CareerData) SkillPoints() uint64 {
+	earned := c.Level() - 1
+	if c.SkillPointsSpent >= earned {
+		return 0
+	}
+	return earned - c.SkillPointsSpent
+}
+
+// MissingSkillRequirement returns a description of what is needed before the skill can be bought,
+// it is empty when the skill can be bought.
+func (c *CareerData) MissingSkillRequirement(skill *SkillDefinition) string {
+	if skill.IsUnlocked(c.UnlockedSkills) {
+		return "Already unlocked."
+	}
+	if required := SkillByKey(skill.Requires); required != nil && !required.IsUnlocked(c.UnlockedSkills) {
+		return "Requires " + required.Name + "."
+	}
+	return ""
+}
+
+// BuySkillWithMoney unlocks the skill if its requirement is met and the player can afford it.
+func (c *CareerData) BuySkillWithMoney(skill *SkillDefinition) bool {
+	if c.MissingSkillRequirement(skill) != "" || c.Money < skill.Price {
+		return false
+	}
+	c.Money -= skill.Price
+	skill.unlock(&c.UnlockedSkills)
+	return true
+}
+
+// BuySkillWithSkillPoints unlocks the skill if its requirement is met and the player has enough skill points.
+func (c *CareerData) BuySkillWithSkillPoints(skill *SkillDefinition) bool {
+	if c.MissingSkillRequirement(skill) != "" || c.SkillPoints() < skill.SkillPoints {
+		return false
+	}
+	c.SkillPointsSpent += skill.SkillPoints
+	skill.unlock(&c.UnlockedSkills)
+	return true
+}
@@ -0,0 +1,120 @@
+package services
+
+import (
+	"slices"
+	"testing"
+)
+
+// experience points for the first levels, see CareerData.Level
+const (
+	levelOneExperience   = 0
+	levelTwoExperience   = 10000
+	levelThreeExperience = 30000
+)
+
+func TestSkillPoints(t *testing.T) {
+	tests := []struct {
+		name       string
+		experience uint64
+		spent      uint64
+		want       uint64
+	}{
+		{name: "none at level one", experience: levelOneExperience, want: 0},
+		{name: "one per level", experience: levelTwoExperience, want: 1},
+		{name: "two at level three", experience: levelThreeExperience, want: 2},
+		{name: "spent points are gone", experience: levelThreeExperience, spent: 1, want: 1},
+		{name: "never negative", experience: levelTwoExperience, spent: 3, want: 0},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			career := &CareerData{ExperiencePoints: test.experience, SkillPointsSpent: test.spent}
+			if got := career.SkillPoints(); got != test.want {
+				t.Errorf("SkillPoints() = %d, want %d", got, test.want)
+			}
+		})
+	}
+}
+
+func TestBuySkillWithMoney(t *testing.T) {
+	tests := []struct {
+		name      string
+		skill     string
+		money     uint64
+		unlocked  []string
+		wantBuy   bool
+		wantMoney uint64
+	}{
+		{name: "affordable", skill: "light_feet", money: 3500, wantBuy: true, wantMoney: 500},
+		{name: "too expensive", skill: "light_feet", money: 2999, wantMoney: 2999},
+		{name: "requirement missing", skill: "strong_back", money: 10000, wantMoney: 10000},
+		{name: "requirement met", skill: "strong_back", money: 10000, unlocked: []string{"light_feet"}, wantBuy: true, wantMoney: 5000},
+		{name: "already unlocked", skill: "quick_picks", money: 10000, unlocked: []string{"quick_picks"}, wantMoney: 10000},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			career := NewCareer("test")
+			career.Money = test.money
+			career.UnlockedSkills = PlayerSkillsFromKeys(test.unlocked)
+			skill := SkillByKey(test.skill)
+			if got := career.BuySkillWithMoney(skill); got != test.wantBuy {
+				t.Errorf("BuySkillWithMoney() = %v, want %v", got, test.wantBuy)
+			}
+			if career.Money != test.wantMoney {
+				t.Errorf("money is %d, want %d", career.Money, test.wantMoney)
+			}
+			if wantUnlocked := test.wantBuy || slices.Contains(test.unlocked, test.skill); skill.IsUnlocked(career.UnlockedSkills) != wantUnlocked {
+				t.Errorf("skill unlocked is %v, want %v", !wantUnlocked, wantUnlocked)
+			}
+		})
+	}
+}
+
+func TestBuySkillWithSkillPoints(t *testing.T) {
+	tests := []struct {
+		name       string
+		skill      string
+		experience uint64
+		spent      uint64
+		unlocked   []string
+		wantBuy    bool
+		wantSpent  uint64
+	}{
+		{name: "enough points", skill: "quick_picks", experience: levelTwoExperience, wantBuy: true, wantSpent: 1},
+		{name: "no points", skill: "quick_picks", experience: levelOneExperience},
+		{name: "points already spent", skill: "quick_picks", experience: levelTwoExperience, spent: 1, wantSpent: 1},
+		{name: "too few points", skill: "strong_back", experience: levelTwoExperience, unlocked: []string{"light_feet"}},
+		{name: "requirement missing", skill: "corner_peek", experience: levelThreeExperience},
+		{name: "requirement met", skill: "strong_back", experience: levelThreeExperience, unlocked: []string{"light_feet"}, wantBuy: true, wantSpent: 2},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			career := NewCareer("test")
+			career.ExperiencePoints = test.experience
+			career.SkillPointsSpent = test.spent
+			career.UnlockedSkills = PlayerSkillsFromKeys(test.unlocked)
+			skill := SkillByKey(test.skill)
+			if got := career.BuySkillWithSkillPoints(skill); got != test.wantBuy {
+				t.Errorf("BuySkillWithSkillPoints() = %v, want %v", got, test.wantBuy)
+			}
+			if career.SkillPointsSpent != test.wantSpent {
+				t.Errorf("spent %d skill points, want %d", career.SkillPointsSpent, test.wantSpent)
+			}
+			if skill.IsUnlocked(career.UnlockedSkills) != test.wantBuy {
+				t.Errorf("skill unlocked is %v, want %v", !test.wantBuy, test.wantBuy)
+			}
+		})
+	}
+}
+
+func TestNewCareerHasNoSkills(t *testing.T) {
+	if keys := NewCareer("test").UnlockedSkills.Keys(); len(keys) > 0 {
+		t.Errorf("a new career has the skills %v", keys)
+	}
+}
+
+func TestPlayerSkillKeys(t *testing.T) {
+	keys := []string{"double_assassination", "strong_back", "corner_peek"}
+	if got := PlayerSkillsFromKeys(append(keys, "unknown")).Keys(); !slices.Equal(got, keys) {
+		t.Errorf("Keys() = %v, want %v", got, keys)
+	}
+}
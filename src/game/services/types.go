@@ -73,6 +73,41 @@ type AlarmTriggeredEvent struct {
 	SightingLocation geometry.Point
 }
 
+// ActorKnockedOutEvent is published when an NPC is put to sleep, eg. by a blow or sleeping poison.
+type ActorKnockedOutEvent struct {
+	Victim *core.Actor
+}
+
+// BodyHiddenEvent is published when a downed actor is put into a container.
+type BodyHiddenEvent struct {
+	Body      *core.Actor
+	Container geometry.Point
+}
+
+// ItemThrownEvent is published when an actor throws an item.
+type ItemThrownEvent struct {
+	Thrower *core.Actor
+	Item    *core.Item
+}
+
+// DoorLockpickedEvent is published when a locked door was opened with lockpicks.
+type DoorLockpickedEvent struct {
+	Picker  *core.Actor
+	DoorPos geometry.Point
+}
+
+// ActorAlertedEvent is published when an NPC sees something dangerous for the first time and becomes an eye witness.
+type ActorAlertedEvent struct {
+	Actor *core.Actor
+}
+
+// PlayerMovedEvent is published when the player has moved to another cell,
+// usually an adjacent one. Changing floors moves the player further.
+type PlayerMovedEvent struct {
+	OldPosition geometry.Point
+	NewPosition geometry.Point
+}
+
 func (f FixedChallenge) WithTime(completionTime time.Duration) Challenge {
 	f.timeNeeded = completionTime
 	return f
@@ -121,6 +156,13 @@ type MapStatistics struct {
 	TotalDuration       time.Duration
 	FastestDuration     time.Duration
 	UnlockedLocations   mapset.Set[string]
+	UnlockedItems       mapset.Set[string]
+	Loadout             Loadout
+	// EscalationLevel is the highest level of the map's escalation contract that was completed.
+	EscalationLevel int
+	// BestScore and BestRating are the result of the highest scoring run, the rating is empty before the first one.
+	BestScore  int
+	BestRating string
 }
 
 func NewMapStats(mission *gridmap.GridMap[*core.Actor, *core.Item, Object]) *MapStatistics {
@@ -132,5 +174,6 @@ func NewMapStats(mission *gridmap.GridMap[*core.Actor, *core.Item, Object]) *Map
 		TotalBodyCount:      0,
 		CompletedChallenges: make(map[int]DiskChallenge, 0),
 		UnlockedLocations:   mapset.NewSet[string](),
+		UnlockedItems:       mapset.NewSet[string](),
 	}
 }
@@ -0,0 +1,38 @@
+package game
+
+import (
+	"github.com/memmaker/terminal-assassin/game/core"
+	"github.com/memmaker/terminal-assassin/game/services"
+	"github.com/memmaker/terminal-assassin/geometry"
+)
+
+// SetPlayerSkills sets the skills the player has in the current mission.
+func (m *Model) SetPlayerSkills(skills services.PlayerSkills) {
+	m.skills = skills
+}
+
+// GetPlayerSkills returns the skills of the player in the current mission. Replays use the skills
+// they were recorded with, so gameplay code must ask the model instead of the career.
+func (m *Model) GetPlayerSkills() services.PlayerSkills {
+	return m.skills
+}
+
+// movementNoiseRadius is the radius of a noise the person makes by moving about,
+// the Light Feet skill makes the noises of the player carry less far.
+func (m *Model) movementNoiseRadius(person *core.Actor, radius int) int {
+	if person.IsPlayer() && m.skills.LightFeet {
+		return int(float64(radius) * services.SkillLightFeetFactor)
+	}
+	return radius
+}
+
+// canDragWhileWalking is true when the Strong Back skill lets the person drag a body without sneaking.
+func (m *Model) canDragWhileWalking(person *core.Actor) bool {
+	return person.IsPlayer() && m.skills.StrongBack
+}
+
+// blendsIn is true when the Blend In skill keeps the observer from noticing that the player is trespassing.
+func (m *Model) blendsIn(observer *core.Actor, susActor *core.Actor) bool {
+	return susActor.IsPlayer() && m.skills.BlendIn &&
+		geometry.DistanceChebyshev(observer.Pos(), susActor.Pos()) > services.SkillBlendInDistance
+}
@@ -29,16 +29,27 @@ func (g *GameStateMainMenu) openBriefingMenu() {
     //recorder := g.engine.GetRecorder()
     title := "Mission" // + currentMap.MapFileName()
     recorder := g.engine.GetRecorder()
+    variant := &missionVariant{}
     menuItems := []services.MenuItem{
         {
             Label: "Start mission",
             Handler: func() {
                 audioPlayer.StopAll()
                 userInterface.PopAll()
+                g.prepareMission(variant)
                 game.PopState()
                 game.PushState(&GameStateGameplay{})
             },
         },
+        {
+            Label:     "Plan loadout",
+            Handler:   g.openLoadoutMenu,
+            Condition: g.hasLoadoutChoices,
+        },
+    }
+    menuItems = append(menuItems, g.escalationMenuItems(variant)...)
+    menuItems = append(menuItems, g.contractMenuItems(variant)...)
+    menuItems = append(menuItems, []services.MenuItem{
         {
             DynamicLabel: func() string {
                 if recorder.ShouldRecord {
@@ -64,7 +75,7 @@ func (g *GameStateMainMenu) openBriefingMenu() {
                 userInterface.PopModal()
             },
         },
-    }
+    }...)
 
     userInterface.OpenFixedWidthStackedMenu(title, menuItems)
 }
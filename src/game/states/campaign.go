@@ -0,0 +1,143 @@
+package states
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/memmaker/terminal-assassin/game/core"
+	"github.com/memmaker/terminal-assassin/game/services"
+	"github.com/memmaker/terminal-assassin/gridmap"
+)
+
+// openNewMission lets the player choose the next mission. Campaigns with a campaign.txt
+// show the next interlude first and list their missions in campaign order.
+func (g *GameStateMainMenu) openNewMission() {
+	userInterface := g.engine.GetUI()
+	campaign := services.CurrentCampaign(g.engine)
+	if campaign == nil {
+		userInterface.OpenMapsMenu(func(*gridmap.GridMap[*core.Actor, *core.Item, services.Object]) {
+			g.engine.GetAudio().StopAll()
+			g.openBriefingMenu()
+		})
+		return
+	}
+	if interlude := campaign.NextInterlude(g.engine.GetCareer()); interlude != nil {
+		g.showInterlude(campaign, interlude, func() {
+			g.openCampaignMissionsMenu(campaign)
+		})
+		return
+	}
+	g.openCampaignMissionsMenu(campaign)
+}
+
+// showInterlude plays the slides of the interlude and remembers that the player has seen it.
+func (g *GameStateMainMenu) showInterlude(campaign *services.Campaign, interlude *services.CampaignInterlude, onFinish func()) {
+	career := g.engine.GetCareer()
+	userInterface := g.engine.GetUI()
+	career.MarkInterludeSeen(campaign.Folder, interlude.Name)
+	career.SaveToFile()
+
+	interludePath := campaign.InterludePath(interlude)
+	file, err := g.engine.GetFiles().Open(path.Join(interludePath, "slides.txt"))
+	if err != nil {
+		println("Error opening interlude file: " + err.Error())
+		onFinish()
+		return
+	}
+	script := NewBriefingFromFile(file)
+	file.Close()
+	script.AssetPath = interludePath
+	userInterface.HideModal()
+	g.engine.GetAnimator().BriefingAnimation(script, func() {
+		g.isDirty = true
+		userInterface.ShowModal()
+		onFinish()
+	})
+}
+
+// openCampaignMissionsMenu lists the missions in campaign order, locked ones tell the player what is missing.
+func (g *GameStateMainMenu) openCampaignMissionsMenu(campaign *services.Campaign) {
+	career := g.engine.GetCareer()
+	userInterface := g.engine.GetUI()
+	menuItems := make([]services.MenuItem, 0, len(campaign.Missions))
+	for _, campaignMission := range campaign.Missions {
+		mission := campaignMission
+		missing := campaign.MissingRequirements(mission, career)
+		if len(missing) > 0 {
+			menuItems = append(menuItems, services.MenuItem{
+				Label: mission.Title + " (locked)",
+				Handler: func() {
+					userInterface.ShowAlert(append([]string{"To play " + mission.Title + ":"}, missing...))
+				},
+			})
+			continue
+		}
+		label := mission.Title
+		if career.HasCompletedMap(campaign.MapPath(mission)) {
+			label += " (completed)"
+		}
+		menuItems = append(menuItems, services.MenuItem{
+			Label: label,
+			Handler: func() {
+				userInterface.PopModal()
+				g.loadCampaignMission(campaign, mission)
+			},
+		})
+	}
+	userInterface.OpenFixedWidthStackedMenu(campaign.Title, menuItems)
+}
+
+func (g *GameStateMainMenu) loadCampaignMission(campaign *services.Campaign, mission *services.CampaignMission) {
+	game := g.engine.GetGame()
+	game.ResetModel()
+	loadedMap, err := g.engine.LoadMap(campaign.MapPath(mission))
+	if err != nil {
+		println("Error loading map: " + err.Error())
+		return
+	}
+	game.InitLoadedMap(loadedMap)
+	g.engine.GetAudio().StopAll()
+	g.openBriefingMenu()
+}
+
+// campaignFlagsAtStart returns the flags of the current campaign a live mission starts with.
+func campaignFlagsAtStart(engine services.Engine) []string {
+	if campaign := services.CurrentCampaign(engine); campaign != nil {
+		return campaign.FlagsSetIn(engine.GetCareer())
+	}
+	return []string{}
+}
+
+// completeCampaignMission sets the campaign flags of a successfully finished mission,
+// together with the flags the scripts of a live mission queued.
+func completeCampaignMission(engine services.Engine, mapPath string) {
+	campaign := services.CurrentCampaign(engine)
+	if campaign == nil {
+		return
+	}
+	career := engine.GetCareer()
+	campaign.CompleteMission(career, mapPath)
+	for _, flag := range engine.GetGame().QueuedCampaignFlags() {
+		campaign.SetFlag(career, flag)
+	}
+}
+
+// setCampaignFlag is used by the map scripts, the flag is only saved with the career if the mission succeeds.
+func setCampaignFlag(engine services.Engine, campaign *services.Campaign, flag string) {
+	flag = strings.TrimSpace(flag)
+	if campaign == nil {
+		println("(WARNING) SetCampaignFlag: The current campaign has no campaign.txt")
+		return
+	}
+	if !campaign.HasFlag(flag) {
+		println(fmt.Sprintf("(WARNING) SetCampaignFlag: The campaign '%s' has no flag named '%s'", campaign.Folder, flag))
+		return
+	}
+	engine.GetGame().SetCampaignFlag(flag)
+}
+
+// isCampaignFlagSet is used by the map scripts, unknown flags are never set.
+func isCampaignFlagSet(engine services.Engine, flag string) bool {
+	return engine.GetGame().IsCampaignFlagSet(strings.TrimSpace(flag))
+}
@@ -0,0 +1,70 @@
+package states
+
+import (
+	"time"
+
+	"github.com/memmaker/terminal-assassin/game/core"
+	"github.com/memmaker/terminal-assassin/game/services"
+)
+
+// contractMenuItems returns the briefing entries to generate a random contract or to enter
+// the code of a contract a friend shared. Choosing a contract drops the escalation level.
+func (g *GameStateMainMenu) contractMenuItems(variant *missionVariant) []services.MenuItem {
+	userInterface := g.engine.GetUI()
+	chooseContract := func(seed int64) {
+		variant.contract = services.GenerateContract(g.engine.GetGame().GetMap(), seed)
+		variant.escalation = nil
+		userInterface.ShowAlert(variant.contract.Briefing)
+	}
+	return []services.MenuItem{
+		{
+			Label: "New random contract",
+			Handler: func() {
+				chooseContract(services.NewContractSeed(time.Now().UnixNano()))
+			},
+		},
+		{
+			Label: "Enter contract code",
+			Handler: func() {
+				userInterface.ShowTextInput("Contract code: ", "", func(code string) {
+					seed, err := services.ParseContractCode(code)
+					if err != nil {
+						userInterface.ShowAlert([]string{err.Error()})
+						return
+					}
+					chooseContract(seed)
+				}, func() {})
+			},
+		},
+		{
+			DynamicLabel: func() string {
+				return "Contract  : " + variant.contract.Code
+			},
+			Handler: func() {
+				userInterface.ShowAlert(variant.contract.Briefing)
+			},
+			Condition: func() bool {
+				return variant.contract != nil
+			},
+		},
+		{
+			Label: "Cancel contract",
+			Handler: func() {
+				variant.contract = nil
+			},
+			Condition: func() bool {
+				return variant.contract != nil
+			},
+		},
+	}
+}
+
+// enforceContract ends the mission as soon as the player breaks a term of the contract.
+func (g *GameStateGameplay) enforceContract(contract *services.Contract) {
+	g.engine.SubscribeToEvents(services.NewFilter(func(e services.ActorKilledEvent) bool {
+		if e.Victim.IsTarget && !contract.IsKillAllowed(e.CauseOfDeath) {
+			g.breakContract(core.CoDContractMethod)
+		}
+		return true
+	}))
+}
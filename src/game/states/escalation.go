@@ -0,0 +1,111 @@
+package states
+
+import (
+	"fmt"
+
+	"github.com/memmaker/terminal-assassin/game/core"
+	"github.com/memmaker/terminal-assassin/game/services"
+	"github.com/memmaker/terminal-assassin/utils"
+)
+
+// availableEscalationLevels returns the levels of the current map that the player may start,
+// which are all completed levels and the one after the highest completed.
+func (g *GameStateMainMenu) availableEscalationLevels() []*services.EscalationLevel {
+	currentMap := g.engine.GetGame().GetMap()
+	levels := services.LoadEscalation(g.engine.GetFiles(), currentMap.MapFileName())
+	reachable := min(g.engine.GetCareer().EscalationLevelFor(currentMap)+1, len(levels))
+	return levels[:reachable]
+}
+
+// escalationMenuItems returns the briefing entries to choose the escalation level and to
+// show its complications. Choosing a level drops a generated contract.
+func (g *GameStateMainMenu) escalationMenuItems(variant *missionVariant) []services.MenuItem {
+	userInterface := g.engine.GetUI()
+	levels := g.availableEscalationLevels()
+	changeLevel := func(step int) func() {
+		return func() {
+			options := append([]*services.EscalationLevel{nil}, levels...)
+			index := 0
+			for i, level := range options {
+				if level == variant.escalation {
+					index = i
+				}
+			}
+			variant.escalation = options[(index+step+len(options))%len(options)]
+			variant.contract = nil
+		}
+	}
+	return []services.MenuItem{
+		{
+			DynamicLabel: func() string {
+				if variant.escalation == nil {
+					return "Escalation: Standard"
+				}
+				return fmt.Sprintf("Escalation: %d - %s", variant.escalation.Level, variant.escalation.Title)
+			},
+			Handler:      changeLevel(1),
+			LeftHandler:  changeLevel(-1),
+			RightHandler: changeLevel(1),
+			Condition: func() bool {
+				return len(levels) > 0
+			},
+		},
+		{
+			Label: "Show complications",
+			Handler: func() {
+				userInterface.ShowAlert(variant.escalation.Complications())
+			},
+			Condition: func() bool {
+				return variant.escalation != nil
+			},
+		},
+	}
+}
+
+// enforceEscalation ends the mission as soon as the player breaks a rule of the escalation level.
+func (g *GameStateGameplay) enforceEscalation(level *services.EscalationLevel) {
+	if level.SpottingForbidden {
+		g.engine.SubscribeToEvents(services.NewFilter(func(_ services.PlayerSpottedEvent) bool {
+			g.breakContract(core.CoDContractSpotted)
+			return true
+		}))
+	}
+	if level.BodiesFoundForbidden {
+		g.engine.SubscribeToEvents(services.NewFilter(func(_ services.BodyDiscoveredEvent) bool {
+			g.breakContract(core.CoDContractBodyFound)
+			return true
+		}))
+	}
+}
+
+// checkEscalationTimeLimit fails the mission once the time limit of the escalation level is over.
+func (g *GameStateGameplay) checkEscalationTimeLimit() {
+	level := g.engine.GetGame().GetEscalation()
+	if level == nil || level.TimeLimit <= 0 {
+		return
+	}
+	if utils.UTicksToSeconds(g.engine.CurrentInGameTick()) > level.TimeLimit {
+		g.breakContract(core.CoDContractTimeout)
+	}
+}
+
+func (g *GameStateGameplay) breakContract(description core.CoDDescription) {
+	if g.contractBroken {
+		return
+	}
+	g.contractBroken = true
+	g.engine.GetGame().EndMissionWithFailure(core.NewCauseOfDeath(description, nil))
+}
+
+// escalationInformation is the HUD part showing the remaining time of the escalation level.
+func (g *GameStateGameplay) escalationInformation() string {
+	level := g.engine.GetGame().GetEscalation()
+	if level == nil || level.TimeLimit <= 0 {
+		return ""
+	}
+	remaining := max(0, int(level.TimeLimit-utils.UTicksToSeconds(g.engine.CurrentInGameTick())))
+	if remaining < 60 {
+		return fmt.Sprintf(" | @r%d:%02d@N", remaining/60, remaining%60)
+	}
+	return fmt.Sprintf(" | %d:%02d", remaining/60, remaining%60)
+}
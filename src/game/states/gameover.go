@@ -3,6 +3,7 @@ package states
 import (
 	"fmt"
 	"path"
+	"sort"
 	"strconv"
 	"time"
 
@@ -47,10 +48,21 @@ func (g *GameStateGameOver) Init(engine services.Engine) {
 	// Clear any replay input override so the debriefing pager gets real input.
 	engine.SetInputOverride(nil)
 
+	recorder := engine.GetRecorder()
+	outcome := g.missionOutcome()
+	// A verification run only reports the outcome, the career stays untouched.
+	if recorder != nil && recorder.IsVerifying() {
+		recorder.FinishVerification(outcome)
+		return
+	}
+
 	// Stop any active recording.
-	if recorder := engine.GetRecorder(); recorder != nil && recorder.IsRecording() {
+	replayPath := ""
+	if recorder != nil && recorder.IsRecording() {
+		recorder.SetOutcome(outcome)
 		if path, err := recorder.StopAndSave(); err == nil {
 			println("Replay saved:", path)
+			replayPath = path
 		}
 	}
 
@@ -58,11 +70,36 @@ func (g *GameStateGameOver) Init(engine services.Engine) {
 
 	userInterface := g.engine.GetUI()
 	onQuit := func() {
+		if g.MissionExitedWithGoalCompletion && replayPath != "" {
+			submitToLeaderboard(g.engine, replayPath)
+			return
+		}
 		g.engine.Reset()
 	}
 	userInterface.ShowPager("Mission Debriefing", g.debriefingMessage, onQuit)
 }
 
+// missionOutcome is the result of the mission that is stored with its replay and ranked on the leaderboards.
+func (g *GameStateGameOver) missionOutcome() services.ReplayOutcome {
+	game := g.engine.GetGame()
+	stats := *game.GetStats()
+	outcome := services.ReplayOutcome{
+		Success:     g.MissionExitedWithGoalCompletion,
+		Seconds:     stats.SecondsNeeded,
+		NPCsAlerted: stats.NPCsAlerted,
+	}
+	if !outcome.Success {
+		return outcome
+	}
+	outcome.Score = services.CalculateScore(game.GetMap(), stats).Total
+	completedChallenges, _ := g.engine.GetCareer().CompletedChallenges(g.engine, stats)
+	for _, challenge := range completedChallenges {
+		outcome.Challenges = append(outcome.Challenges, challenge.ID())
+	}
+	sort.Ints(outcome.Challenges)
+	return outcome
+}
+
 func (g *GameStateGameOver) createDebriefingMessage(success bool) []core.StyledText {
 	career := g.engine.GetCareer()
 	game := g.engine.GetGame()
@@ -72,11 +109,19 @@ func (g *GameStateGameOver) createDebriefingMessage(success bool) []core.StyledT
 	lootItems := g.getLootFromInventory(player.Inventory)
 	var challengeResults services.ChallengeResults
 	var newUnlocks []Unlockable
+	var score services.MissionScore
+	var isBestScore bool
 	if success {
+		score = services.CalculateScore(currentMap, stats)
 		career.AddMissionStats(currentMap, stats)
+		isBestScore = career.RecordScore(currentMap, score)
 		challengeResults = career.CheckForChallengeCompletion(g.engine, stats)
 		newUnlocks = g.checkForUnlocks(challengeResults.OldCompletionPercentage, challengeResults.NewCompletionPercentage)
 		g.ApplyUnlocks(newUnlocks)
+		if escalation := game.GetEscalation(); escalation != nil {
+			career.CompleteEscalationLevel(currentMap, escalation.Level)
+		}
+		completeCampaignMission(g.engine, currentMap.MapFileName())
 	}
 
 	message := make([]core.StyledText, 0)
@@ -84,6 +129,26 @@ func (g *GameStateGameOver) createDebriefingMessage(success bool) []core.StyledT
 	if success {
 		message = append(message, core.Text("Your career has been updated.").WithStyle(common.DefaultStyle.WithFg(core.CurrentTheme.SuccessForeground)))
 		message = append(message, core.Text(fmt.Sprintf("You completed the mission in %s", stats.MissionDuration().Round(time.Millisecond))))
+		if escalation := game.GetEscalation(); escalation != nil {
+			message = append(message, core.Text(fmt.Sprintf("Escalation level %d completed: %s", escalation.Level, escalation.Title)).WithStyle(common.DefaultStyle.WithFg(core.CurrentTheme.SuccessForeground)))
+		}
+		if contract := game.GetContract(); contract != nil {
+			message = append(message, core.Text(fmt.Sprintf("Contract %s fulfilled.", contract.Code)).WithStyle(common.DefaultStyle.WithFg(core.CurrentTheme.SuccessForeground)))
+		}
+		message = append(message, core.Text(""))
+		message = append(message, core.Text("Score:"))
+		for _, line := range score.Lines {
+			lineStyle := common.DefaultStyle.WithFg(core.CurrentTheme.SuccessForeground)
+			if line.Points < 0 {
+				lineStyle = common.DefaultStyle.WithFg(core.CurrentTheme.FailureForeground)
+			}
+			message = append(message, core.Text(line.String()).WithStyle(lineStyle))
+		}
+		message = append(message, core.Text(services.ScoreLine{Label: "Total", Points: score.Total}.String()))
+		message = append(message, core.Text("Rating: "+score.Rating))
+		if isBestScore {
+			message = append(message, core.Text("New best score for this mission!").WithStyle(common.DefaultStyle.WithFg(core.CurrentTheme.SuccessForeground)))
+		}
 		if len(challengeResults.NewlyCompleted) > 0 {
 			message = append(message, core.Text(""))
 			message = append(message, core.Text("New challenges:"))
@@ -107,6 +172,23 @@ func (g *GameStateGameOver) createDebriefingMessage(success bool) []core.StyledT
 		message = append(message, core.Text(fmt.Sprintf("Cause of death: %s", g.CauseOfPlayerDeath.WithKiller())))
 	}
 
+	if objectives := game.GetObjectives(); objectives != nil && len(objectives.Objectives) > 0 {
+		objectives.Update()
+		message = append(message, core.Text(""))
+		message = append(message, core.Text("Objectives:"))
+		for _, objective := range objectives.Objectives {
+			objectiveMessage := objective.Title
+			if objective.IsOptional() {
+				objectiveMessage += " (optional)"
+			}
+			if objective.IsCompleted() {
+				message = append(message, core.Text("[x] "+objectiveMessage).WithStyle(common.DefaultStyle.WithFg(core.CurrentTheme.SuccessForeground)))
+			} else {
+				message = append(message, core.Text("[ ] "+objectiveMessage))
+			}
+		}
+	}
+
 	if len(newUnlocks) > 0 {
 		message = append(message, core.Text(""))
 		message = append(message, core.Text("New unlocks:"))
@@ -234,6 +316,8 @@ func (g *GameStateGameOver) ApplyUnlocks(unlocks []Unlockable) {
 			currentMap := g.engine.GetGame().GetMap()
 			mapHash := currentMap.MapHash()
 			career.AddUnlockedLocation(mapHash, unlock.Unlockable)
+		case "Item":
+			career.AddUnlockedItem(g.engine.GetGame().GetMap(), unlock.Unlockable)
 		}
 	}
 }
@@ -25,6 +25,15 @@ import (
 )
 
 type GameStateGameplay struct {
+	// Loadout is the plan of the player for this mission.
+	// When it is nil, the loadout saved in the career for the map is used.
+	Loadout *services.Loadout
+	// Skills are the skills of the player for this mission, the skills of the career if nil.
+	Skills *services.PlayerSkills
+	// CampaignFlags are the campaign flags the mission starts with, those of the career if nil.
+	CampaignFlags *[]string
+	// Seed is the RNG seed of a replayed mission. Live missions leave it at 0 and pick a new one.
+	Seed                  int64
 	engine                services.Engine
 	Ui                    GameplayUIState
 	MouseDown             bool
@@ -72,6 +81,16 @@ type GameStateGameplay struct {
 	// advanced.  At 60 TPS one real second equals one in-game minute, so a full
 	// day/night cycle takes 24 real minutes.
 	timeAccumulator int
+	// fireAccumulator counts Update ticks since the last fire simulation step.
+	fireAccumulator int
+	// liquidAccumulator counts Update ticks since the last liquid simulation step.
+	liquidAccumulator int
+	// gasAccumulator counts Update ticks since the last gas simulation step.
+	gasAccumulator int
+	// objectivesAccumulator counts Update ticks since the objectives were last evaluated.
+	objectivesAccumulator int
+	// contractBroken is set once a rule of the escalation level or contract was broken, so the mission fails only once.
+	contractBroken bool
 }
 
 func (g *GameStateGameplay) Print(text string) {
@@ -155,7 +174,22 @@ func (g *GameStateGameplay) Init(engine services.Engine) {
 		Color:        common.RGBAColor{R: 1.5, G: 1.5, B: 1.2, A: 1.0},
 		MaxIntensity: 15,
 	}
+	if g.Loadout == nil {
+		loadout := g.engine.GetCareer().LoadoutFor(currentMap)
+		g.Loadout = &loadout
+	}
+	if g.Skills == nil {
+		skills := g.engine.GetCareer().UnlockedSkills
+		g.Skills = &skills
+	}
+	game.SetPlayerSkills(*g.Skills)
+	if g.CampaignFlags == nil {
+		flags := campaignFlagsAtStart(g.engine)
+		g.CampaignFlags = &flags
+	}
+	game.SetCampaignFlags(*g.CampaignFlags, g.Seed == 0)
 	g.SpawnPlayer()
+	g.equipLoadout()
 	g.initCamera()
 
 	currentMap.UpdateBakedLights()
@@ -188,14 +222,24 @@ func (g *GameStateGameplay) Init(engine services.Engine) {
 
 	stats := engine.GetGame().GetStats()
 	stats.StartMission()
+	engine.GetGame().LoadObjectives()
+	if escalation := engine.GetGame().GetEscalation(); escalation != nil {
+		g.enforceEscalation(escalation)
+	}
+	if contract := engine.GetGame().GetContract(); contract != nil {
+		g.enforceContract(contract)
+	}
 
 	// Seed RNG for this mission. Replay uses the stored seed; live play picks one now.
 	recorder := engine.GetRecorder()
-	if recorder == nil || !recorder.IsRecording() {
+	if g.Seed != 0 {
+		rng.Seed(g.Seed)
+	} else if recorder == nil || !recorder.IsRecording() {
 		seed := time.Now().UnixNano()
 		rng.Seed(seed)
 		if recorder != nil && recorder.ShouldRecord {
-			recorder.StartRecording(currentMap.MapFileName(), currentMap.MapHash(), seed)
+			escalationLevel, contractCode := missionVariantOf(engine.GetGame())
+			recorder.StartRecording(currentMap.MapFileName(), currentMap.MapHash(), seed, *g.Loadout, *g.Skills, *g.CampaignFlags, escalationLevel, contractCode)
 		}
 	}
 
@@ -259,6 +303,37 @@ func (g *GameStateGameplay) Init(engine services.Engine) {
 		stats.AddKill(e.Victim, e.CauseOfDeath, e.Position, utils.UTicksToSeconds(g.engine.CurrentInGameTick()))
 		return true
 	}))
+	g.engine.SubscribeToEvents(services.NewFilter(func(_ services.ActorKnockedOutEvent) bool {
+		stats.Knockouts++
+		return true
+	}))
+	g.engine.SubscribeToEvents(services.NewFilter(func(_ services.BodyHiddenEvent) bool {
+		stats.BodiesHidden++
+		return true
+	}))
+	g.engine.SubscribeToEvents(services.NewFilter(func(e services.ItemThrownEvent) bool {
+		if e.Thrower == g.engine.GetGame().GetMap().Player {
+			stats.ItemsThrown++
+		}
+		return true
+	}))
+	g.engine.SubscribeToEvents(services.NewFilter(func(e services.DoorLockpickedEvent) bool {
+		if e.Picker == g.engine.GetGame().GetMap().Player {
+			stats.DoorsLockpicked++
+		}
+		return true
+	}))
+	g.engine.SubscribeToEvents(services.NewFilter(func(_ services.ActorAlertedEvent) bool {
+		stats.NPCsAlerted++
+		return true
+	}))
+	g.engine.SubscribeToEvents(services.NewFilter(func(e services.PlayerMovedEvent) bool {
+		// only steps count, changing floors moves the player across the map
+		if geometry.DistanceChebyshev(e.OldPosition, e.NewPosition) == 1 {
+			stats.DistanceWalked += geometry.Distance(e.OldPosition, e.NewPosition)
+		}
+		return true
+	}))
 	// Ambience sound — reacts to zone changes instead of running in playerEnteredCell.
 	g.engine.SubscribeToEvents(services.NewFilter(func(e services.ActorEnteredZoneEvent) bool {
 		if e.Actor != g.engine.GetGame().GetMap().Player {
@@ -297,7 +372,7 @@ func (g *GameStateGameplay) parseMapDialogues(currentMap *gridmap.GridMap[*core.
 		return
 	}
 	parser := director.NewDialogueParser(currentMap.Player)
-	g.registerPredicateAndAssignmentFunctions(parser)
+	g.registerPredicateAndAssignmentFunctions(scriptFunctionTable{parser: parser})
 	for _, dialogueFilename := range dialogueFiles {
 		dialogueFile, err := files.Open(dialogueFilename)
 		if err != nil {
@@ -324,8 +399,9 @@ func (g *GameStateGameplay) parseMapScripts(currentMap *gridmap.GridMap[*core.Ac
 		return
 	}
 	parser := director.NewScriptParser(currentMap.Player)
-	g.registerPredicateAndAssignmentFunctions(parser)
-	g.registerActionFunctions(parser)
+	functions := scriptFunctionTable{parser: parser}
+	g.registerPredicateAndAssignmentFunctions(functions)
+	g.registerActionFunctions(functions)
 	config := g.engine.GetGame().GetConfig()
 	for _, scriptFilename := range scriptFiles {
 		baseFilename := path.Base(scriptFilename)
@@ -352,14 +428,33 @@ func (g *GameStateGameplay) initCamera() {
 	game := g.engine.GetGame()
 	currentMap := game.GetMap()
 	playerPos := currentMap.Player.Pos()
-	mapWidth, mapHeight := game.GetMap().MapWidth, game.GetMap().MapHeight
-	game.GetCamera().CenterOn(playerPos, mapWidth, mapHeight)
+	game.GetCamera().CenterWithin(playerPos, currentMap.CameraBoundsAt(playerPos))
+}
+
+// followPlayerToOtherFloor moves the camera to the floor the player has just
+// reached by stairs or elevator.
+func (g *GameStateGameplay) followPlayerToOtherFloor() {
+	game := g.engine.GetGame()
+	currentMap := game.GetMap()
+	player := currentMap.Player
+	if player == nil || !currentMap.HasFloors() {
+		return
+	}
+	bounds := currentMap.CameraBoundsAt(player.Pos())
+	if bounds.Contains(game.GetCamera().ViewPort.Mid()) {
+		return
+	}
+	game.GetCamera().CenterWithin(player.Pos(), bounds)
+	g.isDirty = true
 }
 
 func (g *GameStateGameplay) SpawnPlayer() {
 	career := g.engine.GetCareer()
 	currentMap := g.engine.GetGame().GetMap()
 	startLocation := currentMap.PlayerSpawn
+	if location, isNamed := currentMap.NamedLocations[g.Loadout.StartLocation]; isNamed {
+		startLocation = location
+	}
 	visionRange := 90
 	player := &core.Actor{
 		Name:           career.PlayerName,
@@ -378,6 +473,38 @@ func (g *GameStateGameplay) SpawnPlayer() {
 	currentMap.AddActor(player, startLocation)
 	currentMap.UpdateFieldOfView(player)
 }
+
+// equipLoadout hands the gear of the loadout to the player and hides the stash item at its stash point.
+func (g *GameStateGameplay) equipLoadout() {
+	currentMap := g.engine.GetGame().GetMap()
+	itemFactory := g.engine.GetItemFactory()
+	player := currentMap.Player
+	for _, itemName := range g.Loadout.Gear {
+		item := itemFactory.DecodeStringToItem(itemName)
+		item.HeldBy = player
+		player.Inventory.AddItem(&item)
+	}
+	if g.Loadout.StashItem == "" {
+		return
+	}
+	stashPos, isNamed := currentMap.NamedLocations[g.Loadout.StashPoint]
+	if !isNamed {
+		return
+	}
+	if currentMap.IsItemAt(stashPos) {
+		freeCells := currentMap.GetFreeCellsForDistribution(stashPos, 1, func(p geometry.Point) bool {
+			return currentMap.IsTileWalkable(p) && !currentMap.IsItemAt(p)
+		})
+		if len(freeCells) == 0 {
+			println("No room for the stash item at " + g.Loadout.StashPoint)
+			return
+		}
+		stashPos = freeCells[0]
+	}
+	stashItem := itemFactory.DecodeStringToItem(g.Loadout.StashItem)
+	currentMap.AddItem(&stashItem, stashPos)
+}
+
 func (g *GameStateGameplay) drawTargetPath(con console.CellInterface) {
 	cam := g.engine.GetGame().GetCamera()
 	player := g.engine.GetGame().GetMap().Player
@@ -488,8 +615,6 @@ func (g *GameStateGameplay) ensureWorldPosInView(worldPosition geometry.Point, b
 	screenMapHeight := g.engine.MapWindowHeight()
 	game := g.engine.GetGame()
 	camera := game.GetCamera()
-	mapWidth := game.GetMap().MapWidth
-	mapHeight := game.GetMap().MapHeight
 	newPositionOnScreen := camera.WorldToScreen(worldPosition)
 
 	moveDelta := geometry.Point{X: 0, Y: 0}
@@ -505,7 +630,7 @@ func (g *GameStateGameplay) ensureWorldPosInView(worldPosition geometry.Point, b
 	}
 
 	if moveDelta.X != 0 || moveDelta.Y != 0 {
-		camera.MoveBy(moveDelta, mapWidth, mapHeight)
+		camera.MoveByWithin(moveDelta, game.GetMap().CameraBoundsAt(worldPosition))
 	}
 
 }
@@ -610,6 +735,7 @@ func (g *GameStateGameplay) Update(input services.InputInterface) {
 	game := g.engine.GetGame()
 	aic := g.engine.GetAI()
 	aic.Update()
+	g.followPlayerToOtherFloor()
 	commands := input.PollGameCommands()
 	noPointerCmd := false
 	for _, command := range commands {
@@ -649,6 +775,35 @@ func (g *GameStateGameplay) Update(input services.InputInterface) {
 		currentMap.UpdateDynamicLights()
 	}
 
+	g.fireAccumulator++
+	if g.fireAccumulator >= utils.SecondsToTicks(gridmap.FireStepSeconds) {
+		g.fireAccumulator = 0
+		game.UpdateFire()
+	}
+
+	g.liquidAccumulator++
+	if g.liquidAccumulator >= utils.SecondsToTicks(gridmap.LiquidStepSeconds) {
+		g.liquidAccumulator = 0
+		game.UpdateLiquids()
+	}
+
+	g.gasAccumulator++
+	if g.gasAccumulator >= utils.SecondsToTicks(gridmap.GasStepSeconds) {
+		g.gasAccumulator = 0
+		game.UpdateGas()
+	}
+
+	if g.isPlayerInSight() {
+		game.GetStats().SecondsInSight += utils.TicksToSeconds(1)
+	}
+
+	g.objectivesAccumulator++
+	if g.objectivesAccumulator >= utils.SecondsToTicks(services.ObjectiveCheckSeconds) {
+		g.objectivesAccumulator = 0
+		game.UpdateObjectives()
+	}
+	g.checkEscalationTimeLimit()
+
 	// Advance in-game time: one real second = one in-game minute.
 	g.timeAccumulator++
 	if g.timeAccumulator >= utils.SecondsToTicks(1) {
@@ -801,6 +956,11 @@ func (g *GameStateGameplay) playerPeekingFromInput(pdelta geometry.Point) {
 	player.InteractionShift = pdelta
 	if (pdelta.X != 0 || pdelta.Y != 0) && player.CanMove() && g.canPeekFrom(player.Pos().Add(pdelta)) {
 		player.FoVShift = pdelta
+		player.PeekDistance = 1
+		farPeek := player.Pos().Add(pdelta.Mul(services.SkillCornerPeekDistance))
+		if game.GetPlayerSkills().CornerPeek && g.canPeekFrom(farPeek) {
+			player.PeekDistance = services.SkillCornerPeekDistance
+		}
 	} else {
 		player.FoVShift = geometry.PointZero
 	}
@@ -1015,9 +1175,19 @@ func (g *GameStateGameplay) UpdateStatusLine() {
 		challengeInformation += "@gW@N"
 	}
 
+	objectiveInformation := ""
+	if objectives := m.GetObjectives(); objectives != nil && len(objectives.Objectives) > 0 {
+		completed, total := objectives.Progress()
+		if objectives.RequiredCompleted() {
+			objectiveInformation = fmt.Sprintf(" | @gO %d/%d@N", completed, total)
+		} else {
+			objectiveInformation = fmt.Sprintf(" | O %d/%d", completed, total)
+		}
+	}
+
 	redStyle := common.DefaultStyle.WithBg(core.CurrentTheme.HUDDangerBackground)
 	greenStyle := common.DefaultStyle.WithBg(core.CurrentTheme.HUDGoodBackground)
-	g.topLabel.SetStyledText(core.Text(fmt.Sprintf("%s@i%s@N | @d%s@N%s | %s", string(player.MovementMode), itemSymbol, zoneInformation, challengeInformation, currentMap.TimeOfDay.Format("15:04"))).
+	g.topLabel.SetStyledText(core.Text(fmt.Sprintf("%s@i%s@N | @d%s@N%s%s%s | %s", string(player.MovementMode), itemSymbol, zoneInformation, challengeInformation, objectiveInformation, g.escalationInformation(), currentMap.TimeOfDay.Format("15:04"))).
 		WithStyle(common.DefaultStyle).
 		WithMarkup('i', itemStyle).
 		WithMarkup('d', detectionStyle).
@@ -1027,20 +1197,24 @@ func (g *GameStateGameplay) UpdateStatusLine() {
 
 	g.isDirty = true
 }
-func (g *GameStateGameplay) witnessCount() int {
-	witnessCount := 0
+
+// isPlayerInSight is true if the player is in the vision cone of any NPC.
+func (g *GameStateGameplay) isPlayerInSight() bool {
 	currentMap := g.engine.GetGame().GetMap()
-	for _, actor := range currentMap.Actors() {
-		if actor.IsEyeWitness {
-			witnessCount++
-		}
+	player := currentMap.Player
+	if !player.IsVisible() {
+		return false
 	}
-	for _, downedActor := range currentMap.DownedActors() {
-		if downedActor.IsAlive() && downedActor.IsEyeWitness {
-			witnessCount++
+	for _, actor := range currentMap.Actors() {
+		if actor != player && actor.IsActive() && actor.CanSeeInVisionCone(player.Pos()) {
+			return true
 		}
 	}
-	return witnessCount
+	return false
+}
+
+func (g *GameStateGameplay) witnessCount() int {
+	return services.CountWitnesses(g.engine.GetGame().GetMap())
 }
 
 func (g *GameStateGameplay) drawContextHUD(con console.CellInterface) {
@@ -1121,6 +1295,10 @@ func (g *GameStateGameplay) handleCommand(command core.GameCommand) {
 				if len(player.Inventory.Items) > 0 {
 					openInventory()
 				}
+			}, func(itemToCombine *core.Item) {
+				model.CombineItem(player, itemToCombine)
+				g.midLabel.SetText("")
+				g.midLabel.SetDirty()
 			})
 		}
 		openInventory()
@@ -121,7 +121,7 @@ func (g *GameStateGameplay) BeginMouseAiming() {
 		return
 	}
 	g.Ui = aimingUIState
-	if player.EquippedItem.Type.HasScope() {
+	if player.EquippedItem.HasScope() {
 		player.FovMode = gridmap.FoVModeScoped
 		currentMap.UpdateFieldOfView(player)
 	}
@@ -255,7 +255,7 @@ func (g *GameStateGameplay) AdjustPlayerAimFromPad(xAxis float64, yAxis float64)
 		g.padAimPos = player.Pos().ToPointF()
 		g.Ui = aimingUIState
 		g.padAimActive = true
-		if player.EquippedItem != nil && player.EquippedItem.Type.HasScope() {
+		if player.EquippedItem != nil && player.EquippedItem.HasScope() {
 			player.FovMode = gridmap.FoVModeScoped
 			currentMap.UpdateFieldOfView(player)
 		}
@@ -353,7 +353,7 @@ func assassinationTargets(engine services.Engine) map[*core.Actor]struct{} {
 	}
 
 	maxTargets := 1
-	if piercingCount >= 2 && engine.GetCareer().UnlockedSkills.DoubleAssassination {
+	if piercingCount >= 2 && engine.GetGame().GetPlayerSkills().DoubleAssassination {
 		maxTargets = 2
 	}
 
@@ -24,26 +24,95 @@ type ParserLogicRegisterer interface {
 	RegisterAction(name string, actionFunc core.ActionFunc)
 }
 
-func (g *GameStateGameplay) registerPredicateAndAssignmentFunctions(parser ParserLogicRegisterer) {
-	currentMap := g.engine.GetGame().GetMap()
+// scriptFunctionTable registers the script functions with the parser of a mission. Without a parser
+// it only collects their signatures, that is how director.Vocabulary is built for the editor.
+type scriptFunctionTable struct {
+	parser     ParserLogicRegisterer
+	signatures *[]director.FunctionSignature
+}
+
+func init() {
+	signatures := make([]director.FunctionSignature, 0)
+	table := scriptFunctionTable{signatures: &signatures}
+	g := &GameStateGameplay{}
+	g.registerPredicateAndAssignmentFunctions(table)
+	g.registerActionFunctions(table)
+	for _, signature := range signatures {
+		director.RegisterSignature(signature)
+	}
+}
+
+func params(kinds ...director.ParamKind) []director.ParamKind {
+	return kinds
+}
+
+func (t scriptFunctionTable) addSignature(signature director.FunctionSignature) {
+	if t.signatures != nil {
+		*t.signatures = append(*t.signatures, signature)
+	}
+}
+
+func (t scriptFunctionTable) Value(name string, params []director.ParamKind, returns director.ParamKind, valueFunc core.AnyFunc) {
+	t.addSignature(director.FunctionSignature{Name: name, Kind: director.FunctionValue, Params: params, Returns: returns})
+	if t.parser != nil {
+		t.parser.RegisterAny(name, valueFunc)
+	}
+}
+
+func (t scriptFunctionTable) Predicate(name string, params []director.ParamKind, predicate core.Predicate) {
+	t.addSignature(director.FunctionSignature{Name: name, Kind: director.FunctionPredicate, Params: params})
+	if t.parser != nil {
+		t.parser.RegisterPredicate(name, predicate)
+	}
+}
+
+// VariadicPredicate registers a predicate that accepts any number of arguments of its last parameter kind.
+func (t scriptFunctionTable) VariadicPredicate(name string, params []director.ParamKind, predicate core.Predicate) {
+	t.addSignature(director.FunctionSignature{Name: name, Kind: director.FunctionPredicate, Params: params, Variadic: true})
+	if t.parser != nil {
+		t.parser.RegisterPredicate(name, predicate)
+	}
+}
+
+func (t scriptFunctionTable) Action(name string, params []director.ParamKind, actionFunc core.ActionFunc) {
+	t.addSignature(director.FunctionSignature{Name: name, Kind: director.FunctionAction, Params: params})
+	if t.parser != nil {
+		t.parser.RegisterAction(name, actionFunc)
+	}
+}
+
+// VariadicAction registers an action that accepts any number of arguments of its last parameter kind.
+func (t scriptFunctionTable) VariadicAction(name string, params []director.ParamKind, actionFunc core.ActionFunc) {
+	t.addSignature(director.FunctionSignature{Name: name, Kind: director.FunctionAction, Params: params, Variadic: true})
+	if t.parser != nil {
+		t.parser.RegisterAction(name, actionFunc)
+	}
+}
+
+// registerPredicateAndAssignmentFunctions must not use the game before the functions are called,
+// it also runs without one to build the director.Vocabulary.
+func (g *GameStateGameplay) registerPredicateAndAssignmentFunctions(parser scriptFunctionTable) {
+	currentMap := func() *gridmap.GridMap[*core.Actor, *core.Item, services.Object] {
+		return g.engine.GetGame().GetMap()
+	}
 
 	// VALUE FUNCTIONS
-	parser.RegisterAny("NamedLocation", func(args ...any) any {
+	parser.Value("NamedLocation", params(director.ParamText), director.ParamLocation, func(args ...any) any {
 		nameArgument := args[0].(string)
-		if pos, ok := currentMap.NamedLocations[nameArgument]; ok {
+		if pos, ok := currentMap().NamedLocations[nameArgument]; ok {
 			return pos
 		}
 		println(fmt.Sprintf("(WARNING) NamedLocation: No location named '%s'", nameArgument))
 		return geometry.Point{X: -1, Y: -1}
 	})
-	parser.RegisterAny("ActorWithName", func(args ...any) any {
+	parser.Value("ActorWithName", params(director.ParamText), director.ParamActor, func(args ...any) any {
 		nameArgument := args[0].(string)
-		for _, actor := range currentMap.AllActors {
+		for _, actor := range currentMap().AllActors {
 			if actor.Name == nameArgument {
 				return actor
 			}
 		}
-		for _, actor := range currentMap.AllDownedActors {
+		for _, actor := range currentMap().AllDownedActors {
 			if actor.Name == nameArgument {
 				return actor
 			}
@@ -51,7 +120,7 @@ func (g *GameStateGameplay) registerPredicateAndAssignmentFunctions(parser Parse
 		println(fmt.Sprintf("(WARNING) ActorWithName: No actor named '%s'", nameArgument))
 		return nil
 	})
-	parser.RegisterAny("ItemWithNameInInventory", func(args ...any) any {
+	parser.Value("ItemWithNameInInventory", params(director.ParamActor, director.ParamText), director.ParamItem, func(args ...any) any {
 		person := args[0].(*core.Actor)
 		itemName := args[1].(string)
 		for _, item := range person.Inventory.Items {
@@ -62,7 +131,7 @@ func (g *GameStateGameplay) registerPredicateAndAssignmentFunctions(parser Parse
 		println(fmt.Sprintf("(WARNING) ItemWithNameInInventory: No item named '%s' in inventory of '%s'", itemName, person.DebugDisplayName()))
 		return nil
 	})
-	parser.RegisterAny("KeyItem", func(args ...any) any {
+	parser.Value("KeyItem", params(director.ParamActor, director.ParamText), director.ParamItem, func(args ...any) any {
 		person := args[0].(*core.Actor)
 		key := args[1].(string)
 		for _, item := range person.Inventory.Items {
@@ -73,7 +142,7 @@ func (g *GameStateGameplay) registerPredicateAndAssignmentFunctions(parser Parse
 		println(fmt.Sprintf("(WARNING) KeyItem: No item with key '%s' in inventory of '%s'", key, person.DebugDisplayName()))
 		return nil
 	})
-	parser.RegisterAny("NearestItemWithName", func(args ...any) any {
+	parser.Value("NearestItemWithName", params(director.ParamActor, director.ParamText), director.ParamItem, func(args ...any) any {
 		actorArgument, argOK := args[0].(*core.Actor)
 		if !argOK {
 			println(fmt.Sprintf("Expected actor as first argument, got '%v'", args[0]))
@@ -86,7 +155,7 @@ func (g *GameStateGameplay) registerPredicateAndAssignmentFunctions(parser Parse
 		}
 		var nearestItem *core.Item
 		var nearestDistance int
-		for _, item := range currentMap.AllItems {
+		for _, item := range currentMap().AllItems {
 			if strings.Contains(strings.ToLower(item.Name), strings.ToLower(namePart)) {
 				distance := geometry.DistanceManhattan(item.Pos(), actorArgument.Pos())
 				if nearestItem == nil || distance < nearestDistance {
@@ -102,7 +171,7 @@ func (g *GameStateGameplay) registerPredicateAndAssignmentFunctions(parser Parse
 	})
 
 	// PREDICATES
-	parser.RegisterPredicate("AreActorsMeeting", func(args ...any) bool {
+	parser.Predicate("AreActorsMeeting", params(director.ParamActor, director.ParamActor), func(args ...any) bool {
 		actor1 := args[0].(*core.Actor)
 		actor2 := args[1].(*core.Actor)
 		distanceManhattan := geometry.DistanceManhattan(actor1.Pos(), actor2.Pos())
@@ -110,60 +179,60 @@ func (g *GameStateGameplay) registerPredicateAndAssignmentFunctions(parser Parse
 		areNearToEachOther := distanceManhattan < 6
 		return areNearToEachOther && canSeeEachOther
 	})
-	parser.RegisterPredicate("IsScriptFinished", func(args ...any) bool {
+	parser.Predicate("IsScriptFinished", params(director.ParamActor), func(args ...any) bool {
 		actor := args[0].(*core.Actor)
 		return actor.Script.IsFinished()
 	})
-	parser.RegisterPredicate("IsItemAtPlayerPosition", func(args ...any) bool {
+	parser.Predicate("IsItemAtPlayerPosition", params(), func(args ...any) bool {
 		player := g.engine.GetGame().GetMap().Player
 		playerPos := player.Pos()
-		return currentMap.IsItemAt(playerPos)
+		return currentMap().IsItemAt(playerPos)
 	})
-	parser.RegisterPredicate("HasRangedWeapon", func(args ...any) bool {
+	parser.Predicate("HasRangedWeapon", params(director.ParamActor), func(args ...any) bool {
 		actor := args[0].(*core.Actor)
 		return actor.HasWeapon() // TODO: check if it's a ranged weapon
 	})
-	parser.RegisterPredicate("IsDead", func(args ...any) bool {
+	parser.Predicate("IsDead", params(director.ParamActor), func(args ...any) bool {
 		actor := args[0].(*core.Actor)
 		return actor.IsDead()
 	})
-	parser.RegisterPredicate("IsScriptFinished", func(args ...any) bool {
+	parser.Predicate("IsScriptFinished", params(director.ParamActor), func(args ...any) bool {
 		actor := args[0].(*core.Actor)
 		return actor.Script.IsFinished()
 	})
-	parser.RegisterPredicate("IsAtLocation", func(args ...any) bool {
+	parser.Predicate("IsAtLocation", params(director.ParamActor, director.ParamLocation), func(args ...any) bool {
 		actor := args[0].(*core.Actor)
 		location := args[1].(geometry.Point)
 		return actor.Pos() == location
 	})
-	parser.RegisterPredicate("IsMissionTimeInSeconds", func(args ...any) bool {
+	parser.Predicate("IsMissionTimeInSeconds", params(director.ParamNumber), func(args ...any) bool {
 		missionRunningSinceSeconds, _ := strconv.Atoi(args[0].(string))
 		if g.engine.CurrentInGameTick() > uint64(utils.SecondsToTicks(float64(missionRunningSinceSeconds))) {
 			return true
 		}
 		return false
 	})
-	parser.RegisterPredicate("IsWaiting", func(args ...any) bool {
+	parser.Predicate("IsWaiting", params(director.ParamActor), func(args ...any) bool {
 		actor := args[0].(*core.Actor)
 		return actor.IsIdle()
 	})
-	parser.RegisterPredicate("IsDowned", func(args ...any) bool {
+	parser.Predicate("IsDowned", params(director.ParamActor), func(args ...any) bool {
 		actor := args[0].(*core.Actor)
 		return actor.IsDowned()
 	})
-	parser.RegisterPredicate("HasDialogueEnded", func(args ...any) bool {
+	parser.Predicate("HasDialogueEnded", params(director.ParamDialogue), func(args ...any) bool {
 		dialogueName := args[0].(string)
 		if dialogue, ok := g.mapDialogues[dialogueName]; ok {
 			return dialogue.LastSpeaker.Dialogue.HasSpoken(dialogue.LastSpeechCode)
 		}
 		return false
 	})
-	parser.RegisterPredicate("CanSeeActor", func(args ...any) bool {
+	parser.Predicate("CanSeeActor", params(director.ParamActor, director.ParamActor), func(args ...any) bool {
 		viewer := args[0].(*core.Actor)
 		target := args[1].(*core.Actor)
 		return viewer.CanSeeActor(target)
 	})
-	parser.RegisterPredicate("HasItemInInventory", func(args ...any) bool {
+	parser.Predicate("HasItemInInventory", params(director.ParamActor, director.ParamItem), func(args ...any) bool {
 		actor := args[0].(*core.Actor)
 		item := args[1].(*core.Item)
 		for _, heldItem := range actor.Inventory.Items {
@@ -173,32 +242,32 @@ func (g *GameStateGameplay) registerPredicateAndAssignmentFunctions(parser Parse
 		}
 		return false
 	})
-	parser.RegisterPredicate("HasEnteredZone", func(args ...any) bool {
+	parser.Predicate("HasEnteredZone", params(director.ParamActor, director.ParamZone), func(args ...any) bool {
 		actor := args[0].(*core.Actor)
 		zoneName := args[1].(string)
-		currentZone := currentMap.ZoneAt(actor.Pos())
-		lastZone := currentMap.ZoneAt(actor.LastPos)
+		currentZone := currentMap().ZoneAt(actor.Pos())
+		lastZone := currentMap().ZoneAt(actor.LastPos)
 		return currentZone.Name == zoneName && lastZone.Name != zoneName
 	})
-	parser.RegisterPredicate("HasPlayerEnteredZone", func(args ...any) bool {
-		actor := currentMap.Player
+	parser.Predicate("HasPlayerEnteredZone", params(director.ParamZone), func(args ...any) bool {
+		actor := currentMap().Player
 		zoneName := args[0].(string)
-		currentZone := currentMap.ZoneAt(actor.Pos())
-		lastZone := currentMap.ZoneAt(actor.LastPos)
+		currentZone := currentMap().ZoneAt(actor.Pos())
+		lastZone := currentMap().ZoneAt(actor.LastPos)
 		return currentZone.Name == zoneName && lastZone.Name != zoneName
 	})
-	parser.RegisterPredicate("IsPlayerTrespassing", func(args ...any) bool {
-		return currentMap.IsTrespassing(currentMap.Player)
+	parser.Predicate("IsPlayerTrespassing", params(), func(args ...any) bool {
+		return currentMap().IsTrespassing(currentMap().Player)
 	})
 	// PlayerHasLineOfSightToActors(actor1, actor2, ...) — returns true when the
 	// player has direct line of sight to every listed actor AND every actor is
 	// inside the currently rendered viewport.  All actors must satisfy both
 	// conditions simultaneously.
-	parser.RegisterPredicate("PlayerHasLineOfSightToActors", func(args ...any) bool {
+	parser.VariadicPredicate("PlayerHasLineOfSightToActors", params(director.ParamActor), func(args ...any) bool {
 		if len(args) == 0 {
 			return false
 		}
-		player := currentMap.Player
+		player := currentMap().Player
 		if player == nil {
 			return false
 		}
@@ -214,92 +283,100 @@ func (g *GameStateGameplay) registerPredicateAndAssignmentFunctions(parser Parse
 		}
 		return true
 	})
+	parser.Predicate("IsCampaignFlagSet", params(director.ParamText), func(args ...any) bool {
+		return isCampaignFlagSet(g.engine, args[0].(string))
+	})
 }
 
-func (g *GameStateGameplay) registerActionFunctions(parser ParserLogicRegisterer) {
+// registerActionFunctions must not use the game before the functions are called,
+// it also runs without one to build the director.Vocabulary.
+func (g *GameStateGameplay) registerActionFunctions(parser scriptFunctionTable) {
 	// ACTIONS
-	parser.RegisterAction("Wait", func(args ...any) {
+	parser.Action("SetCampaignFlag", params(director.ParamText), func(args ...any) {
+		setCampaignFlag(g.engine, services.CurrentCampaign(g.engine), args[0].(string))
+	})
+	parser.Action("Wait", params(director.ParamActor, director.ParamNumber), func(args ...any) {
 		actor := args[0].(*core.Actor)
 		delay, _ := strconv.ParseFloat(args[1].(string), 64)
 		actor.Script.AddAction(director.NewWaitAction(delay))
 	})
-	parser.RegisterAction("Approach", func(args ...any) {
+	parser.Action("Approach", params(director.ParamActor, director.ParamActor), func(args ...any) {
 		actor := args[0].(*core.Actor)
 		target := args[1].(*core.Actor)
 		actor.Script.AddAction(director.NewApproachAction(target))
 	})
-	parser.RegisterAction("UseItemAtRange", func(args ...any) {
+	parser.Action("UseItemAtRange", params(director.ParamActor, director.ParamActor), func(args ...any) {
 		actor := args[0].(*core.Actor)
 		target := args[1].(*core.Actor)
 		actor.Script.AddAction(director.NewUseItemAtRangeAction(g.engine, target))
 	})
-	parser.RegisterAction("MoveToItem", func(args ...any) {
+	parser.Action("MoveToItem", params(director.ParamActor, director.ParamItem), func(args ...any) {
 		actor := args[0].(*core.Actor)
 		item := args[1].(*core.Item)
 		actor.Script.AddAction(director.NewMoveToItem(g.engine, func(currentItem *core.Item) bool {
 			return currentItem == item
 		}))
 	})
-	parser.RegisterAction("MoveToLocation", func(args ...any) {
+	parser.Action("MoveToLocation", params(director.ParamActor, director.ParamLocation), func(args ...any) {
 		actor := args[0].(*core.Actor)
 		destination := args[1].(geometry.Point)
 		actor.Script.AddAction(director.NewMoveAction(destination))
 	})
-	parser.RegisterAction("SetPreferredLocation", func(args ...any) {
+	parser.Action("SetPreferredLocation", params(director.ParamActor, director.ParamLocation), func(args ...any) {
 		actor := args[0].(*core.Actor)
 		destination := args[1].(geometry.Point)
 		actor.Script.AddAction(director.NewSetPreferredLocation(destination))
 	})
-	parser.RegisterAction("SetPreferredLocationHere", func(args ...any) {
+	parser.Action("SetPreferredLocationHere", params(director.ParamActor), func(args ...any) {
 		actor := args[0].(*core.Actor)
 		actor.Script.AddAction(director.NewSetPreferredLocationHere())
 	})
-	parser.RegisterAction("StopStaying", func(args ...any) {
+	parser.Action("StopStaying", params(director.ParamActor), func(args ...any) {
 		actor := args[0].(*core.Actor)
 		actor.Script.AddAction(director.NewStopStayingAtPreferredLocationAction())
 	})
-	parser.RegisterAction("PickUpItem", func(args ...any) {
+	parser.Action("PickUpItem", params(director.ParamActor), func(args ...any) {
 		actor := args[0].(*core.Actor)
 		actor.Script.AddAction(director.NewPickUpAction(g.engine))
 	})
-	parser.RegisterAction("Wait", func(args ...any) {
+	parser.Action("Wait", params(director.ParamActor), func(args ...any) {
 		actor := args[0].(*core.Actor)
 		aic := g.engine.GetAI()
 		actor.Script.AddAction(director.NewSwitchToWaitAction(aic))
 	})
-	parser.RegisterAction("DropFromInventory", func(args ...any) {
+	parser.Action("DropFromInventory", params(director.ParamActor, director.ParamItem), func(args ...any) {
 		actor := args[0].(*core.Actor)
 		item := args[1].(*core.Item)
 		actor.Script.AddAction(director.NewDropFromInventoryAction(g.engine, item))
 	})
-	parser.RegisterAction("Dance", func(args ...any) {
+	parser.Action("Dance", params(director.ParamActor, director.ParamNumber), func(args ...any) {
 		actor := args[0].(*core.Actor)
 		delayInSeconds, _ := strconv.ParseFloat(args[1].(string), 64)
 		actor.Script.AddAction(director.NewDanceAction(delayInSeconds))
 	})
-	parser.RegisterAction("TurnTable", func(args ...any) {
+	parser.Action("TurnTable", params(director.ParamActor, director.ParamNumber), func(args ...any) {
 		actor := args[0].(*core.Actor)
 		delayInSeconds, _ := strconv.ParseFloat(args[1].(string), 64)
 		actor.Script.AddAction(director.NewTurnTableAction(delayInSeconds))
 	})
 
 	//// INSTANT ACTIONS
-	parser.RegisterAction("InstantDropFromInventory", func(args ...any) {
+	parser.Action("InstantDropFromInventory", params(director.ParamActor, director.ParamItem), func(args ...any) {
 		actor := args[0].(*core.Actor)
 		item := args[1].(*core.Item)
 		director.NewDropFromInventoryAction(g.engine, item).Execute(actor)
 	})
-	parser.RegisterAction("StartDialogue", func(args ...any) {
+	parser.Action("StartDialogue", params(director.ParamDialogue), func(args ...any) {
 		dialogueName := args[0].(string)
 		if dialogue, ok := g.mapDialogues[dialogueName]; ok {
 			dialogue.InitialSpeaker.StartDialogue(dialogueName)
 		}
 	})
-	parser.RegisterAction("Print", func(args ...any) {
+	parser.Action("Print", params(director.ParamText, director.ParamText), func(args ...any) {
 		text := args[1].(string)
 		g.Print(text)
 	})
-	parser.RegisterAction("Say", func(args ...any) {
+	parser.Action("Say", params(director.ParamActor, director.ParamText), func(args ...any) {
 		actor := args[0].(*core.Actor)
 		text := args[1].(string)
 		actor.SetNextUtterance(core.Utterance{
@@ -307,7 +384,7 @@ func (g *GameStateGameplay) registerActionFunctions(parser ParserLogicRegisterer
 			EventCode: "DLG_SAY",
 		})
 	})
-	parser.RegisterAction("DeleteDialogue", func(args ...any) {
+	parser.Action("DeleteDialogue", params(director.ParamDialogue), func(args ...any) {
 		dialogueName := args[0].(string)
 		if dialogue, ok := g.mapDialogues[dialogueName]; ok {
 			delete(g.mapDialogues, dialogueName)
@@ -318,31 +395,31 @@ func (g *GameStateGameplay) registerActionFunctions(parser ParserLogicRegisterer
 			})
 		}
 	})
-	parser.RegisterAction("PinDialogueLocation", func(args ...any) {
+	parser.Action("PinDialogueLocation", params(director.ParamActor), func(args ...any) {
 		actor := args[0].(*core.Actor)
 		actor.Dialogue.Situation = &core.OrientedLocation{
 			Location:  actor.Pos(),
 			Direction: actor.LookDirection,
 		}
 	})
-	parser.RegisterAction("SwitchToScript", func(args ...any) {
+	parser.Action("SwitchToScript", params(director.ParamActor), func(args ...any) {
 		actor := args[0].(*core.Actor)
 		actor.Script.SetDefaultMoveActionGenerator(director.NewMoveAction)
 		aic := g.engine.GetAI()
 		aic.SwitchToScript(actor)
 	})
-	parser.RegisterAction("LookAtActor", func(args ...any) {
+	parser.Action("LookAtActor", params(director.ParamActor, director.ParamActor), func(args ...any) {
 		actor := args[0].(*core.Actor)
 		target := args[1].(*core.Actor)
 		actor.LookAt(target.Pos())
 		//actor.Script.AddAction(director.NewLookAtActorAction(target))
 	})
-	parser.RegisterAction("StopScripted", func(args ...any) {
+	parser.Action("StopScripted", params(director.ParamActor), func(args ...any) {
 		actor := args[0].(*core.Actor)
 		aic := g.engine.GetAI()
 		aic.TryPopScripted(actor)
 	})
-	parser.RegisterAction("CreateTravelGroup", func(args ...any) {
+	parser.VariadicAction("CreateTravelGroup", params(director.ParamActor), func(args ...any) {
 		aic := g.engine.GetAI()
 		group := mapset.NewSet[*core.Actor]()
 		for _, actor := range args {
@@ -350,7 +427,7 @@ func (g *GameStateGameplay) registerActionFunctions(parser ParserLogicRegisterer
 		}
 		aic.CreateTravelGroup(group)
 	})
-	parser.RegisterAction("DeleteTravelGroup", func(args ...any) {
+	parser.VariadicAction("DeleteTravelGroup", params(director.ParamActor), func(args ...any) {
 		group := mapset.NewSet[*core.Actor]()
 		for _, actor := range args {
 			group.Add(actor.(*core.Actor))
@@ -359,7 +436,7 @@ func (g *GameStateGameplay) registerActionFunctions(parser ParserLogicRegisterer
 		aic.DeleteTravelGroup(group)
 	})
 	// Special Action for map
-	parser.RegisterAction("FillZoneRandomlyWithStimuli", func(args ...any) {
+	parser.Action("FillZoneRandomlyWithStimuli", params(director.ParamZone, director.ParamStimulus, director.ParamNumber), func(args ...any) {
 		nameOfZone := args[0].(string)
 		nameOfStim := stimuli.StimulusType(args[1].(string))
 		amount, _ := strconv.Atoi(args[2].(string))
@@ -399,30 +476,30 @@ func (g *GameStateGameplay) registerActionFunctions(parser ParserLogicRegisterer
 	})
 
 	// Training Related
-	parser.RegisterAction("PrintMovementHint", func(args ...any) {
+	parser.Action("PrintMovementHint", params(), func(args ...any) {
 		input := g.engine.GetInput()
 		movementKey := input.GetKeyDefinitions().MovementKeys
 		infoText := fmt.Sprintf("HINT: Use @l[%s,%s,%s,%s]@N to move around", movementKey[0].String(), movementKey[1].String(), movementKey[2].String(), movementKey[3].String())
 		g.PrintStyled(core.NewStyledText(infoText, common.TerminalStyle).WithMarkup('l', common.DefaultStyle.WithFg(common.Green)))
 	})
-	parser.RegisterAction("PrintPickupHint", func(args ...any) {
+	parser.Action("PrintPickupHint", params(), func(args ...any) {
 		input := g.engine.GetInput()
 		movementKey := input.GetKeyDefinitions().SameTileActionKey
 		infoText := fmt.Sprintf("HINT: Use @l[%s]@N to pick up items", movementKey.String())
 		g.PrintStyled(core.NewStyledText(infoText, common.TerminalStyle).WithMarkup('l', common.DefaultStyle.WithFg(common.Green)))
 	})
-	parser.RegisterAction("PrintExplorationHint", func(args ...any) {
+	parser.Action("PrintExplorationHint", params(), func(args ...any) {
 		g.PrintStyled(core.NewStyledText("HINT: Move @lNorth-East@N towards the landing bridge.", common.TerminalStyle).WithMarkup('l', common.DefaultStyle.WithFg(common.Green)))
 	})
-	parser.RegisterAction("ShowInfiltrationAlert", func(args ...any) {
+	parser.Action("ShowInfiltrationAlert", params(), func(args ...any) {
 		training := NewTrainingHelper(g.engine)
 		training.alertInfiltration()
 	})
-	parser.RegisterAction("ShowPeekingKeyHolesAlert", func(args ...any) {
+	parser.Action("ShowPeekingKeyHolesAlert", params(), func(args ...any) {
 		training := NewTrainingHelper(g.engine)
 		training.alertPeekingThroughKeyholes()
 	})
-	parser.RegisterAction("ShowPeekingPickupAlert", func(args ...any) {
+	parser.Action("ShowPeekingPickupAlert", params(), func(args ...any) {
 		training := NewTrainingHelper(g.engine)
 		training.alertPeekingPickup()
 	})
@@ -42,18 +42,32 @@ func (g *GameStateNewCareer) Init(engine services.Engine) {
 }
 
 func (g *GameStateNewCareer) confirm(name string) {
-	name = strings.TrimSpace(strings.ToLower(name))
-	if name == "" {
+	if !startNewCareer(g.engine, name) {
 		return
 	}
-	career := g.engine.GetCareer()
-	career.PlayerName = name
-	career.CurrentCampaignFolder = "first blood"
-	career.SaveToFile()
 	g.engine.GetGame().PopState()
 	g.engine.GetGame().PushState(&GameStateMainMenu{})
 }
 
+// startNewCareer creates a profile for the contractor ID and switches to it.
+// It returns false if the ID can't be used or is taken by another profile.
+func startNewCareer(engine services.Engine, name string) bool {
+	name = strings.TrimSpace(strings.ToLower(name))
+	if !services.IsValidProfileName(name) {
+		return false
+	}
+	for _, existing := range services.ListProfiles() {
+		if existing == name {
+			return false
+		}
+	}
+	career := services.NewCareer(name)
+	career.CurrentCampaignFolder = "first blood"
+	career.SaveToFile()
+	engine.SetCareer(career)
+	return true
+}
+
 func (g *GameStateNewCareer) Update(input services.InputInterface) {
 	g.textInput.Update(input)
 	g.isDirty = true
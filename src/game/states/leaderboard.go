@@ -0,0 +1,180 @@
+package states
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/memmaker/terminal-assassin/game/services"
+)
+
+// verifyReplay re-simulates the replay headlessly and reports if it reaches the claimed outcome.
+// The engine is reset to the main menu before onFinished is called.
+func verifyReplay(engine services.Engine, replayPath string, claim services.ReplayOutcome, onFinished func(verified bool)) {
+	recorder := engine.GetRecorder()
+	recorder.StartVerification(func(outcome services.ReplayOutcome) {
+		engine.SetHeadless(false)
+		engine.SetInputOverride(nil)
+		// the mission ends while a state is pushed, so leave it on the next tick
+		engine.Schedule(0, func() {
+			engine.Reset()
+			onFinished(outcome.Matches(claim))
+		})
+	})
+	println("Verifying replay " + replayPath)
+	engine.SetHeadless(true)
+	engine.GetGame().PushState(&GameStateReplay{
+		ReplayPath: replayPath,
+		OnComplete: func() {
+			recorder.FinishVerification(services.ReplayOutcome{})
+		},
+		OnError: func(err error) {
+			println("Replay can't be verified: " + err.Error())
+			recorder.FinishVerification(services.ReplayOutcome{})
+		},
+	})
+}
+
+// submitToLeaderboard verifies a replay that was just recorded and puts it on the leaderboard of its map.
+func submitToLeaderboard(engine services.Engine, replayPath string) {
+	replay, err := services.LoadReplayFile(replayPath)
+	if err != nil || !replay.HasOutcome {
+		engine.Reset()
+		return
+	}
+	verifyReplay(engine, replayPath, replay.Outcome, func(verified bool) {
+		userInterface := engine.GetUI()
+		if !verified {
+			userInterface.ShowAlert([]string{"The replay didn't reproduce the mission result.", "It was not added to the leaderboard."})
+			return
+		}
+		leaderboard := services.LoadLeaderboard(replay.MapHash, replay.MapPath)
+		leaderboard.Add(services.LeaderboardEntry{
+			PlayerName: engine.GetCareer().PlayerName,
+			ReplayPath: replayPath,
+			RecordedAt: time.Now(),
+			Outcome:    replay.Outcome,
+		})
+		if err := leaderboard.Save(); err != nil {
+			println("Error saving leaderboard: " + err.Error())
+			return
+		}
+		userInterface.ShowAlert([]string{"The replay was verified.", "It was added to the leaderboard."})
+	})
+}
+
+// importLeaderboard merges the entries of a shared leaderboard file into the local one.
+// Every entry is only accepted if its replay reproduces the claimed result.
+func importLeaderboard(engine services.Engine, filename string) {
+	userInterface := engine.GetUI()
+	shared, err := services.LoadLeaderboardFile(filename)
+	if err != nil {
+		userInterface.ShowAlert([]string{"Failed to load leaderboard:", err.Error()})
+		return
+	}
+	local := services.LoadLeaderboard(shared.MapHash, shared.MapPath)
+	pending := make([]services.LeaderboardEntry, 0, len(shared.Entries))
+	for _, entry := range shared.Entries {
+		if !local.Contains(entry.ReplayPath) {
+			pending = append(pending, entry)
+		}
+	}
+	accepted, rejected := 0, 0
+	var verifyNext func()
+	verifyNext = func() {
+		if len(pending) == 0 {
+			if err := local.Save(); err != nil {
+				println("Error saving leaderboard: " + err.Error())
+			}
+			engine.GetUI().ShowAlert([]string{
+				fmt.Sprintf("Imported %d entries.", accepted),
+				fmt.Sprintf("Rejected %d entries that their replays didn't reproduce.", rejected),
+			})
+			return
+		}
+		entry := pending[0]
+		pending = pending[1:]
+		replay, err := services.LoadReplayFile(entry.ReplayPath)
+		if err != nil || replay.MapHash != shared.MapHash {
+			rejected++
+			verifyNext()
+			return
+		}
+		verifyReplay(engine, entry.ReplayPath, entry.Outcome, func(verified bool) {
+			if verified && local.Add(entry) {
+				accepted++
+			} else {
+				rejected++
+			}
+			verifyNext()
+		})
+	}
+	verifyNext()
+}
+
+func (g *GameStateMainMenu) openLeaderboardsMenu() {
+	userInterface := g.engine.GetUI()
+	menuItems := make([]services.MenuItem, 0)
+	for _, leaderboard := range services.ListLeaderboards() {
+		board := leaderboard
+		menuItems = append(menuItems, services.MenuItem{
+			Label: filepath.Base(board.MapPath),
+			Handler: func() {
+				g.openLeaderboardMenu(board)
+			},
+		})
+	}
+	menuItems = append(menuItems, services.MenuItem{
+		Label: "Import leaderboard",
+		Handler: func() {
+			userInterface.ShowTextInput("Leaderboard file: ", "", func(filename string) {
+				userInterface.PopAll()
+				importLeaderboard(g.engine, filename)
+			}, func() {})
+		},
+	})
+	userInterface.OpenFixedWidthStackedMenu("Leaderboards", menuItems)
+}
+
+// openLeaderboardMenu shows the rankings of one map, for the whole mission and for every challenge.
+func (g *GameStateMainMenu) openLeaderboardMenu(leaderboard *services.Leaderboard) {
+	userInterface := g.engine.GetUI()
+	showRanking := func(category services.LeaderboardCategory, challengeID int) func() {
+		return func() {
+			userInterface.ShowAlert(rankingLines(leaderboard.Ranking(category, challengeID)))
+		}
+	}
+	menuItems := make([]services.MenuItem, 0)
+	for _, category := range services.LeaderboardCategories {
+		menuItems = append(menuItems, services.MenuItem{
+			Label:   string(category),
+			Handler: showRanking(category, 0),
+		})
+	}
+	mapStats := g.engine.GetCareer().MapStatistics[leaderboard.MapHash]
+	for _, challengeID := range leaderboard.ChallengeIDs() {
+		challengeName := fmt.Sprintf("Challenge %d", challengeID)
+		if mapStats != nil {
+			if challenge, completed := mapStats.CompletedChallenges[challengeID]; completed {
+				challengeName = challenge.Name()
+			}
+		}
+		menuItems = append(menuItems, services.MenuItem{
+			Label:   "Fastest: " + challengeName,
+			Handler: showRanking(services.LeaderboardFastest, challengeID),
+		})
+	}
+	userInterface.OpenFixedWidthStackedMenu(filepath.Base(leaderboard.MapPath), menuItems)
+}
+
+func rankingLines(ranking []services.LeaderboardEntry) []string {
+	if len(ranking) == 0 {
+		return []string{"No entries yet."}
+	}
+	lines := make([]string, len(ranking))
+	for i, entry := range ranking {
+		duration := time.Duration(entry.Outcome.Seconds * float64(time.Second)).Round(time.Millisecond)
+		lines[i] = fmt.Sprintf("%2d. %-12s %10s %7d pts %3d alerted", i+1, entry.PlayerName, duration, entry.Outcome.Score, entry.Outcome.NPCsAlerted)
+	}
+	return lines
+}
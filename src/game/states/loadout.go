@@ -0,0 +1,163 @@
+package states
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/memmaker/terminal-assassin/game/services"
+)
+
+// hasLoadoutChoices is true once mastering the map unlocked something to plan with.
+func (g *GameStateMainMenu) hasLoadoutChoices() bool {
+	career := g.engine.GetCareer()
+	currentMap := g.engine.GetGame().GetMap()
+	return len(career.UnlockedStartLocationsFor(currentMap)) > 0 || len(career.UnlockedItemsFor(currentMap)) > 0
+}
+
+// openLoadoutMenu lets the player plan the mission: where to start, which gear to bring
+// and what to smuggle into a stash point. Every change is saved to the career right away.
+func (g *GameStateMainMenu) openLoadoutMenu() {
+	userInterface := g.engine.GetUI()
+	career := g.engine.GetCareer()
+	currentMap := g.engine.GetGame().GetMap()
+
+	loadout := career.LoadoutFor(currentMap)
+	startLocations := append([]string{""}, career.UnlockedStartLocationsFor(currentMap)...)
+	unlockedItems := append([]string{""}, career.UnlockedItemsFor(currentMap)...)
+	stashPoints := services.StashPoints(currentMap)
+	gearSlots := make([]string, career.GearSlots())
+	copy(gearSlots, loadout.Gear)
+
+	save := func() {
+		loadout.Gear = make([]string, 0, len(gearSlots))
+		for _, item := range gearSlots {
+			if item != "" {
+				loadout.Gear = append(loadout.Gear, item)
+			}
+		}
+		career.SetLoadout(currentMap, loadout)
+		career.SaveToFile()
+	}
+	// availableItems are the unlocked items that no other gear slot or the stash holds.
+	availableItems := func(current string) []string {
+		chosen := append(slices.Clone(gearSlots), loadout.StashItem)
+		available := make([]string, 0, len(unlockedItems))
+		for _, item := range unlockedItems {
+			if item == "" || item == current || !slices.Contains(chosen, item) {
+				available = append(available, item)
+			}
+		}
+		return available
+	}
+
+	changeStart := func(step int) func() {
+		return func() {
+			loadout.StartLocation = cycleOption(startLocations, loadout.StartLocation, step)
+			save()
+		}
+	}
+	menuItems := []services.MenuItem{
+		{
+			DynamicLabel: func() string {
+				return "Start      : " + orDefault(loadout.StartLocation, "Default")
+			},
+			Handler:      changeStart(1),
+			LeftHandler:  changeStart(-1),
+			RightHandler: changeStart(1),
+			Condition: func() bool {
+				return len(startLocations) > 1
+			},
+		},
+	}
+
+	for i := range gearSlots {
+		slot := i
+		changeGear := func(step int) func() {
+			return func() {
+				gearSlots[slot] = cycleOption(availableItems(gearSlots[slot]), gearSlots[slot], step)
+				save()
+			}
+		}
+		menuItems = append(menuItems, services.MenuItem{
+			DynamicLabel: func() string {
+				return fmt.Sprintf("Gear %d     : %s", slot+1, orDefault(gearSlots[slot], "Empty"))
+			},
+			Handler:      changeGear(1),
+			LeftHandler:  changeGear(-1),
+			RightHandler: changeGear(1),
+			Condition: func() bool {
+				return len(unlockedItems) > 1
+			},
+		})
+	}
+
+	changeStashItem := func(step int) func() {
+		return func() {
+			loadout.StashItem = cycleOption(availableItems(loadout.StashItem), loadout.StashItem, step)
+			if loadout.StashItem == "" {
+				loadout.StashPoint = ""
+			} else if loadout.StashPoint == "" {
+				loadout.StashPoint = stashPoints[0]
+			}
+			save()
+		}
+	}
+	changeStashPoint := func(step int) func() {
+		return func() {
+			loadout.StashPoint = cycleOption(stashPoints, loadout.StashPoint, step)
+			save()
+		}
+	}
+	menuItems = append(menuItems,
+		services.MenuItem{
+			DynamicLabel: func() string {
+				return "Stash item : " + orDefault(loadout.StashItem, "None")
+			},
+			Handler:      changeStashItem(1),
+			LeftHandler:  changeStashItem(-1),
+			RightHandler: changeStashItem(1),
+			Condition: func() bool {
+				return len(unlockedItems) > 1 && len(stashPoints) > 0
+			},
+		},
+		services.MenuItem{
+			DynamicLabel: func() string {
+				return "Stash point: " + loadout.StashPoint
+			},
+			Handler:      changeStashPoint(1),
+			LeftHandler:  changeStashPoint(-1),
+			RightHandler: changeStashPoint(1),
+			Condition: func() bool {
+				return loadout.StashItem != ""
+			},
+		},
+		services.MenuItem{
+			Label:   "Back",
+			Handler: userInterface.PopModal,
+		},
+	)
+
+	userInterface.OpenFixedWidthStackedMenu("Loadout", menuItems)
+}
+
+// cycleOption returns the option step places away from the current one, wrapping around.
+func cycleOption(options []string, current string, step int) string {
+	if len(options) == 0 {
+		return current
+	}
+	index := 0
+	for i, option := range options {
+		if option == current {
+			index = i
+		}
+	}
+	index = (index + step + len(options)) % len(options)
+	return options[index]
+}
+
+func orDefault(value string, defaultValue string) string {
+	if value == "" {
+		return defaultValue
+	}
+	return value
+}
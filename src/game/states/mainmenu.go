@@ -11,7 +11,6 @@ import (
 	"github.com/memmaker/terminal-assassin/game/editor"
 	"github.com/memmaker/terminal-assassin/game/services"
 	"github.com/memmaker/terminal-assassin/geometry"
-	"github.com/memmaker/terminal-assassin/gridmap"
 )
 
 type GameStateMainMenu struct {
@@ -57,13 +56,8 @@ func (g *GameStateMainMenu) openMainMenu() {
 	}
 	menuItems := []services.MenuItem{
 		{
-			Label: "New Mission",
-			Handler: func() {
-				userInterface.OpenMapsMenu(func(*gridmap.GridMap[*core.Actor, *core.Item, services.Object]) {
-					g.engine.GetAudio().StopAll()
-					g.openBriefingMenu()
-				})
-			},
+			Label:   "New Mission",
+			Handler: g.openNewMission,
 		},
 		{
 			Label:   "Change campaign",
@@ -75,10 +69,18 @@ func (g *GameStateMainMenu) openMainMenu() {
 				g.engine.GetGame().PushState(&GameStateCareerViewer{})
 			},
 		},
+		{
+			Label:   "Profiles",
+			Handler: g.openProfilesMenu,
+		},
 		{
 			Label:   "Watch Replay",
 			Handler: g.openReplayMenu,
 		},
+		{
+			Label:   "Leaderboards",
+			Handler: g.openLeaderboardsMenu,
+		},
 		{
 			Label: "Editor",
 			Handler: func() {
@@ -295,6 +297,81 @@ func (g *GameStateMainMenu) printVersionText(text string, topLeft gruid.Point, y
 	}
 }*/
 
+func (g *GameStateMainMenu) openProfilesMenu() {
+	userInterface := g.engine.GetUI()
+	current := g.engine.GetCareer().PlayerName
+	menuItems := make([]services.MenuItem, 0)
+	for _, profile := range services.ListProfiles() {
+		name := profile
+		label := "  " + name
+		if name == current {
+			label = "* " + name
+		}
+		menuItems = append(menuItems, services.MenuItem{
+			Label: label,
+			Handler: func() {
+				career, err := services.LoadProfile(name)
+				if err != nil {
+					userInterface.ShowAlert([]string{"Could not load profile " + name + ":", err.Error()})
+					return
+				}
+				g.engine.SetCareer(career)
+				g.openMainMenu()
+				g.isDirty = true
+			},
+		})
+	}
+	menuItems = append(menuItems, services.MenuItem{
+		Label: "New profile",
+		Handler: func() {
+			userInterface.ShowTextInput("Contractor ID: ", "", func(name string) {
+				if !startNewCareer(g.engine, name) {
+					userInterface.ShowAlert([]string{"This contractor ID can't be used or is already taken."})
+					return
+				}
+				g.openMainMenu()
+				g.isDirty = true
+			}, func() {})
+		},
+	}, services.MenuItem{
+		Label:   "Delete profile",
+		Handler: g.openDeleteProfileMenu,
+	})
+	userInterface.OpenFixedWidthAutoCloseMenuWithCallback("Profiles", menuItems, nil)
+}
+
+// openDeleteProfileMenu lists all profiles but the current one.
+// The contractor ID has to be typed again to delete a profile.
+func (g *GameStateMainMenu) openDeleteProfileMenu() {
+	userInterface := g.engine.GetUI()
+	current := g.engine.GetCareer().PlayerName
+	menuItems := make([]services.MenuItem, 0)
+	for _, profile := range services.ListProfiles() {
+		name := profile
+		if name == current {
+			continue
+		}
+		menuItems = append(menuItems, services.MenuItem{
+			Label: name,
+			Handler: func() {
+				userInterface.ShowTextInput("Type '"+name+"' to delete the profile: ", "", func(input string) {
+					if input != name {
+						return
+					}
+					if err := services.DeleteProfile(name); err != nil {
+						userInterface.ShowAlert([]string{"Could not delete profile " + name + ":", err.Error()})
+					}
+				}, func() {})
+			},
+		})
+	}
+	if len(menuItems) == 0 {
+		userInterface.ShowAlert([]string{"There are no other profiles to delete."})
+		return
+	}
+	userInterface.OpenFixedWidthAutoCloseMenuWithCallback("Delete profile", menuItems, nil)
+}
+
 func (g *GameStateMainMenu) openReplayMenu() {
 	userInterface := g.engine.GetUI()
 	files := g.engine.GetFiles()
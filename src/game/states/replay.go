@@ -1,17 +1,20 @@
 package states
 
 import (
+	"fmt"
+
 	"github.com/memmaker/terminal-assassin/console"
 	"github.com/memmaker/terminal-assassin/game/core"
 	"github.com/memmaker/terminal-assassin/game/services"
-	"github.com/memmaker/terminal-assassin/rng"
 )
 
 // GameStateReplay loads a replay file, verifies the map hash, seeds the RNG,
 // and runs GameStateGameplay while feeding recorded commands instead of live input.
 type GameStateReplay struct {
-	ReplayPath  string
-	OnComplete  func() // called when the replay finishes; nil = just pop state
+	ReplayPath string
+	OnComplete func() // called when the replay finishes; nil = just pop state
+	// OnError is called instead of showing an alert when the replay can't be played faithfully.
+	OnError     func(err error)
 	engine      services.Engine
 	gameplay    *GameStateGameplay
 	replayInput *replayInputSource
@@ -35,31 +38,33 @@ func (r *GameStateReplay) Init(engine services.Engine) {
 
 	rf, err := services.LoadReplayFile(r.ReplayPath)
 	if err != nil {
-		engine.GetUI().ShowAlert([]string{"Failed to load replay:", err.Error()})
-		engine.GetGame().PopState()
+		r.abort("Failed to load replay:", err)
 		return
 	}
 
 	// Load the map.
 	loadedMap, err := engine.LoadMap(rf.MapPath)
 	if err != nil {
-		engine.GetUI().ShowAlert([]string{"Failed to load map:", err.Error()})
-		engine.GetGame().PopState()
+		r.abort("Failed to load map:", err)
 		return
 	}
 
 	// Verify hash.
 	if loadedMap.MapHash() != rf.MapHash {
+		if r.OnError != nil {
+			r.OnError(fmt.Errorf("map has changed since recording"))
+			return
+		}
 		engine.GetUI().ShowAlert([]string{
 			"Map has changed since recording.",
 			"Replay may not match original.",
 		})
 	}
 
+	variant := loadMissionVariant(engine, loadedMap, rf.EscalationLevel, rf.ContractCode)
+	variant.applyTo(engine, loadedMap)
 	engine.GetGame().InitLoadedMap(loadedMap)
-
-	// Seed the RNG deterministically.
-	rng.Seed(rf.Seed)
+	variant.activate(engine.GetGame())
 
 	// Build the replay input wrapper and hand it to gameplay.
 	r.replayInput = &replayInputSource{
@@ -69,11 +74,22 @@ func (r *GameStateReplay) Init(engine services.Engine) {
 	}
 
 	engine.SetInputOverride(r.replayInput)
-	r.gameplay = &GameStateGameplay{}
+	// Gameplay seeds the RNG with the recorded seed.
+	r.gameplay = &GameStateGameplay{Loadout: &rf.Loadout, Skills: &rf.Skills, CampaignFlags: &rf.CampaignFlags, Seed: rf.Seed}
 	r.gameplay.Init(engine)
 	r.isDirty = true
 }
 
+// abort ends the replay before it started.
+func (r *GameStateReplay) abort(message string, err error) {
+	if r.OnError != nil {
+		r.OnError(err)
+		return
+	}
+	r.engine.GetUI().ShowAlert([]string{message, err.Error()})
+	r.engine.GetGame().PopState()
+}
+
 func (r *GameStateReplay) Update(input services.InputInterface) {
 	if r.gameplay == nil {
 		return
@@ -0,0 +1,90 @@
+package states
+
+import (
+	"fmt"
+
+	"github.com/memmaker/terminal-assassin/game/services"
+)
+
+// openSkillsMenu shows the skill tree. Skills are bought with money or with the skill points of the levels.
+func (g *GameStateCareerViewer) openSkillsMenu() {
+	userInterface := g.engine.GetUI()
+	career := g.engine.GetCareer()
+	menuItems := []services.MenuItem{
+		{
+			DynamicLabel: func() string {
+				return fmt.Sprintf("$%d, %d skill point(s)", career.Money, career.SkillPoints())
+			},
+			Handler: func() {},
+		},
+	}
+	for _, definition := range services.SkillTree {
+		skill := definition
+		menuItems = append(menuItems, services.MenuItem{
+			DynamicLabel: func() string {
+				return skillLabel(career, skill)
+			},
+			Handler: func() {
+				g.openSkillMenu(skill)
+			},
+		})
+	}
+	userInterface.OpenFixedWidthStackedMenu("Skills", menuItems)
+}
+
+func skillLabel(career *services.CareerData, skill *services.SkillDefinition) string {
+	indent := ""
+	if skill.Requires != "" {
+		indent = "  "
+	}
+	if skill.IsUnlocked(career.UnlockedSkills) {
+		return indent + "[x] " + skill.Name
+	}
+	return indent + "[ ] " + skill.Name
+}
+
+// openSkillMenu describes the skill and offers to buy it.
+func (g *GameStateCareerViewer) openSkillMenu(skill *services.SkillDefinition) {
+	userInterface := g.engine.GetUI()
+	career := g.engine.GetCareer()
+	buy := func(buyFunc func(skill *services.SkillDefinition) bool) func() {
+		return func() {
+			if !buyFunc(skill) {
+				return
+			}
+			career.SaveToFile()
+			userInterface.PopModal()
+			userInterface.ShowAlert([]string{skill.Name + " unlocked.", skill.Description})
+		}
+	}
+	canBuy := func() bool {
+		return career.MissingSkillRequirement(skill) == ""
+	}
+	menuItems := []services.MenuItem{
+		{
+			Label: "What does it do?",
+			Handler: func() {
+				lines := []string{skill.Description}
+				if missing := career.MissingSkillRequirement(skill); missing != "" {
+					lines = append(lines, missing)
+				}
+				userInterface.ShowAlert(lines)
+			},
+		},
+		{
+			Label:   fmt.Sprintf("Buy for $%d", skill.Price),
+			Handler: buy(career.BuySkillWithMoney),
+			Condition: func() bool {
+				return canBuy() && career.Money >= skill.Price
+			},
+		},
+		{
+			Label:   fmt.Sprintf("Buy for %d skill point(s)", skill.SkillPoints),
+			Handler: buy(career.BuySkillWithSkillPoints),
+			Condition: func() bool {
+				return canBuy() && career.SkillPoints() >= skill.SkillPoints
+			},
+		},
+	}
+	userInterface.OpenFixedWidthStackedMenu(skill.Name, menuItems)
+}
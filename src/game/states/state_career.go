@@ -36,15 +36,35 @@ func (g *GameStateCareerViewer) UpdateHUD() {
 
 }
 
+// Update is only called when no menu is open, so the player closed the career menu.
 func (g *GameStateCareerViewer) Update(input services.InputInterface) {
-
+	g.engine.GetGame().PushState(&GameStateMainMenu{})
 }
 
 func (g *GameStateCareerViewer) Init(engine services.Engine) {
 	g.engine = engine
+	g.openCareerMenu()
+}
+
+func (g *GameStateCareerViewer) openCareerMenu() {
 	userInterface := g.engine.GetUI()
-	userInterface.ShowPager("Career Data", g.renderCareerData(), func() {
-		g.engine.GetGame().PushState(&GameStateMainMenu{})
+	userInterface.OpenFixedWidthStackedMenu("Career", []services.MenuItem{
+		{
+			Label: "Career Data",
+			Handler: func() {
+				userInterface.ShowPager("Career Data", g.renderCareerData(), nil)
+			},
+		},
+		{
+			Label:   "Skills",
+			Handler: g.openSkillsMenu,
+		},
+		{
+			Label: "Back",
+			Handler: func() {
+				userInterface.PopModal()
+			},
+		},
 	})
 }
 
@@ -75,6 +95,9 @@ func (g *GameStateCareerViewer) renderCareerData() []core.StyledText {
 		lines = append(lines, core.Text("    Location Mastery: "+strconv.FormatFloat(locationCompletion*100, 'f', 2, 64)+"%"))
 		lines = append(lines, core.Text("    Finish Count: "+strconv.Itoa(mapStat.FinishCount)))
 		lines = append(lines, core.Text("    Fastest Time: "+mapStat.FastestDuration.Round(time.Millisecond).String()))
+		if mapStat.BestRating != "" {
+			lines = append(lines, core.Text(fmt.Sprintf("    Best Score: %d (%s)", mapStat.BestScore, mapStat.BestRating)))
+		}
 		if mapStat.UnlockedLocations.Cardinality() > 0 {
 			lines = append(lines, core.Text("    Unlocked Starting Locations:"))
 			unlockedLocations := mapStat.UnlockedLocations.ToSlice()
@@ -0,0 +1,89 @@
+package states
+
+import (
+	"github.com/memmaker/terminal-assassin/game/core"
+	"github.com/memmaker/terminal-assassin/game/services"
+	"github.com/memmaker/terminal-assassin/gridmap"
+)
+
+// missionVariant is what the player chose to play on the map in the briefing.
+// Both fields are nil for the standard mission.
+type missionVariant struct {
+	escalation *services.EscalationLevel
+	contract   *services.Contract
+}
+
+// loadMissionVariant restores a variant from its escalation level and contract code on a freshly loaded map.
+// Contracts are always generated on the uninitialized map, so that replays get the same contract.
+func loadMissionVariant(engine services.Engine, loadedMap *gridmap.GridMap[*core.Actor, *core.Item, services.Object], escalationLevel int, contractCode string) *missionVariant {
+	variant := &missionVariant{}
+	if escalationLevel > 0 {
+		for _, level := range services.LoadEscalation(engine.GetFiles(), loadedMap.MapFileName()) {
+			if level.Level == escalationLevel {
+				variant.escalation = level
+			}
+		}
+	}
+	if contractCode != "" {
+		if seed, err := services.ParseContractCode(contractCode); err == nil {
+			variant.contract = services.GenerateContract(loadedMap, seed)
+		}
+	}
+	return variant
+}
+
+// missionVariantOf returns the escalation level and contract code of the current mission, to record them.
+func missionVariantOf(game services.GameInterface) (int, string) {
+	variant := &missionVariant{escalation: game.GetEscalation(), contract: game.GetContract()}
+	return variant.identify()
+}
+
+// identify returns the escalation level and contract code of the variant, they are 0 and empty for the standard mission.
+func (v *missionVariant) identify() (int, string) {
+	escalationLevel := 0
+	if v.escalation != nil {
+		escalationLevel = v.escalation.Level
+	}
+	contractCode := ""
+	if v.contract != nil {
+		contractCode = v.contract.Code
+	}
+	return escalationLevel, contractCode
+}
+
+// applyTo changes a freshly loaded map, before it is initialized.
+func (v *missionVariant) applyTo(engine services.Engine, loadedMap *gridmap.GridMap[*core.Actor, *core.Item, services.Object]) {
+	if v.escalation != nil {
+		v.escalation.ApplyTo(engine, loadedMap)
+	}
+	if v.contract != nil {
+		v.contract.ApplyTo(loadedMap)
+	}
+}
+
+// activate tells the game which variant is played, after the map was initialized.
+func (v *missionVariant) activate(game services.GameInterface) {
+	game.SetEscalation(v.escalation)
+	game.SetContract(v.contract)
+}
+
+// prepareMission reloads the current map with the changes of the variant applied.
+// The map has to be loaded again, so that the changed actors are initialized with it.
+func (g *GameStateMainMenu) prepareMission(variant *missionVariant) {
+	game := g.engine.GetGame()
+	if variant.escalation == nil && variant.contract == nil {
+		return
+	}
+	mapFileName := game.GetMap().MapFileName()
+	game.ResetModel()
+	loadedMap, err := g.engine.LoadMap(mapFileName)
+	if err != nil {
+		println("Error loading map: " + err.Error())
+		return
+	}
+	escalationLevel, contractCode := variant.identify()
+	loadedVariant := loadMissionVariant(g.engine, loadedMap, escalationLevel, contractCode)
+	loadedVariant.applyTo(g.engine, loadedMap)
+	game.InitLoadedMap(loadedMap)
+	loadedVariant.activate(game)
+}
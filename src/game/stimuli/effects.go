@@ -26,6 +26,34 @@ const (
 	StimulusSmoke           StimulusType = "smoke"
 )
 
+// StimulusTypes lists the stimulus types objects, items and tiles can react to.
+var StimulusTypes = []StimulusType{
+	StimulusPiercingDamage,
+	StimulusBluntDamage,
+	StimulusChokingDamage,
+	StimulusEmetic,
+	StimulusLethal,
+	StimulusSleep,
+	StimulusFire,
+	StimulusExplosionDamage,
+	StimulusWater,
+	StimulusBlood,
+	StimulusBurnable,
+	StimulusHighVoltage,
+	StimulusFrenzy,
+	StimulusSmoke,
+}
+
+// IsKnown is true for the stimulus types of StimulusTypes.
+func (s StimulusType) IsKnown() bool {
+	for _, known := range StimulusTypes {
+		if s == known {
+			return true
+		}
+	}
+	return false
+}
+
 type Stim struct {
 	StimType  StimulusType
 	StimForce int
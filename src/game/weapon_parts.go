@@ -0,0 +1,72 @@
+package game
+
+import (
+	"github.com/memmaker/terminal-assassin/game/core"
+	"github.com/memmaker/terminal-assassin/game/services"
+)
+
+// weaponPartOptions lists what can be fitted to or taken off the item. For a part these
+// are the firearms in the inventory it fits, for a firearm the parts that fit it and
+// the parts that are already fitted.
+func (m *Model) weaponPartOptions(person *core.Actor, item *core.Item) []services.MenuItem {
+	options := make([]services.MenuItem, 0)
+	if item.IsWeaponPart() {
+		for _, w := range person.Inventory.Items {
+			weapon := w
+			if item.Fits(weapon) {
+				options = append(options, services.MenuItem{
+					Label:   "Fit to " + weapon.Name,
+					Handler: func() { m.FitWeaponPart(person, weapon, item) },
+				})
+			}
+		}
+		return options
+	}
+	if !item.IsRangedWeapon() {
+		return options
+	}
+	for _, p := range person.Inventory.Items {
+		part := p
+		if part.Fits(item) {
+			options = append(options, services.MenuItem{
+				Label:   "Fit " + part.Name,
+				Handler: func() { m.FitWeaponPart(person, item, part) },
+			})
+		}
+	}
+	for _, p := range item.Attachments {
+		part := p
+		options = append(options, services.MenuItem{
+			Label:   "Remove " + part.Name,
+			Handler: func() { m.RemoveWeaponPart(person, item, part) },
+		})
+	}
+	return options
+}
+
+// FitWeaponPart moves the part from the inventory onto the weapon.
+// A part that had to make room for it goes back into the inventory.
+func (m *Model) FitWeaponPart(person *core.Actor, weapon *core.Item, part *core.Item) {
+	if !part.Fits(weapon) {
+		m.PrintMessage("The " + part.Name + " doesn't fit the " + weapon.Name + ".")
+		return
+	}
+	person.RemoveItem(part)
+	if replaced := weapon.Fit(part); replaced != nil {
+		replaced.HeldBy = person
+		person.Inventory.AddItem(replaced)
+	}
+	m.PrintMessage("You fit the " + part.Name + " to the " + weapon.Name + ".")
+	m.UpdateHUD()
+}
+
+// RemoveWeaponPart takes the part off the weapon and puts it into the inventory.
+func (m *Model) RemoveWeaponPart(person *core.Actor, weapon *core.Item, part *core.Item) {
+	if !weapon.Unfit(part) {
+		return
+	}
+	part.HeldBy = person
+	person.Inventory.AddItem(part)
+	m.PrintMessage("You remove the " + part.Name + " from the " + weapon.Name + ".")
+	m.UpdateHUD()
+}
@@ -20,19 +20,29 @@ func (c *Camera) CenterOn(targetWorldPosition Point, mapWidth int, mapHeight int
 	c.MoveBy(deltaMovement, mapWidth, mapHeight)
 }
 func (c *Camera) MoveBy(delta Point, mapWidth int, mapHeight int) {
+	c.MoveByWithin(delta, NewRect(0, 0, mapWidth, mapHeight))
+}
+
+// MoveByWithin moves the camera, but keeps the view port inside the bounds, eg. a floor of the map.
+func (c *Camera) MoveByWithin(delta Point, bounds Rect) {
 	deltaMin := c.ViewPort.Min.Add(delta)
 	deltaMax := c.ViewPort.Max.Add(delta)
-	if deltaMin.X < 0 {
-		delta.X = delta.X - deltaMin.X
+	if deltaMin.X < bounds.Min.X {
+		delta.X = delta.X - (deltaMin.X - bounds.Min.X)
 	}
-	if deltaMin.Y < 0 {
-		delta.Y = delta.Y - deltaMin.Y
+	if deltaMin.Y < bounds.Min.Y {
+		delta.Y = delta.Y - (deltaMin.Y - bounds.Min.Y)
 	}
-	if deltaMax.X > mapWidth {
-		delta.X = delta.X - (deltaMax.X - mapWidth)
+	if deltaMax.X > bounds.Max.X {
+		delta.X = delta.X - (deltaMax.X - bounds.Max.X)
 	}
-	if deltaMax.Y > mapHeight {
-		delta.Y = delta.Y - (deltaMax.Y - mapHeight)
+	if deltaMax.Y > bounds.Max.Y {
+		delta.Y = delta.Y - (deltaMax.Y - bounds.Max.Y)
 	}
 	c.ViewPort = c.ViewPort.Add(delta)
 }
+
+// CenterWithin centers the camera on the target, but keeps the view port inside the bounds.
+func (c *Camera) CenterWithin(targetWorldPosition Point, bounds Rect) {
+	c.MoveByWithin(targetWorldPosition.Sub(c.ViewPort.Mid()), bounds)
+}
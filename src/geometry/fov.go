@@ -554,6 +554,20 @@ func (fov *FOV) SSCVisionMap(src Point, maxDepth int, passable func(p Point) boo
 	return fov.Visibles
 }
 
+// Conceal removes the positions for which hidden returns true from the result
+// of the last SSCVisionMap call, without casting shadows behind them.
+func (fov *FOV) Conceal(hidden func(p Point) bool) {
+	visibles := fov.Visibles[:0]
+	for _, p := range fov.Visibles {
+		if hidden(p) {
+			fov.ShadowCasting[fov.idx(p)] = false
+			continue
+		}
+		visibles = append(visibles, p)
+	}
+	fov.Visibles = visibles
+}
+
 func (fov *FOV) sscVisionMap(src Point, maxDepth int, diags bool) {
 	idx := fov.idx(src)
 	if !fov.ShadowCasting[idx] {
@@ -0,0 +1,52 @@
+package gridmap
+
+import (
+	"strings"
+
+	"github.com/memmaker/terminal-assassin/geometry"
+)
+
+// FallTargetPrefix marks the named locations below glass floors on maps without floors.
+// Everything on a breaking glass floor falls to the nearest of them.
+const FallTargetPrefix = "below"
+
+// DamageTileAt wears down a breakable tile. It returns true once the accumulated
+// damage reaches the health of the tile, the caller replaces the broken tile.
+func (m *GridMap[ActorType, ItemType, ObjectType]) DamageTileAt(p geometry.Point, damage int) bool {
+	if !m.Contains(p) || damage <= 0 || !m.GetCell(p).TileType.IsBreakable() {
+		return false
+	}
+	if m.tileDamage == nil {
+		m.tileDamage = make(map[geometry.Point]int)
+	}
+	m.tileDamage[p] += damage
+	if m.tileDamage[p] < m.GetCell(p).TileType.Health {
+		return false
+	}
+	delete(m.tileDamage, p)
+	return true
+}
+
+// FallTargetFor returns the position directly below the glass floor at p.
+// Without a floor below it is the nearest named location marked as below.
+func (m *GridMap[ActorType, ItemType, ObjectType]) FallTargetFor(p geometry.Point) (geometry.Point, bool) {
+	if floor := m.FloorAt(p); floor != nil {
+		if below, ok := m.PositionOnFloor(p, floor.Level-1); ok {
+			return below, true
+		}
+	}
+	found := false
+	var target geometry.Point
+	var targetName string
+	for name, location := range m.NamedLocations {
+		if !strings.HasPrefix(name, FallTargetPrefix) {
+			continue
+		}
+		dist, targetDist := geometry.DistanceSquared(p, location), geometry.DistanceSquared(p, target)
+		if !found || dist < targetDist || (dist == targetDist && name < targetName) {
+			target, targetName = location, name
+			found = true
+		}
+	}
+	return target, found
+}
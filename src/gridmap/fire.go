@@ -0,0 +1,149 @@
+package gridmap
+
+import (
+	"github.com/memmaker/terminal-assassin/game/stimuli"
+	"github.com/memmaker/terminal-assassin/geometry"
+)
+
+// FireStepSeconds is the game time between two steps of the fire simulation.
+const FireStepSeconds = 0.25
+
+const (
+	fireMaxIntensity  = 100
+	fireIgnitionForce = 20
+	fireGrowth        = 10
+	fireDecay         = 15
+	fireIgnitionHeat  = 30
+	fireHeatLoss      = 2
+	// fireSmokeSeconds is how long the smoke of a fire lingers
+	fireSmokeSeconds = 8.0
+)
+
+// fireState is the bookkeeping of the fire simulation that is not visible as stimuli.
+type fireState struct {
+	// fuel left on burning tiles, tiles that are not in here start with FuelAt
+	fuel map[geometry.Point]int
+	// heat accumulated by tiles next to a fire
+	heat map[geometry.Point]int
+}
+
+func (f *fireState) init() {
+	if f.fuel == nil {
+		f.fuel = make(map[geometry.Point]int)
+		f.heat = make(map[geometry.Point]int)
+	}
+}
+
+// FireUpdate reports what changed during one step of the fire simulation.
+type FireUpdate struct {
+	Ignited      []geometry.Point
+	Burning      []geometry.Point
+	Extinguished []geometry.Point
+	// BurntOut lists the tiles whose fuel has been used up in this step.
+	BurntOut []geometry.Point
+}
+
+// FuelAt is the fuel of the tile, spilled burnables and anything else that fuelOfThings adds.
+func (m *GridMap[ActorType, ItemType, ObjectType]) FuelAt(p geometry.Point, fuelOfThings func(geometry.Point) int) int {
+	if !m.Contains(p) {
+		return 0
+	}
+	fuel := m.GetCell(p).TileType.Fuel + m.ForceOfStimulusOnTile(p, stimuli.StimulusBurnable)/2
+	if fuelOfThings != nil {
+		fuel += fuelOfThings(p)
+	}
+	return fuel
+}
+
+// Ignite sets the tile on fire with the given intensity, unless it is wet.
+func (m *GridMap[ActorType, ItemType, ObjectType]) Ignite(p geometry.Point, intensity int) bool {
+	if !m.Contains(p) || m.IsStimulusOnTile(p, stimuli.StimulusWater) || m.IsStimulusOnTile(p, stimuli.StimulusFire) {
+		return false
+	}
+	m.fire.init()
+	fireStim := stimuli.Stim{StimType: stimuli.StimulusFire, StimForce: max(intensity, fireIgnitionForce)}
+	m.AddStimulusToTile(p, fireStim)
+	m.onStimAdded(p, fireStim)
+	delete(m.fire.heat, p)
+	return true
+}
+
+// Extinguish removes the fire from the tile. Its remaining fuel is kept for a later fire.
+func (m *GridMap[ActorType, ItemType, ObjectType]) Extinguish(p geometry.Point) {
+	m.RemoveStimulusFromTile(p, stimuli.StimulusFire)
+	delete(m.fire.heat, p)
+}
+
+// UpdateFire advances the fire simulation by one step.
+// Fires grow while they have fuel and die down afterwards, water puts them out.
+// Burning tiles heat up their neighbours, which catch fire once they are hot enough.
+// Every fire emits smoke, which is spread by the gas simulation.
+func (m *GridMap[ActorType, ItemType, ObjectType]) UpdateFire(fuelOfThings func(geometry.Point) int) FireUpdate {
+	m.fire.init()
+	update := FireUpdate{}
+	burning := make([]geometry.Point, 0)
+	for i, cell := range m.Cells {
+		if cell.HasStim(stimuli.StimulusFire) {
+			burning = append(burning, geometry.Point{X: i % m.MapWidth, Y: i / m.MapWidth})
+		}
+	}
+
+	for _, p := range burning {
+		if m.IsStimulusOnTile(p, stimuli.StimulusWater) {
+			m.Extinguish(p)
+			update.Extinguished = append(update.Extinguished, p)
+			continue
+		}
+		fuel, known := m.fire.fuel[p]
+		if !known {
+			fuel = m.FuelAt(p, fuelOfThings)
+		}
+		intensity := m.ForceOfStimulusOnTile(p, stimuli.StimulusFire)
+		if fuel > 0 {
+			intensity = min(fireMaxIntensity, intensity+fireGrowth)
+			fuel -= 1 + intensity/25
+			if fuel <= 0 {
+				fuel = 0
+				update.BurntOut = append(update.BurntOut, p)
+			}
+			m.fire.fuel[p] = fuel
+		} else {
+			intensity -= fireDecay
+		}
+		if intensity <= 0 {
+			m.Extinguish(p)
+			delete(m.fire.fuel, p)
+			update.Extinguished = append(update.Extinguished, p)
+			continue
+		}
+		m.Cells[p.Y*m.MapWidth+p.X].Stimuli[stimuli.StimulusFire] = stimuli.Stim{StimType: stimuli.StimulusFire, StimForce: intensity}
+		update.Burning = append(update.Burning, p)
+		m.AddGas(p, stimuli.Stim{StimType: stimuli.StimulusSmoke, StimForce: 100}, intensity/5, fireSmokeSeconds)
+
+		for _, n := range m.NeighborsCardinal(p, m.Contains) {
+			if m.IsStimulusOnTile(n, stimuli.StimulusFire) || m.IsStimulusOnTile(n, stimuli.StimulusWater) {
+				continue
+			}
+			if m.FuelAt(n, fuelOfThings) <= 0 {
+				continue
+			}
+			m.fire.heat[n] += intensity / 10
+		}
+	}
+
+	for p, heat := range m.fire.heat {
+		if heat >= fireIgnitionHeat {
+			if m.Ignite(p, fireIgnitionForce) {
+				update.Ignited = append(update.Ignited, p)
+			}
+			continue
+		}
+		if heat <= fireHeatLoss {
+			delete(m.fire.heat, p)
+			continue
+		}
+		m.fire.heat[p] = heat - fireHeatLoss
+	}
+
+	return update
+}
@@ -0,0 +1,258 @@
+package gridmap
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/memmaker/terminal-assassin/geometry"
+	rec_files "github.com/memmaker/terminal-assassin/rec-files"
+)
+
+// floorSoundDamping divides the range of a sound that reaches another floor,
+// either through a ceiling or up and down stairs and elevators.
+const floorSoundDamping = 3
+
+// Floor is one storey of a multi-floor map. All floors are laid out side by
+// side in the same grid, so every floor has its own part of the tilemap.
+// The same offset inside the bounds of two floors are positions above each other.
+// Floors should be enclosed by walls, vision and movement don't know about floors.
+type Floor struct {
+	Name   string
+	Level  int
+	Bounds geometry.Rect
+}
+
+func (f *Floor) ToRecord() rec_files.Record {
+	return rec_files.Record{
+		{Name: "Name", Value: f.Name},
+		{Name: "Level", Value: strconv.Itoa(f.Level)},
+		{Name: "Min", Value: f.Bounds.Min.String()},
+		{Name: "Max", Value: f.Bounds.Max.String()},
+	}
+}
+
+func NewFloorFromRecord(record rec_files.Record) *Floor {
+	floor := &Floor{}
+	for _, field := range record {
+		switch field.Name {
+		case "Name":
+			floor.Name = strings.TrimSpace(field.Value)
+		case "Level":
+			floor.Level, _ = strconv.Atoi(strings.TrimSpace(field.Value))
+		case "Min":
+			floor.Bounds.Min, _ = geometry.NewPointFromString(strings.TrimSpace(field.Value))
+		case "Max":
+			floor.Bounds.Max, _ = geometry.NewPointFromString(strings.TrimSpace(field.Value))
+		}
+	}
+	return floor
+}
+
+func (f *Floor) String() string {
+	return fmt.Sprintf("%s (level %d)", f.Name, f.Level)
+}
+
+func (m *GridMap[ActorType, ItemType, ObjectType]) HasFloors() bool {
+	return len(m.Floors) > 0
+}
+
+// AddFloor adds the floor and keeps the floors sorted from the lowest to the highest level.
+func (m *GridMap[ActorType, ItemType, ObjectType]) AddFloor(floor *Floor) {
+	m.Floors = append(m.Floors, floor)
+	sort.SliceStable(m.Floors, func(i, j int) bool { return m.Floors[i].Level < m.Floors[j].Level })
+}
+
+// FloorAt returns the floor containing p or nil if p isn't part of a floor.
+func (m *GridMap[ActorType, ItemType, ObjectType]) FloorAt(p geometry.Point) *Floor {
+	for _, floor := range m.Floors {
+		if floor.Bounds.Contains(p) {
+			return floor
+		}
+	}
+	return nil
+}
+
+func (m *GridMap[ActorType, ItemType, ObjectType]) FloorByLevel(level int) *Floor {
+	for _, floor := range m.Floors {
+		if floor.Level == level {
+			return floor
+		}
+	}
+	return nil
+}
+
+// PositionOnFloor returns the position directly above or below p on the floor with the given level.
+func (m *GridMap[ActorType, ItemType, ObjectType]) PositionOnFloor(p geometry.Point, level int) (geometry.Point, bool) {
+	from, to := m.FloorAt(p), m.FloorByLevel(level)
+	if from == nil || to == nil {
+		return p, false
+	}
+	q := to.Bounds.Min.Add(p.Sub(from.Bounds.Min))
+	return q, to.Bounds.Contains(q)
+}
+
+// CameraBoundsAt returns the part of the map the camera should stay in while looking at p.
+func (m *GridMap[ActorType, ItemType, ObjectType]) CameraBoundsAt(p geometry.Point) geometry.Rect {
+	if floor := m.FloorAt(p); floor != nil {
+		return floor.Bounds
+	}
+	return geometry.NewRect(0, 0, m.MapWidth, m.MapHeight)
+}
+
+// FloorLinksAt returns the positions on other floors that can be reached from p.
+// Stairs lead to stairs at the same position one floor up or down,
+// elevators lead to the elevators at the same position on every other floor.
+func (m *GridMap[ActorType, ItemType, ObjectType]) FloorLinksAt(p geometry.Point) []geometry.Point {
+	floor := m.FloorAt(p)
+	if floor == nil {
+		return nil
+	}
+	special := m.GetCell(p).TileType.Special
+	if special != SpecialTileStairs && special != SpecialTileElevator {
+		return nil
+	}
+	links := make([]geometry.Point, 0, 2)
+	for _, other := range m.Floors {
+		if other == floor || (special == SpecialTileStairs && other.Level != floor.Level-1 && other.Level != floor.Level+1) {
+			continue
+		}
+		if q, ok := m.PositionOnFloor(p, other.Level); ok && m.IsTileWithSpecialAt(q, special) {
+			links = append(links, q)
+		}
+	}
+	return links
+}
+
+// floorExits lists the stairs and elevators of the floor that lead to the other level.
+func (m *GridMap[ActorType, ItemType, ObjectType]) floorExits(floor *Floor, toLevel int) [][2]geometry.Point {
+	exits := make([][2]geometry.Point, 0)
+	floor.Bounds.Iter(func(p geometry.Point) {
+		for _, q := range m.FloorLinksAt(p) {
+			if m.FloorAt(q).Level == toLevel {
+				exits = append(exits, [2]geometry.Point{p, q})
+			}
+		}
+	})
+	return exits
+}
+
+// floorRoute returns the levels to pass through on the way from one floor to another,
+// including both, or nil if they are not connected.
+func (m *GridMap[ActorType, ItemType, ObjectType]) floorRoute(fromLevel, toLevel int) []int {
+	cameFrom := map[int]int{fromLevel: fromLevel}
+	queue := []int{fromLevel}
+	for len(queue) > 0 {
+		level := queue[0]
+		queue = queue[1:]
+		if level == toLevel {
+			route := []int{level}
+			for level != fromLevel {
+				level = cameFrom[level]
+				route = append([]int{level}, route...)
+			}
+			return route
+		}
+		for _, next := range m.Floors {
+			if _, visited := cameFrom[next.Level]; visited || len(m.floorExits(m.FloorByLevel(level), next.Level)) == 0 {
+				continue
+			}
+			cameFrom[next.Level] = level
+			queue = append(queue, next.Level)
+		}
+	}
+	return nil
+}
+
+// crossFloorPath joins JPS paths on each floor of the route. The step from a
+// staircase or elevator to the linked one on the next floor is a single move.
+func (m *GridMap[ActorType, ItemType, ObjectType]) crossFloorPath(start, end geometry.Point, isWalkable func(geometry.Point) bool) []geometry.Point {
+	route := m.floorRoute(m.FloorAt(start).Level, m.FloorAt(end).Level)
+	if route == nil {
+		return nil
+	}
+	path := []geometry.Point{start}
+	current := start
+	for i := 1; i < len(route); i++ {
+		exits := m.floorExits(m.FloorByLevel(route[i-1]), route[i])
+		sort.SliceStable(exits, func(a, b int) bool {
+			return geometry.DistanceManhattan(current, exits[a][0]) < geometry.DistanceManhattan(current, exits[b][0])
+		})
+		var leg []geometry.Point
+		var entry geometry.Point
+		for _, exit := range exits {
+			if !isWalkable(exit[1]) {
+				continue
+			}
+			if leg = m.pathfinder.JPSPath(nil, current, exit[0], isWalkable, false); leg != nil {
+				entry = exit[1]
+				break
+			}
+		}
+		if leg == nil {
+			return nil
+		}
+		path = append(path, leg[1:]...)
+		path = append(path, entry)
+		current = entry
+	}
+	leg := m.pathfinder.JPSPath(nil, current, end, isWalkable, false)
+	if leg == nil {
+		return nil
+	}
+	return append(path, leg[1:]...)
+}
+
+// SoundPropagationFrom is like WavePropagationFrom, but the sound also reaches
+// the floors above and below. It passes through the ceiling and along stairs and
+// elevators, both with a reduced range.
+func (m *GridMap[ActorType, ItemType, ObjectType]) SoundPropagationFrom(pos geometry.Point, size int) map[int][]geometry.Point {
+	soundMap := m.WavePropagationFrom(pos, size, 0)
+	floor := m.FloorAt(pos)
+	if floor == nil {
+		return soundMap
+	}
+	heard := make(map[geometry.Point]bool)
+	type echo struct {
+		from geometry.Point
+		cost int
+		size int
+	}
+	echoes := make([]echo, 0)
+	for cost, points := range soundMap {
+		for _, p := range points {
+			heard[p] = true
+			for _, q := range m.FloorLinksAt(p) {
+				echoes = append(echoes, echo{from: q, cost: cost + 1, size: (size - cost) / floorSoundDamping})
+			}
+		}
+	}
+	for _, level := range []int{floor.Level - 1, floor.Level + 1} {
+		if q, ok := m.PositionOnFloor(pos, level); ok {
+			echoes = append(echoes, echo{from: q, cost: 1, size: size / floorSoundDamping})
+		}
+	}
+	sort.SliceStable(echoes, func(i, j int) bool {
+		if echoes[i].cost != echoes[j].cost {
+			return echoes[i].cost < echoes[j].cost
+		}
+		return echoes[i].from.Y*m.MapWidth+echoes[i].from.X < echoes[j].from.Y*m.MapWidth+echoes[j].from.X
+	})
+	for _, e := range echoes {
+		if e.size <= 0 {
+			continue
+		}
+		echoFloor := m.FloorAt(e.from)
+		for cost, points := range m.WavePropagationFrom(e.from, e.size, 0) {
+			for _, p := range points {
+				if heard[p] || !echoFloor.Bounds.Contains(p) {
+					continue
+				}
+				heard[p] = true
+				soundMap[e.cost+cost] = append(soundMap[e.cost+cost], p)
+			}
+		}
+	}
+	return soundMap
+}
@@ -0,0 +1,218 @@
+package gridmap
+
+import (
+	"sort"
+
+	"github.com/memmaker/terminal-assassin/game/stimuli"
+	"github.com/memmaker/terminal-assassin/geometry"
+)
+
+// GasStepSeconds is the game time between two steps of the gas simulation.
+const GasStepSeconds = 0.5
+
+const (
+	gasMaxConcentration = 100
+	// gasFaintConcentration is the concentration below which a gas has no effect and is invisible
+	gasFaintConcentration = 10
+	// gasDiffusion divides the difference in concentration that spreads to a neighbour per step
+	gasDiffusion = 6
+	// smokeOpaqueConcentration is the concentration at which smoke blocks sight completely
+	smokeOpaqueConcentration = 50
+)
+
+// GasBlocker is implemented by objects like doors and windows that keep gas out while they are closed.
+type GasBlocker interface {
+	BlocksGas() bool
+}
+
+// gasCloud is the concentration field of one kind of gas.
+type gasCloud struct {
+	// stim is the stimulus of the gas at full concentration
+	stim stimuli.Stimulus
+	// dissipation is the concentration lost per step on every tile
+	dissipation   int
+	concentration map[geometry.Point]int
+}
+
+type gasState struct {
+	clouds map[stimuli.StimulusType]*gasCloud
+}
+
+func (g *gasState) init() {
+	if g.clouds == nil {
+		g.clouds = make(map[stimuli.StimulusType]*gasCloud)
+	}
+}
+
+// GasUpdate reports what changed during one step of the gas simulation.
+type GasUpdate struct {
+	// Reached maps the tiles that the gas has spread to in this step to its stimuli.
+	Reached map[geometry.Point][]stimuli.Stimulus
+}
+
+// IsGasPermeable is true for walkable tiles that are not closed off by a door or window.
+func (m *GridMap[ActorType, ItemType, ObjectType]) IsGasPermeable(p geometry.Point) bool {
+	if !m.IsTileWalkable(p) {
+		return false
+	}
+	if objectAt, ok := m.TryGetObjectAt(p); ok {
+		if blocker, isBlocker := any(objectAt).(GasBlocker); isBlocker && blocker.BlocksGas() {
+			return false
+		}
+	}
+	return true
+}
+
+// GasConcentrationAt returns the concentration of the gas at p in percent.
+func (m *GridMap[ActorType, ItemType, ObjectType]) GasConcentrationAt(p geometry.Point, gasType stimuli.StimulusType) int {
+	if cloud, ok := m.gas.clouds[gasType]; ok {
+		return cloud.concentration[p]
+	}
+	return 0
+}
+
+// IsSmokeOpaqueAt is true where dense smoke blocks sight. Smoke that was put on the tile as a
+// stimulus, eg. by the editor or a script, is not part of the gas simulation and always blocks it.
+func (m *GridMap[ActorType, ItemType, ObjectType]) IsSmokeOpaqueAt(p geometry.Point) bool {
+	concentration := m.GasConcentrationAt(p, stimuli.StimulusSmoke)
+	if concentration == 0 {
+		return m.IsStimulusOnTile(p, stimuli.StimulusSmoke)
+	}
+	return concentration >= smokeOpaqueConcentration
+}
+
+// AddGas raises the concentration of the gas at p. The gas has the force of stim
+// at full concentration and disappears within about durationSecs.
+func (m *GridMap[ActorType, ItemType, ObjectType]) AddGas(p geometry.Point, stim stimuli.Stimulus, amount int, durationSecs float64) {
+	if !m.Contains(p) || amount <= 0 {
+		return
+	}
+	m.gas.init()
+	dissipation := max(1, int(gasMaxConcentration*GasStepSeconds/max(durationSecs, GasStepSeconds)))
+	cloud, exists := m.gas.clouds[stim.Type()]
+	if !exists {
+		cloud = &gasCloud{stim: stim, dissipation: dissipation, concentration: make(map[geometry.Point]int)}
+		m.gas.clouds[stim.Type()] = cloud
+	}
+	if stim.Force() > cloud.stim.Force() {
+		cloud.stim = stim
+	}
+	cloud.dissipation = min(cloud.dissipation, dissipation)
+	cloud.concentration[p] = min(gasMaxConcentration, cloud.concentration[p]+amount)
+	m.syncGasStimulus(p, cloud)
+}
+
+// ReleaseGas fills the permeable tiles within radius of p with the gas and returns them.
+func (m *GridMap[ActorType, ItemType, ObjectType]) ReleaseGas(p geometry.Point, stim stimuli.Stimulus, radius int, durationSecs float64) []geometry.Point {
+	filled := []geometry.Point{p}
+	distance := map[geometry.Point]int{p: 0}
+	for i := 0; i < len(filled); i++ {
+		current := filled[i]
+		if distance[current] >= radius {
+			continue
+		}
+		for _, n := range m.NeighborsCardinal(current, m.IsGasPermeable) {
+			if _, seen := distance[n]; seen {
+				continue
+			}
+			distance[n] = distance[current] + 1
+			filled = append(filled, n)
+		}
+	}
+	for _, q := range filled {
+		m.AddGas(q, stim, gasMaxConcentration, durationSecs)
+	}
+	return filled
+}
+
+// syncGasStimulus keeps the stimulus on the tile in line with the concentration.
+// Its force scales with the concentration, faint gas leaves no stimulus at all.
+func (m *GridMap[ActorType, ItemType, ObjectType]) syncGasStimulus(p geometry.Point, cloud *gasCloud) {
+	gasType := cloud.stim.Type()
+	concentration := cloud.concentration[p]
+	if concentration <= 0 {
+		delete(cloud.concentration, p)
+	}
+	if concentration < gasFaintConcentration {
+		if m.IsStimulusOnTile(p, gasType) {
+			m.RemoveStimulusFromTile(p, gasType)
+		}
+		return
+	}
+	force := max(1, cloud.stim.Force()*concentration/gasMaxConcentration)
+	index := p.Y*m.MapWidth + p.X
+	if m.Cells[index].Stimuli == nil {
+		m.Cells[index].Stimuli = make(map[stimuli.StimulusType]stimuli.Stimulus)
+	}
+	m.Cells[index].Stimuli[gasType] = cloud.stim.WithForce(force)
+}
+
+// UpdateGas advances the gas simulation by one step. Every gas spreads to
+// permeable neighbours with a lower concentration and dissipates over time.
+func (m *GridMap[ActorType, ItemType, ObjectType]) UpdateGas() GasUpdate {
+	m.gas.init()
+	update := GasUpdate{Reached: make(map[geometry.Point][]stimuli.Stimulus)}
+	gasTypes := make([]stimuli.StimulusType, 0, len(m.gas.clouds))
+	for gasType := range m.gas.clouds {
+		gasTypes = append(gasTypes, gasType)
+	}
+	sort.Slice(gasTypes, func(i, j int) bool { return gasTypes[i] < gasTypes[j] })
+
+	for _, gasType := range gasTypes {
+		cloud := m.gas.clouds[gasType]
+		tiles := make([]geometry.Point, 0, len(cloud.concentration))
+		for p := range cloud.concentration {
+			if m.Contains(p) {
+				tiles = append(tiles, p)
+			}
+		}
+		sort.Slice(tiles, func(i, j int) bool {
+			return tiles[i].Y*m.MapWidth+tiles[i].X < tiles[j].Y*m.MapWidth+tiles[j].X
+		})
+		next := make(map[geometry.Point]int, len(tiles))
+		for _, p := range tiles {
+			next[p] += cloud.concentration[p] - cloud.dissipation
+			if !m.IsGasPermeable(p) {
+				// a door was closed on the gas, it stays where it is
+				continue
+			}
+			for _, n := range m.NeighborsCardinal(p, m.IsGasPermeable) {
+				if share := (cloud.concentration[p] - cloud.concentration[n]) / gasDiffusion; share > 0 {
+					next[n] += share
+					next[p] -= share
+				}
+			}
+		}
+		for p := range cloud.concentration {
+			if _, touched := next[p]; !touched {
+				next[p] = 0
+			}
+		}
+		for p, concentration := range next {
+			wasPresent := m.IsStimulusOnTile(p, gasType)
+			cloud.concentration[p] = min(gasMaxConcentration, concentration)
+			m.syncGasStimulus(p, cloud)
+			if !wasPresent && m.IsStimulusOnTile(p, gasType) {
+				update.Reached[p] = append(update.Reached[p], m.GetStimAt(p, gasType))
+			}
+		}
+		if len(cloud.concentration) == 0 {
+			delete(m.gas.clouds, gasType)
+		}
+	}
+	return update
+}
+
+// IsObscuredBySmoke is true if smoke keeps a person at source from seeing p.
+// Dense smoke blocks sight completely, thinner smoke shortens the vision range.
+func (m *GridMap[ActorType, ItemType, ObjectType]) IsObscuredBySmoke(source, p geometry.Point, visionRange int) bool {
+	concentration := m.GasConcentrationAt(p, stimuli.StimulusSmoke)
+	if concentration < gasFaintConcentration {
+		return false
+	}
+	if concentration >= smokeOpaqueConcentration {
+		return true
+	}
+	reducedRange := visionRange * (gasMaxConcentration - concentration) / gasMaxConcentration
+	return geometry.DistanceSquared(source, p) > reducedRange*reducedRange
+}
@@ -43,7 +43,7 @@ type MapActor interface {
 	MapObject
 	FoV() *geometry.FOV
 	FoVMode() FoVMode
-	FoVSource() geometry.Point
+	VisionSource() geometry.Point
 	VisionRange() int
 	GetTeam() string
 }
@@ -298,6 +298,16 @@ type GridMap[ActorType interface {
 
 	NamedLocations   map[string]geometry.Point
 	AmbienceSoundCue string
+	// Floors are empty for single floor maps
+	Floors []*Floor
+
+	fire   fireState
+	liquid liquidState
+	gas    gasState
+	// dynamic lights that are switched off, e.g. because their circuit has no power
+	switchedOffLights map[geometry.Point]*LightSource
+	// damage taken by breakable tiles that are still intact
+	tileDamage map[geometry.Point]int
 }
 
 func (m *GridMap[ActorType, ItemType, ObjectType]) AddZone(zone *ZoneInfo) {
@@ -415,6 +425,16 @@ func (m *GridMap[ActorType, ItemType, ObjectType]) SetDownedActorToActive(a Acto
 	}
 	m.Cells[pos.Y*m.MapWidth+pos.X] = m.Cells[pos.Y*m.MapWidth+pos.X].WithActor(a)
 }
+
+// RemoveDownedActorFromMap takes a downed actor off its cell and marks it as removed.
+func (m *GridMap[ActorType, ItemType, ObjectType]) RemoveDownedActorFromMap(person ActorType) {
+	pos := person.Pos()
+	if m.IsDownedActorAt(pos) && m.DownedActorAt(pos) == person {
+		m.Cells[pos.Y*m.MapWidth+pos.X].DownedActor = nil
+	}
+	m.SetActorToRemoved(person)
+}
+
 func (m *GridMap[ActorType, ItemType, ObjectType]) SetActorToRemoved(person ActorType) {
 	m.RemoveActor(person)
 	m.RemoveDownedActor(person)
@@ -589,11 +609,13 @@ func (m *GridMap[ActorType, ItemType, ObjectType]) AddStimulusToTile(p geometry.
 	}
 	if cell.Stimuli[s.Type()] == nil {
 		m.Cells[m.MapWidth*p.Y+p.X].Stimuli[s.Type()] = s
+		m.onLiquidAdded(p, s)
 		return
 	}
 	currentStim := cell.Stimuli[s.Type()]
 	m.Cells[m.MapWidth*p.Y+p.X].Stimuli[s.Type()] = currentStim.WithForce(currentStim.Force() + s.Force())
 	m.onStimAdded(p, s)
+	m.onLiquidAdded(p, s)
 }
 func NewEmptyMap[ActorType interface {
 	comparable
@@ -696,7 +718,7 @@ func (m *GridMap[ActorType, ItemType, ObjectType]) IsTransparent(p geometry.Poin
 		return false
 	}
 
-	if m.IsStimulusOnTile(p, stimuli.StimulusSmoke) {
+	if m.IsSmokeOpaqueAt(p) {
 		return false
 	}
 
@@ -791,6 +813,9 @@ func (m *GridMap[ActorType, ItemType, ObjectType]) GetJPSPath(start geometry.Poi
 	if !isWalkable(end) {
 		end = m.getNearestFreeNeighbor(start, end, isWalkable)
 	}
+	if startFloor, endFloor := m.FloorAt(start), m.FloorAt(end); startFloor != nil && endFloor != nil && startFloor != endFloor {
+		return m.crossFloorPath(start, end, isWalkable)
+	}
 	//println(fmt.Sprintf("JPS from %v to %v", start, end))
 	buffer = m.pathfinder.JPSPath(buffer, start, end, isWalkable, false)
 	return buffer
@@ -863,12 +888,19 @@ func (m *GridMap[ActorType, ItemType, ObjectType]) UpdateFieldOfView(person Acto
 	visionRangeSquared := visionRange * visionRange
 
 	var fovRange = geometry.NewRect(-visionRange, -visionRange, visionRange+1, visionRange+1)
-	person.FoV().SetRange(fovRange.Add(person.FoVSource()).Intersect(geometry.NewRect(0, 0, m.MapWidth, m.MapHeight)))
+	visionSource := person.VisionSource()
+	person.FoV().SetRange(fovRange.Add(visionSource).Intersect(geometry.NewRect(0, 0, m.MapWidth, m.MapHeight)))
 
-	visionMap := person.FoV().SSCVisionMap(person.FoVSource(), visionRange, func(p geometry.Point) bool {
-		return m.IsTransparent(p) && geometry.DistanceSquared(p, person.FoVSource()) <= visionRangeSquared
+	visionMap := person.FoV().SSCVisionMap(visionSource, visionRange, func(p geometry.Point) bool {
+		return m.IsTransparent(p) && geometry.DistanceSquared(p, visionSource) <= visionRangeSquared &&
+			!m.IsObscuredBySmoke(visionSource, p, visionRange)
 	}, false)
 	if person != m.Player {
+		// NPCs can't make out anyone standing in dense smoke, unless they are right next to them
+		person.FoV().Conceal(func(p geometry.Point) bool {
+			return geometry.DistanceChebyshev(p, visionSource) > 1 &&
+				m.IsSmokeOpaqueAt(p)
+		})
 		return
 	}
 	for _, p := range visionMap {
@@ -1088,6 +1120,7 @@ func (m *GridMap[ActorType, ItemType, ObjectType]) onStimRemoved(p geometry.Poin
 	if s == stimuli.StimulusFire {
 		m.RemoveDynamicLightAt(p)
 	}
+	m.onLiquidRemoved(p, s)
 }
 
 func (m *GridMap[ActorType, ItemType, ObjectType]) RemoveDynamicLightAt(p geometry.Point) {
@@ -1192,6 +1225,8 @@ func (m *GridMap[ActorType, ItemType, ObjectType]) Resize(width int, height int,
 	m.ZoneMap = newZoneMap
 	m.MapWidth = width
 	m.MapHeight = height
+	m.pathfinder = geometry.NewPathRange(geometry.NewRect(0, 0, width, height))
+	m.lightfov = geometry.NewFOV(geometry.NewRect(0, 0, width, height))
 
 	m.ApplyAmbientLight()
 	m.UpdateBakedLights()
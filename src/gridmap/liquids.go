@@ -0,0 +1,171 @@
+package gridmap
+
+import (
+	"sort"
+
+	"github.com/memmaker/terminal-assassin/game/stimuli"
+	"github.com/memmaker/terminal-assassin/geometry"
+)
+
+// LiquidStepSeconds is the game time between two steps of the liquid simulation.
+const LiquidStepSeconds = 0.5
+
+const (
+	// liquidFilm is the volume that sticks to a tile and doesn't flow any further
+	liquidFilm = 10
+	// bloodTrailSearchRadius is how far apart two drops of a blood trail can be
+	bloodTrailSearchRadius = 5
+)
+
+// Liquids are the stimuli that flow. Their force is the volume on a tile.
+var Liquids = []stimuli.StimulusType{stimuli.StimulusWater, stimuli.StimulusBurnable, stimuli.StimulusBlood}
+
+func IsLiquid(stimType stimuli.StimulusType) bool {
+	for _, liquid := range Liquids {
+		if liquid == stimType {
+			return true
+		}
+	}
+	return false
+}
+
+// liquidState is the bookkeeping of the liquid simulation that is not visible as stimuli.
+type liquidState struct {
+	// tiles whose liquid has not settled yet, only these are simulated
+	flowing map[geometry.Point]bool
+	// order in which the drops of blood trails were left behind
+	bloodDrops   map[geometry.Point]int
+	dropsCounter int
+}
+
+func (l *liquidState) init() {
+	if l.flowing == nil {
+		l.flowing = make(map[geometry.Point]bool)
+		l.bloodDrops = make(map[geometry.Point]int)
+	}
+}
+
+// LiquidUpdate reports what changed during one step of the liquid simulation.
+type LiquidUpdate struct {
+	// Wetted maps the tiles that were dry before this step to the liquid that reached them.
+	Wetted map[geometry.Point]stimuli.StimulusType
+}
+
+func (m *GridMap[ActorType, ItemType, ObjectType]) onLiquidAdded(p geometry.Point, s stimuli.Stimulus) {
+	if !IsLiquid(s.Type()) {
+		return
+	}
+	m.liquid.init()
+	m.liquid.flowing[p] = true
+}
+
+func (m *GridMap[ActorType, ItemType, ObjectType]) onLiquidRemoved(p geometry.Point, s stimuli.StimulusType) {
+	if s == stimuli.StimulusBlood && m.liquid.bloodDrops != nil {
+		delete(m.liquid.bloodDrops, p)
+	}
+}
+
+// AddBloodDrop leaves a drop of blood that belongs to a trail, see NextBloodDrop.
+func (m *GridMap[ActorType, ItemType, ObjectType]) AddBloodDrop(p geometry.Point, volume int) {
+	m.liquid.init()
+	m.AddStimulusToTile(p, stimuli.Stim{StimType: stimuli.StimulusBlood, StimForce: volume})
+	m.liquid.dropsCounter++
+	m.liquid.bloodDrops[p] = m.liquid.dropsCounter
+}
+
+// NextBloodDrop returns the next drop of the blood trail that passes p, ie. the oldest
+// drop nearby that was left after the one at p. Without a trail at p, it returns false.
+func (m *GridMap[ActorType, ItemType, ObjectType]) NextBloodDrop(p geometry.Point) (geometry.Point, bool) {
+	order, isDrop := m.liquid.bloodDrops[p]
+	if !isDrop {
+		return p, false
+	}
+	next, nextOrder := p, 0
+	for q, o := range m.liquid.bloodDrops {
+		if o <= order || geometry.DistanceChebyshev(p, q) > bloodTrailSearchRadius {
+			continue
+		}
+		if nextOrder == 0 || o < nextOrder {
+			next, nextOrder = q, o
+		}
+	}
+	return next, nextOrder != 0
+}
+
+// liquidOutlets are the tiles a liquid at p can flow to. Stairs down come first,
+// since liquid on them runs to the floor below.
+func (m *GridMap[ActorType, ItemType, ObjectType]) liquidOutlets(p geometry.Point) (down []geometry.Point, level []geometry.Point) {
+	if floor := m.FloorAt(p); floor != nil && m.IsTileWithSpecialAt(p, SpecialTileStairs) {
+		for _, q := range m.FloorLinksAt(p) {
+			if m.FloorAt(q).Level < floor.Level {
+				down = append(down, q)
+			}
+		}
+	}
+	return down, m.NeighborsCardinal(p, m.IsTileWalkable)
+}
+
+// UpdateLiquids advances the liquid simulation by one step.
+// Liquids above a thin film run down stairs and even out with their neighbours
+// until they settle. Volume is conserved, it only moves between tiles.
+func (m *GridMap[ActorType, ItemType, ObjectType]) UpdateLiquids() LiquidUpdate {
+	m.liquid.init()
+	update := LiquidUpdate{Wetted: make(map[geometry.Point]stimuli.StimulusType)}
+	active := make([]geometry.Point, 0, len(m.liquid.flowing))
+	for p := range m.liquid.flowing {
+		if m.Contains(p) {
+			active = append(active, p)
+		}
+	}
+	sort.Slice(active, func(i, j int) bool {
+		return active[i].Y*m.MapWidth+active[i].X < active[j].Y*m.MapWidth+active[j].X
+	})
+	m.liquid.flowing = make(map[geometry.Point]bool)
+
+	for _, liquid := range Liquids {
+		flow := make(map[geometry.Point]int)
+		for _, p := range active {
+			volume := m.ForceOfStimulusOnTile(p, liquid)
+			if volume <= liquidFilm {
+				continue
+			}
+			down, level := m.liquidOutlets(p)
+			if len(down) > 0 {
+				share := (volume - liquidFilm) / (2 * len(down))
+				for _, q := range down {
+					flow[q] += share
+					flow[p] -= share
+				}
+				continue
+			}
+			for _, q := range level {
+				difference := volume - m.ForceOfStimulusOnTile(q, liquid)
+				if share := difference / (len(level) + 1) / 2; share > 0 {
+					flow[q] += share
+					flow[p] -= share
+				}
+			}
+		}
+		for p, amount := range flow {
+			if amount == 0 {
+				continue
+			}
+			if amount > 0 {
+				if !m.IsStimulusOnTile(p, liquid) {
+					update.Wetted[p] = liquid
+				}
+				m.AddStimulusToTile(p, stimuli.Stim{StimType: liquid, StimForce: amount})
+			} else {
+				volume := m.ForceOfStimulusOnTile(p, liquid) + amount
+				m.Cells[p.Y*m.MapWidth+p.X].Stimuli[liquid] = stimuli.Stim{StimType: liquid, StimForce: volume}
+			}
+			m.liquid.flowing[p] = true
+			for _, n := range m.NeighborsCardinal(p, m.Contains) {
+				if m.IsStimulusOnTile(n, liquid) {
+					m.liquid.flowing[n] = true
+				}
+			}
+		}
+	}
+	return update
+}
@@ -0,0 +1,253 @@
+package gridmap
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/memmaker/terminal-assassin/geometry"
+)
+
+// PatrolTemplate controls how a generated patrol dwells at its waypoints.
+type PatrolTemplate struct {
+	Name              string
+	DurationInSeconds float64
+	// MaxLookDirections limits how many doorways / zone entrances are watched per waypoint.
+	MaxLookDirections int
+	// LookRadius is the maximum distance at which doorways and entrances are considered.
+	LookRadius int
+}
+
+var PatrolTemplates = []PatrolTemplate{
+	{Name: "Quick sweep", DurationInSeconds: 3, MaxLookDirections: 1, LookRadius: 6},
+	{Name: "Standard patrol", DurationInSeconds: 10, MaxLookDirections: 2, LookRadius: 8},
+	{Name: "Watchful patrol", DurationInSeconds: 20, MaxLookDirections: 3, LookRadius: 10},
+}
+
+// ZoneWaypoint returns the walkable cell of the zone closest to its center.
+func (m *GridMap[ActorType, ItemType, ObjectType]) ZoneWaypoint(zoneName string) (geometry.Point, bool) {
+	cells := make([]geometry.Point, 0)
+	sumX, sumY := 0, 0
+	for i, zone := range m.ZoneMap {
+		if zone == nil || zone.Name != zoneName {
+			continue
+		}
+		p := geometry.Point{X: i % m.MapWidth, Y: i / m.MapWidth}
+		if !m.isTileWalkable(p) {
+			continue
+		}
+		cells = append(cells, p)
+		sumX += p.X
+		sumY += p.Y
+	}
+	if len(cells) == 0 {
+		return geometry.Point{}, false
+	}
+	center := geometry.Point{X: sumX / len(cells), Y: sumY / len(cells)}
+	best := cells[0]
+	for _, p := range cells[1:] {
+		if geometry.DistanceSquared(p, center) < geometry.DistanceSquared(best, center) {
+			best = p
+		}
+	}
+	return best, true
+}
+
+// GeneratePatrol builds a looping schedule that starts near start and visits all
+// waypoints. The visiting order is chosen by the walking distance along JPS paths.
+// At each waypoint the actor looks towards nearby doorways and zone entrances.
+// Waypoints that cannot be reached from start are left out and returned.
+func (m *GridMap[ActorType, ItemType, ObjectType]) GeneratePatrol(name string, start geometry.Point, waypoints []geometry.Point, template PatrolTemplate) (*Schedule, []geometry.Point, error) {
+	points := append([]geometry.Point{start}, uniquePoints(waypoints)...)
+	distances, unreachable := m.pathDistances(points)
+	reachable := []int{0}
+	for i := 1; i < len(points); i++ {
+		if unreachable[i] {
+			continue
+		}
+		reachable = append(reachable, i)
+	}
+	if len(reachable) < 3 {
+		return nil, nil, fmt.Errorf("a patrol needs at least two reachable waypoints, got %d", len(reachable)-1)
+	}
+	tour := shortestLoop(reachable, distances)
+
+	schedule := &Schedule{Name: name, Tasks: make([]ScheduledTask, 0, len(tour)-1)}
+	for _, index := range tour[1:] {
+		location := points[index]
+		schedule.Tasks = append(schedule.Tasks, ScheduledTask{
+			Location:          location,
+			DurationInSeconds: template.DurationInSeconds,
+			LookDirections:    m.lookDirectionsAt(location, template),
+			KnownPath:         make([]geometry.Point, 0),
+		})
+	}
+	skipped := make([]geometry.Point, 0)
+	for i := 1; i < len(points); i++ {
+		if unreachable[i] {
+			skipped = append(skipped, points[i])
+		}
+	}
+	return schedule, skipped, nil
+}
+
+func uniquePoints(points []geometry.Point) []geometry.Point {
+	seen := make(map[geometry.Point]bool, len(points))
+	result := make([]geometry.Point, 0, len(points))
+	for _, p := range points {
+		if seen[p] {
+			continue
+		}
+		seen[p] = true
+		result = append(result, p)
+	}
+	return result
+}
+
+// pathDistances returns the walking distances between all pairs of points.
+// Only tiles are considered, so closed doors do not cut a patrol route.
+// Points without a path from the first point are marked as unreachable.
+func (m *GridMap[ActorType, ItemType, ObjectType]) pathDistances(points []geometry.Point) ([][]int, []bool) {
+	distances := make([][]int, len(points))
+	for i := range distances {
+		distances[i] = make([]int, len(points))
+	}
+	unreachable := make([]bool, len(points))
+	buffer := make([]geometry.Point, 0)
+	for i := 0; i < len(points); i++ {
+		for j := i + 1; j < len(points); j++ {
+			buffer = m.GetJPSPath(points[i], points[j], m.isTileWalkable, buffer)
+			distance := len(buffer)
+			if distance == 0 {
+				distance = math.MaxInt32 / 4
+				if i == 0 {
+					unreachable[j] = true
+				}
+			}
+			distances[i][j] = distance
+			distances[j][i] = distance
+		}
+	}
+	return distances, unreachable
+}
+
+// shortestLoop orders the given point indices into a short round trip starting at
+// the first index: a nearest neighbour tour that is then improved with 2-opt moves.
+func shortestLoop(indices []int, distances [][]int) []int {
+	tour := []int{indices[0]}
+	remaining := append([]int{}, indices[1:]...)
+	for len(remaining) > 0 {
+		last := tour[len(tour)-1]
+		sort.SliceStable(remaining, func(i, j int) bool {
+			return distances[last][remaining[i]] < distances[last][remaining[j]]
+		})
+		tour = append(tour, remaining[0])
+		remaining = remaining[1:]
+	}
+	next := func(i int) int { return tour[(i+1)%len(tour)] }
+	for improved := true; improved; {
+		improved = false
+		for i := 0; i < len(tour)-1; i++ {
+			for j := i + 2; j < len(tour); j++ {
+				if i == 0 && j == len(tour)-1 {
+					continue
+				}
+				before := distances[tour[i]][next(i)] + distances[tour[j]][next(j)]
+				after := distances[tour[i]][tour[j]] + distances[next(i)][next(j)]
+				if after < before {
+					for a, b := i+1, j; a < b; a, b = a+1, b-1 {
+						tour[a], tour[b] = tour[b], tour[a]
+					}
+					improved = true
+				}
+			}
+		}
+	}
+	return tour
+}
+
+// lookDirectionsAt returns the bearings towards the closest visible doorways and
+// zone entrances around location, at least 45 degrees apart.
+func (m *GridMap[ActorType, ItemType, ObjectType]) lookDirectionsAt(location geometry.Point, template PatrolTemplate) []float64 {
+	radius := template.LookRadius
+	candidates := make([]geometry.Point, 0)
+	for y := location.Y - radius; y <= location.Y+radius; y++ {
+		for x := location.X - radius; x <= location.X+radius; x++ {
+			p := geometry.Point{X: x, Y: y}
+			if p == location || !m.Contains(p) || geometry.DistanceSquared(p, location) > radius*radius {
+				continue
+			}
+			if (m.isDoorway(p) || m.isZoneEntrance(p)) && m.isVisibleFrom(location, p) {
+				candidates = append(candidates, p)
+			}
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return geometry.DistanceSquared(candidates[i], location) < geometry.DistanceSquared(candidates[j], location)
+	})
+	directions := make([]float64, 0, template.MaxLookDirections)
+	for _, p := range candidates {
+		if len(directions) >= template.MaxLookDirections {
+			break
+		}
+		bearing := geometry.DirectionVectorToAngleInDegrees(p.Sub(location))
+		if isBearingCloseToAny(bearing, directions, 45) {
+			continue
+		}
+		directions = append(directions, bearing)
+	}
+	return directions
+}
+
+// isDoorway is true for walkable tiles that form a one cell wide gap in a wall,
+// whether or not a door object sits in the gap.
+func (m *GridMap[ActorType, ItemType, ObjectType]) isDoorway(p geometry.Point) bool {
+	if !m.isTileWalkable(p) {
+		return false
+	}
+	isWall := func(q geometry.Point) bool {
+		return m.Contains(q) && !m.isTileWalkable(q)
+	}
+	north, south := p.Add(geometry.Point{Y: -1}), p.Add(geometry.Point{Y: 1})
+	west, east := p.Add(geometry.Point{X: -1}), p.Add(geometry.Point{X: 1})
+	return (isWall(north) && isWall(south) && m.isTileWalkable(west) && m.isTileWalkable(east)) ||
+		(isWall(west) && isWall(east) && m.isTileWalkable(north) && m.isTileWalkable(south))
+}
+
+func (m *GridMap[ActorType, ItemType, ObjectType]) isTileWalkable(p geometry.Point) bool {
+	return m.Contains(p) && m.GetCell(p).TileType.IsWalkable
+}
+
+// isZoneEntrance is true for walkable tiles next to a walkable tile of another zone.
+func (m *GridMap[ActorType, ItemType, ObjectType]) isZoneEntrance(p geometry.Point) bool {
+	if !m.isTileWalkable(p) {
+		return false
+	}
+	zone := m.ZoneAt(p)
+	for _, neighbor := range m.NeighborsCardinal(p, m.isTileWalkable) {
+		if other := m.ZoneAt(neighbor); other != nil && zone != nil && other.Name != zone.Name {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *GridMap[ActorType, ItemType, ObjectType]) isVisibleFrom(source, target geometry.Point) bool {
+	line := geometry.LineOfSight(source, target, func(p geometry.Point) bool {
+		return p == source || p == target || m.IsTransparent(p)
+	})
+	return len(line) > 0 && line[len(line)-1] == target
+}
+
+func isBearingCloseToAny(bearing float64, others []float64, minDifference float64) bool {
+	for _, other := range others {
+		difference := math.Abs(bearing - other)
+		if difference > 180 {
+			difference = 360 - difference
+		}
+		if difference < minDifference {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,48 @@
+package gridmap
+
+import (
+	"sort"
+
+	"github.com/memmaker/terminal-assassin/geometry"
+)
+
+// SwitchOffDynamicLightAt removes the dynamic light at p from the map but keeps it,
+// so that SwitchOnDynamicLightAt can restore it later.
+func (m *GridMap[ActorType, ItemType, ObjectType]) SwitchOffDynamicLightAt(p geometry.Point) bool {
+	light, ok := m.DynamicLights[p]
+	if !ok {
+		return false
+	}
+	if m.switchedOffLights == nil {
+		m.switchedOffLights = make(map[geometry.Point]*LightSource)
+	}
+	m.switchedOffLights[p] = light
+	m.RemoveDynamicLightAt(p)
+	return true
+}
+
+// SwitchOnDynamicLightAt restores a dynamic light that has been switched off.
+func (m *GridMap[ActorType, ItemType, ObjectType]) SwitchOnDynamicLightAt(p geometry.Point) bool {
+	light, ok := m.switchedOffLights[p]
+	if !ok {
+		return false
+	}
+	delete(m.switchedOffLights, p)
+	m.AddDynamicLightSource(p, light)
+	return true
+}
+
+// SwitchedOffDynamicLights returns the positions of all switched off dynamic lights.
+func (m *GridMap[ActorType, ItemType, ObjectType]) SwitchedOffDynamicLights() []geometry.Point {
+	positions := make([]geometry.Point, 0, len(m.switchedOffLights))
+	for p := range m.switchedOffLights {
+		positions = append(positions, p)
+	}
+	sort.Slice(positions, func(i, j int) bool {
+		if positions[i].Y != positions[j].Y {
+			return positions[i].Y < positions[j].Y
+		}
+		return positions[i].X < positions[j].X
+	})
+	return positions
+}
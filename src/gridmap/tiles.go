@@ -2,6 +2,8 @@ package gridmap
 
 import (
     "fmt"
+    "strconv"
+
     "github.com/memmaker/terminal-assassin/common"
     "github.com/memmaker/terminal-assassin/game/stimuli"
     rec_files "github.com/memmaker/terminal-assassin/rec-files"
@@ -27,6 +29,14 @@ func (t SpecialTileType) ToString() string {
         return "powerOutlet"
     case SpecialTileLethal:
         return "lethal"
+    case SpecialTileGlassFloor:
+        return "glassFloor"
+    case SpecialTileShards:
+        return "shards"
+    case SpecialTileStairs:
+        return "stairs"
+    case SpecialTileElevator:
+        return "elevator"
     default:
         return "none"
     }
@@ -50,6 +60,14 @@ func NewSpecialTileTypeFromString(text string) SpecialTileType {
         return SpecialTileTypePowerOutlet
     case "lethal":
         return SpecialTileLethal
+    case "glassFloor":
+        return SpecialTileGlassFloor
+    case "shards":
+        return SpecialTileShards
+    case "stairs":
+        return SpecialTileStairs
+    case "elevator":
+        return SpecialTileElevator
     default:
         return SpecialTileNone
     }
@@ -66,6 +84,14 @@ const (
     SpecialTileTypeFood
     SpecialTileTypePowerOutlet
     SpecialTileLethal
+    // SpecialTileGlassFloor drops everything on it to the floor below when it breaks.
+    SpecialTileGlassFloor
+    // SpecialTileShards makes noise when the player walks over it.
+    SpecialTileShards
+    // SpecialTileStairs leads to the stairs at the same position one floor up or down.
+    SpecialTileStairs
+    // SpecialTileElevator leads to the elevators at the same position on all other floors.
+    SpecialTileElevator
 )
 
 type Tile struct {
@@ -74,6 +100,13 @@ type Tile struct {
     IsWalkable         bool
     IsTransparent      bool
     Special            SpecialTileType
+    // Fuel is how long the tile keeps a fire going. Tiles with fuel burn away
+    // and are replaced by the default floor once it is used up.
+    Fuel int
+    // Health is the damage a breakable tile takes before it is replaced
+    // by the BreaksInto tile. Tiles without health can't be destroyed.
+    Health     int
+    BreaksInto rune
 }
 
 func (t Tile) Icon() rune {
@@ -95,7 +128,21 @@ func (t Tile) ToTextMap() []rec_files.Field {
         {Name: "walkable", Value: fmt.Sprintf("%t", t.IsWalkable)},
         {Name: "transparent", Value: fmt.Sprintf("%t", t.IsTransparent)},
         {Name: "special", Value: t.Special.ToString()},
+        {Name: "fuel", Value: strconv.Itoa(t.Fuel)},
+        {Name: "health", Value: strconv.Itoa(t.Health)},
+        {Name: "breaks_into", Value: t.breaksIntoAsString()},
+    }
+}
+
+func (t Tile) breaksIntoAsString() string {
+    if t.BreaksInto == 0 {
+        return ""
     }
+    return string(t.BreaksInto)
+}
+
+func (t Tile) IsBreakable() bool {
+    return t.Health > 0
 }
 
 func (t Tile) IsLethal() bool {
@@ -110,12 +157,21 @@ func (t Tile) ToString() string {
 }
 
 func NewTileFromRecord(record map[string]string) *Tile {
+    fuel, _ := strconv.Atoi(record["fuel"])
+    health, _ := strconv.Atoi(record["health"])
+    var breaksInto rune
+    if runes := []rune(record["breaks_into"]); len(runes) > 0 {
+        breaksInto = runes[0]
+    }
     return &Tile{
         DefinedIcon:        []rune(record["icon"])[0],
         DefinedDescription: record["description"],
         IsWalkable:         record["walkable"] == "true",
         IsTransparent:      record["transparent"] == "true",
         Special:            NewSpecialTileTypeFromString(record["special"]),
+        Fuel:               fuel,
+        Health:             health,
+        BreaksInto:         breaksInto,
     }
 }
 
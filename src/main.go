@@ -75,8 +75,15 @@ type ConsoleEngine struct {
 	TimeFactor float64
 
 	pendingScreenshotPath string
+
+	// headless runs several simulation steps per frame without drawing and sound, eg. to verify a replay.
+	headless           bool
+	volumeBeforeMuting float64
 }
 
+// headlessStepsPerUpdate is the number of simulation steps per frame while running headless.
+const headlessStepsPerUpdate = 30
+
 func (g *ConsoleEngine) GetObjectFactory() services.ObjectFactoryInterface {
 	return g.ObjectFactory
 }
@@ -140,6 +147,26 @@ func (g *ConsoleEngine) Update() error {
 	// do we need this? we do
 	g.Input.Update()
 
+	if g.headless {
+		for i := 0; i < headlessStepsPerUpdate && g.headless; i++ {
+			g.updateSimulation()
+		}
+		return nil
+	}
+
+	g.updateSimulation()
+
+	g.UserInterface.Draw(g.Console)
+
+	g.Animator.Draw(g.Console)
+
+	// Compute delta and update previous grid state
+	g.Console.Flush()
+	return nil
+}
+
+// updateSimulation advances the game by one tick.
+func (g *ConsoleEngine) updateSimulation() {
 	var effectiveInput services.InputInterface = g.Input
 	if g.inputOverride != nil {
 		effectiveInput = g.inputOverride
@@ -155,15 +182,28 @@ func (g *ConsoleEngine) Update() error {
 		g.InGameTicks++
 	}
 
-	g.UserInterface.Draw(g.Console)
-
 	g.Animator.Update()
-	g.Animator.Draw(g.Console)
+}
 
-	// Compute delta and update previous grid state
-	g.Console.Flush()
-	return nil
+// SetHeadless switches the fast simulation without drawing and sound on or off.
+func (g *ConsoleEngine) SetHeadless(headless bool) {
+	if headless == g.headless {
+		return
+	}
+	g.headless = headless
+	if headless {
+		g.volumeBeforeMuting = g.Audio.GetMasterVolume()
+		g.Audio.StopAll()
+		g.Audio.SetMasterVolume(0)
+	} else {
+		g.Audio.SetMasterVolume(g.volumeBeforeMuting)
+	}
+}
+
+func (g *ConsoleEngine) IsHeadless() bool {
+	return g.headless
 }
+
 func (g *ConsoleEngine) Draw(screen *ebiten.Image) {
 	g.Console.Draw(screen)
 	if g.pendingScreenshotPath != "" {
@@ -256,7 +296,7 @@ func main() {
 		Model:          game.NewModel(gameConfig),
 		Files:          files,
 		ExternalData:   externalData,
-		Career:         game.NewCareerFromFile(),
+		Career:         game.LoadCareer(opts.Profile),
 		Recorder:       &services.Recorder{},
 		scheduledCalls: map[uint64][]func(){},
 		TimeFactor:     1.0,
@@ -460,6 +500,13 @@ func (g *ConsoleEngine) GetCareer() *services.CareerData {
 	return g.Career
 }
 
+// SetCareer switches to another career profile and remembers it for the next start.
+func (g *ConsoleEngine) SetCareer(career *services.CareerData) {
+	g.Career = career
+	g.options.Profile = career.PlayerName
+	g.SaveOptions()
+}
+
 func (g *ConsoleEngine) GetFiles() services.FileInterface {
 	return g.Files
 }
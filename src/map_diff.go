@@ -0,0 +1,645 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/memmaker/terminal-assassin/game/core"
+	"github.com/memmaker/terminal-assassin/game/services"
+	"github.com/memmaker/terminal-assassin/geometry"
+	"github.com/memmaker/terminal-assassin/gridmap"
+	rec_files "github.com/memmaker/terminal-assassin/rec-files"
+)
+
+// Semantic diff & three-way merge of map folders.
+//
+// Both work on a mapSnapshot: the content of a loaded map, reduced to the same
+// records the MapSerializer writes. Entities are keyed by name (actors, named
+// locations, schedules, zones, floors) or by name & position (items, objects, lights),
+// so changes from two designers only conflict if they touch the same entity or cell.
+// Entities that were moved are matched to their base entity, see matchMovedEntities.
+// tile_colors.fg/bg are not part of a snapshot, no code reads them anymore.
+
+type mapCategory struct {
+	Name        string
+	Singular    string
+	PosField    string
+	NameField   string
+	KeyedByName bool
+}
+
+var mapCategories = []mapCategory{
+	{Name: "actors", Singular: "actor", PosField: "Position", NameField: "Name", KeyedByName: true},
+	{Name: "items", Singular: "item", PosField: "ItemAt", NameField: "Name"},
+	{Name: "objects", Singular: "object", PosField: "ObjectAt", NameField: "Name"},
+	{Name: "baked_lights", Singular: "baked light", PosField: "Pos"},
+	{Name: "dynamic_lights", Singular: "dynamic light", PosField: "Pos"},
+	{Name: "named_locations", Singular: "named location", PosField: "Location", NameField: "Name", KeyedByName: true},
+	{Name: "schedules", Singular: "schedule", NameField: "TaskForSchedule", KeyedByName: true},
+	{Name: "zones", Singular: "zone", NameField: "Name", KeyedByName: true},
+	{Name: "floors", Singular: "floor", NameField: "Name", KeyedByName: true},
+}
+
+type mapSnapshot struct {
+	Global     rec_files.Record
+	Width      int
+	Height     int
+	Tiles      []rune
+	ZoneOfCell []string
+	Entities   map[string]map[string]rec_files.Record
+}
+
+func recordToText(record rec_files.Record) string {
+	lines := make([]string, len(record))
+	for i, field := range record {
+		lines[i] = field.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+func recordValue(record rec_files.Record, fieldName string) string {
+	for _, field := range record {
+		if field.Name == fieldName {
+			return field.Value
+		}
+	}
+	return ""
+}
+
+func recordWithout(record rec_files.Record, fieldName string) rec_files.Record {
+	result := make(rec_files.Record, 0, len(record))
+	for _, field := range record {
+		if field.Name != fieldName {
+			result = append(result, field)
+		}
+	}
+	return result
+}
+
+// entityKey identifies an entity of the category. Entities that are not keyed by
+// name are identified by their name & position, so moving them changes their key.
+func (c mapCategory) entityKey(record rec_files.Record) string {
+	if c.KeyedByName {
+		return recordValue(record, c.NameField)
+	}
+	return recordValue(record, c.NameField) + "@" + recordValue(record, c.PosField)
+}
+
+// matchMovedEntities returns the entities with the ones that were moved since base
+// stored under the key of their base entity, so moving an entity is a change of it.
+// A moved entity is matched to a base entity that is missing from the entities:
+// first to one that is the same apart from its position, then to the only one with its name.
+func (c mapCategory) matchMovedEntities(baseEntities, entities map[string]rec_files.Record) map[string]rec_files.Record {
+	if c.KeyedByName || c.PosField == "" {
+		return entities
+	}
+	missing := make(map[string]bool)
+	for key := range baseEntities {
+		if entities[key] == nil {
+			missing[key] = true
+		}
+	}
+	matched := make(map[string]rec_files.Record, len(entities))
+	unmatched := make([]string, 0)
+	for _, key := range sortedKeys(entities) {
+		if baseEntities[key] == nil {
+			unmatched = append(unmatched, key)
+			continue
+		}
+		matched[key] = entities[key]
+	}
+	matchBy := func(isMatch func(baseRecord, record rec_files.Record) bool, onlyUnique bool) {
+		stillUnmatched := make([]string, 0, len(unmatched))
+		for _, key := range unmatched {
+			candidates := make([]string, 0)
+			for _, baseKey := range sortedKeys(baseEntities) {
+				if missing[baseKey] && isMatch(baseEntities[baseKey], entities[key]) {
+					candidates = append(candidates, baseKey)
+				}
+			}
+			if len(candidates) == 0 || (onlyUnique && len(candidates) > 1) {
+				stillUnmatched = append(stillUnmatched, key)
+				continue
+			}
+			matched[candidates[0]] = entities[key]
+			delete(missing, candidates[0])
+		}
+		unmatched = stillUnmatched
+	}
+	matchBy(func(baseRecord, record rec_files.Record) bool {
+		return recordToText(recordWithout(baseRecord, c.PosField)) == recordToText(recordWithout(record, c.PosField))
+	}, false)
+	if c.NameField != "" {
+		matchBy(func(baseRecord, record rec_files.Record) bool {
+			return recordValue(baseRecord, c.NameField) == recordValue(record, c.NameField)
+		}, true)
+	}
+	for _, key := range unmatched {
+		matched[key] = entities[key]
+	}
+	return matched
+}
+
+// label describes the entity in the report, eg. "item Knife at (3,4)".
+func (c mapCategory) label(record rec_files.Record) string {
+	label := c.Singular
+	if c.NameField != "" {
+		label += " " + recordValue(record, c.NameField)
+	}
+	if c.PosField != "" {
+		label += " at " + recordValue(record, c.PosField)
+	}
+	return label
+}
+
+func (g *MapSerializer) snapshotOf(currentMap *gridmap.GridMap[*core.Actor, *core.Item, services.Object]) mapSnapshot {
+	snapshot := mapSnapshot{
+		Global:     NewGlobalDataFromMap(currentMap).ToRecord(),
+		Width:      currentMap.MapWidth,
+		Height:     currentMap.MapHeight,
+		Tiles:      make([]rune, currentMap.MapWidth*currentMap.MapHeight),
+		ZoneOfCell: make([]string, currentMap.MapWidth*currentMap.MapHeight),
+		Entities:   make(map[string]map[string]rec_files.Record),
+	}
+	for y := 0; y < currentMap.MapHeight; y++ {
+		for x := 0; x < currentMap.MapWidth; x++ {
+			pos := geometry.Point{X: x, Y: y}
+			snapshot.Tiles[y*currentMap.MapWidth+x] = currentMap.GetCell(pos).TileType.DefinedIcon
+			snapshot.ZoneOfCell[y*currentMap.MapWidth+x] = currentMap.ZoneAt(pos).Name
+		}
+	}
+	records := make(map[string][]rec_files.Record)
+	for _, actor := range append(append([]*core.Actor{}, currentMap.Actors()...), currentMap.DownedActors()...) {
+		record := rec_files.Record(NewActorOnDiskFromActor(actor).ToRecord())
+		if actor.AI != nil && actor.AI.Schedule != "" {
+			record = append(record, rec_files.Field{Name: "StartSchedule", Value: actor.AI.Schedule})
+		}
+		records["actors"] = append(records["actors"], record)
+	}
+	for _, item := range currentMap.Items() {
+		records["items"] = append(records["items"], itemLocationToRecord(item))
+	}
+	for _, object := range currentMap.Objects() {
+		records["objects"] = append(records["objects"], objectToRecord(object))
+	}
+	records["baked_lights"] = lightsToRecords(currentMap.BakedLights)
+	records["dynamic_lights"] = lightsToRecords(currentMap.DynamicLights)
+	for name, location := range currentMap.NamedLocations {
+		records["named_locations"] = append(records["named_locations"], rec_files.Record{
+			{Name: "Name", Value: name},
+			{Name: "Location", Value: location.String()},
+		})
+	}
+	for _, schedule := range currentMap.ListOfSchedules() {
+		// all tasks of a schedule in one record, every task starts with its TaskForSchedule field
+		var record rec_files.Record
+		for _, task := range schedule.ToRecords() {
+			record = append(record, task...)
+		}
+		records["schedules"] = append(records["schedules"], record)
+	}
+	for zoneIndex, zone := range currentMap.ListOfZones {
+		if zoneIndex == 0 {
+			continue
+		}
+		records["zones"] = append(records["zones"], zone.ToRecord())
+	}
+	for _, floor := range currentMap.Floors {
+		records["floors"] = append(records["floors"], floor.ToRecord())
+	}
+
+	for _, category := range mapCategories {
+		entities := make(map[string]rec_files.Record)
+		for _, record := range records[category.Name] {
+			key := category.entityKey(record)
+			uniqueKey := key
+			for count := 2; entities[uniqueKey] != nil; count++ {
+				uniqueKey = fmt.Sprintf("%s#%d", key, count)
+			}
+			entities[uniqueKey] = record
+		}
+		snapshot.Entities[category.Name] = entities
+	}
+	return snapshot
+}
+
+// mapFromSnapshot builds a map from the snapshot, using the same record
+// conversions as loading a map folder.
+func (g *MapSerializer) mapFromSnapshot(snapshot mapSnapshot) *gridmap.GridMap[*core.Actor, *core.Item, services.Object] {
+	globalData := gridmap.NewGlobalMapFromRecord(snapshot.Global)
+	loadedMap := gridmap.NewEmptyMap[*core.Actor, *core.Item, services.Object](snapshot.Width, snapshot.Height, globalData.MaxVisionRange)
+	g.ApplyGlobalMapData(loadedMap, globalData)
+
+	tileCache := make(map[rune]gridmap.Tile)
+	for index, icon := range snapshot.Tiles {
+		if _, ok := tileCache[icon]; !ok {
+			tileCache[icon] = g.engine.ExternalData.TileFromIcon(icon)
+		}
+		loadedMap.SetTile(geometry.Point{X: index % snapshot.Width, Y: index / snapshot.Width}, tileCache[icon])
+	}
+
+	entities := func(categoryName string) []rec_files.Record {
+		keys := make([]string, 0, len(snapshot.Entities[categoryName]))
+		for key := range snapshot.Entities[categoryName] {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		result := make([]rec_files.Record, len(keys))
+		for i, key := range keys {
+			result[i] = snapshot.Entities[categoryName][key]
+		}
+		return result
+	}
+
+	zonesByName := map[string]*gridmap.ZoneInfo{loadedMap.ListOfZones[0].Name: loadedMap.ListOfZones[0]}
+	for _, record := range entities("zones") {
+		zone := gridmap.NewZoneFromRecord(record)
+		loadedMap.AddZone(zone)
+		zonesByName[zone.Name] = zone
+	}
+	for index, zoneName := range snapshot.ZoneOfCell {
+		if zone, ok := zonesByName[zoneName]; ok {
+			loadedMap.SetZone(geometry.Point{X: index % snapshot.Width, Y: index / snapshot.Width}, zone)
+		}
+	}
+
+	for _, record := range entities("actors") {
+		actor := g.addActorFromRecord(loadedMap, record)
+		if actor.AI != nil {
+			actor.AI.Schedule = recordValue(record, "StartSchedule")
+		}
+	}
+	for _, record := range entities("items") {
+		item, pos := g.itemFromRecord(record)
+		loadedMap.AddItem(item, pos)
+	}
+	for _, record := range entities("objects") {
+		object, pos := g.objectFromRecord(record)
+		if object != nil {
+			loadedMap.AddObject(object, pos)
+		}
+	}
+	for _, record := range entities("baked_lights") {
+		light := gridmap.NewLightSourceFromRecord(record)
+		loadedMap.AddBakedLightSource(light.Pos, light)
+	}
+	for _, record := range entities("dynamic_lights") {
+		light := gridmap.NewLightSourceFromRecord(record)
+		loadedMap.AddDynamicLightSource(light.Pos, light)
+	}
+	for _, record := range entities("named_locations") {
+		loadedMap.NamedLocations[recordValue(record, "Name")], _ = geometry.NewPointFromString(recordValue(record, "Location"))
+	}
+	var taskRecords []rec_files.Record
+	for _, record := range entities("schedules") {
+		for _, field := range record {
+			if field.Name == "TaskForSchedule" {
+				taskRecords = append(taskRecords, rec_files.Record{})
+			}
+			if len(taskRecords) > 0 {
+				taskRecords[len(taskRecords)-1] = append(taskRecords[len(taskRecords)-1], field)
+			}
+		}
+	}
+	for _, schedule := range gridmap.SchedulesFromTaskRecords(taskRecords) {
+		loadedMap.AddSchedule(schedule)
+	}
+	for _, record := range entities("floors") {
+		loadedMap.AddFloor(gridmap.NewFloorFromRecord(record))
+	}
+	return loadedMap
+}
+
+// changedRegions groups the changed cells into 8-connected regions and
+// describes each as its bounding box and the number of changed cells.
+func changedRegions(width, height int, isChanged func(index int) bool) []string {
+	visited := make([]bool, width*height)
+	regions := make([]string, 0)
+	for start := 0; start < width*height; start++ {
+		if visited[start] || !isChanged(start) {
+			continue
+		}
+		minPos := geometry.Point{X: start % width, Y: start / width}
+		maxPos := minPos
+		count := 0
+		stack := []int{start}
+		visited[start] = true
+		for len(stack) > 0 {
+			index := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			count++
+			pos := geometry.Point{X: index % width, Y: index / width}
+			minPos = geometry.Point{X: min(minPos.X, pos.X), Y: min(minPos.Y, pos.Y)}
+			maxPos = geometry.Point{X: max(maxPos.X, pos.X), Y: max(maxPos.Y, pos.Y)}
+			for dy := -1; dy <= 1; dy++ {
+				for dx := -1; dx <= 1; dx++ {
+					neighbor := geometry.Point{X: pos.X + dx, Y: pos.Y + dy}
+					if neighbor.X < 0 || neighbor.Y < 0 || neighbor.X >= width || neighbor.Y >= height {
+						continue
+					}
+					neighborIndex := neighbor.Y*width + neighbor.X
+					if !visited[neighborIndex] && isChanged(neighborIndex) {
+						visited[neighborIndex] = true
+						stack = append(stack, neighborIndex)
+					}
+				}
+			}
+		}
+		regions = append(regions, fmt.Sprintf("%d cell(s) in %s-%s", count, minPos.String(), maxPos.String()))
+	}
+	return regions
+}
+
+// diffSnapshots describes the changes from a to b, one line per change.
+func diffSnapshots(a, b mapSnapshot) []string {
+	report := make([]string, 0)
+	for _, field := range b.Global {
+		if oldValue := recordValue(a.Global, field.Name); oldValue != field.Value {
+			report = append(report, fmt.Sprintf("Global: %s changed from '%s' to '%s'", field.Name, oldValue, field.Value))
+		}
+	}
+	if a.Width != b.Width || a.Height != b.Height {
+		report = append(report, fmt.Sprintf("Size: changed from %dx%d to %dx%d, only the common area is compared", a.Width, a.Height, b.Width, b.Height))
+	}
+	width, height := min(a.Width, b.Width), min(a.Height, b.Height)
+	cellChanged := func(cells func(s mapSnapshot) []rune) func(int) bool {
+		return func(index int) bool {
+			x, y := index%width, index/width
+			return cells(a)[y*a.Width+x] != cells(b)[y*b.Width+x]
+		}
+	}
+	for _, region := range changedRegions(width, height, cellChanged(func(s mapSnapshot) []rune { return s.Tiles })) {
+		report = append(report, "Tiles: changed "+region)
+	}
+	zoneChanged := func(index int) bool {
+		x, y := index%width, index/width
+		return a.ZoneOfCell[y*a.Width+x] != b.ZoneOfCell[y*b.Width+x]
+	}
+	for _, region := range changedRegions(width, height, zoneChanged) {
+		report = append(report, "Zone map: changed "+region)
+	}
+
+	for _, category := range mapCategories {
+		oldEntities := a.Entities[category.Name]
+		newEntities := category.matchMovedEntities(oldEntities, b.Entities[category.Name])
+		for _, key := range sortedKeys(oldEntities, newEntities) {
+			oldRecord, newRecord := oldEntities[key], newEntities[key]
+			switch {
+			case newRecord == nil:
+				report = append(report, "Removed "+category.label(oldRecord))
+			case oldRecord == nil:
+				report = append(report, "Added "+category.label(newRecord))
+			case recordToText(oldRecord) != recordToText(newRecord):
+				report = append(report, describeChange(category, oldRecord, newRecord))
+			}
+		}
+	}
+	return report
+}
+
+func describeChange(category mapCategory, oldRecord, newRecord rec_files.Record) string {
+	if category.PosField != "" {
+		oldPos, newPos := recordValue(oldRecord, category.PosField), recordValue(newRecord, category.PosField)
+		if oldPos != newPos && recordToText(recordWithout(oldRecord, category.PosField)) == recordToText(recordWithout(newRecord, category.PosField)) {
+			return fmt.Sprintf("Moved %s to %s", category.label(oldRecord), newPos)
+		}
+	}
+	if category.Name == "schedules" {
+		return fmt.Sprintf("Changed %s (%d -> %d task fields)", category.label(oldRecord), len(oldRecord), len(newRecord))
+	}
+	changes := make([]string, 0)
+	for _, field := range newRecord {
+		if oldValue := recordValue(oldRecord, field.Name); oldValue != field.Value {
+			changes = append(changes, fmt.Sprintf("%s: '%s' -> '%s'", field.Name, oldValue, field.Value))
+		}
+	}
+	for _, field := range oldRecord {
+		if recordValue(newRecord, field.Name) == "" && field.Value != "" {
+			changes = append(changes, fmt.Sprintf("%s: '%s' removed", field.Name, field.Value))
+		}
+	}
+	return fmt.Sprintf("Changed %s (%s)", category.label(oldRecord), strings.Join(changes, ", "))
+}
+
+// fieldNames returns the names of the fields of all records, in the order they first appear.
+func fieldNames(records ...rec_files.Record) []string {
+	seen := make(map[string]bool)
+	names := make([]string, 0)
+	for _, record := range records {
+		for _, field := range record {
+			if !seen[field.Name] {
+				seen[field.Name] = true
+				names = append(names, field.Name)
+			}
+		}
+	}
+	return names
+}
+
+func hasField(record rec_files.Record, fieldName string) bool {
+	for _, field := range record {
+		if field.Name == fieldName {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedKeys(maps ...map[string]rec_files.Record) []string {
+	seen := make(map[string]bool)
+	keys := make([]string, 0)
+	for _, m := range maps {
+		for key := range m {
+			if !seen[key] {
+				seen[key] = true
+				keys = append(keys, key)
+			}
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// mergeSnapshots does a three-way merge. A change made on one side only is taken,
+// the same change on both sides is taken once, different changes to the same
+// global field, cell or entity are reported as conflicts.
+func mergeSnapshots(base, ours, theirs mapSnapshot) (mapSnapshot, []string) {
+	conflicts := make([]string, 0)
+	merged := mapSnapshot{Entities: make(map[string]map[string]rec_files.Record)}
+
+	for _, name := range fieldNames(base.Global, ours.Global, theirs.Global) {
+		baseValue, ourValue, theirValue := recordValue(base.Global, name), recordValue(ours.Global, name), recordValue(theirs.Global, name)
+		value, ok := mergeValue(baseValue, ourValue, theirValue)
+		if !ok {
+			conflicts = append(conflicts, fmt.Sprintf("Global: %s changed to '%s' and '%s'", name, ourValue, theirValue))
+		}
+		if value != "" || hasField(base.Global, name) {
+			merged.Global = append(merged.Global, rec_files.Field{Name: name, Value: value})
+		}
+	}
+
+	sameSize := func(a, b mapSnapshot) bool { return a.Width == b.Width && a.Height == b.Height }
+	switch {
+	case sameSize(ours, theirs) && sameSize(base, ours):
+		merged.Width, merged.Height = base.Width, base.Height
+		merged.Tiles = make([]rune, len(base.Tiles))
+		merged.ZoneOfCell = make([]string, len(base.ZoneOfCell))
+		tileConflict := make([]bool, len(base.Tiles))
+		zoneConflict := make([]bool, len(base.Tiles))
+		for i := range base.Tiles {
+			var ok bool
+			merged.Tiles[i], ok = mergeValue(base.Tiles[i], ours.Tiles[i], theirs.Tiles[i])
+			tileConflict[i] = !ok
+			merged.ZoneOfCell[i], ok = mergeValue(base.ZoneOfCell[i], ours.ZoneOfCell[i], theirs.ZoneOfCell[i])
+			zoneConflict[i] = !ok
+		}
+		for _, region := range changedRegions(base.Width, base.Height, func(i int) bool { return tileConflict[i] }) {
+			conflicts = append(conflicts, "Tiles: both sides changed "+region)
+		}
+		for _, region := range changedRegions(base.Width, base.Height, func(i int) bool { return zoneConflict[i] }) {
+			conflicts = append(conflicts, "Zone map: both sides changed "+region)
+		}
+	case sameSize(base, theirs) && isGridUnchanged(base, theirs):
+		merged.Width, merged.Height, merged.Tiles, merged.ZoneOfCell = ours.Width, ours.Height, ours.Tiles, ours.ZoneOfCell
+	case sameSize(base, ours) && isGridUnchanged(base, ours):
+		merged.Width, merged.Height, merged.Tiles, merged.ZoneOfCell = theirs.Width, theirs.Height, theirs.Tiles, theirs.ZoneOfCell
+	default:
+		conflicts = append(conflicts, fmt.Sprintf("Size: resized to %dx%d and %dx%d, tiles and zones cannot be merged", ours.Width, ours.Height, theirs.Width, theirs.Height))
+	}
+	for i := range merged.Global {
+		switch merged.Global[i].Name {
+		case "Width":
+			merged.Global[i].Value = strconv.Itoa(merged.Width)
+		case "Height":
+			merged.Global[i].Value = strconv.Itoa(merged.Height)
+		}
+	}
+
+	for _, category := range mapCategories {
+		baseEntities := base.Entities[category.Name]
+		ourEntities := category.matchMovedEntities(baseEntities, ours.Entities[category.Name])
+		theirEntities := category.matchMovedEntities(baseEntities, theirs.Entities[category.Name])
+		mergedEntities := make(map[string]rec_files.Record)
+		for _, key := range sortedKeys(baseEntities, ourEntities, theirEntities) {
+			baseText, ourText, theirText := recordToText(baseEntities[key]), recordToText(ourEntities[key]), recordToText(theirEntities[key])
+			text, ok := mergeValue(baseText, ourText, theirText)
+			if !ok {
+				label := category.label(firstRecord(baseEntities[key], ourEntities[key], theirEntities[key]))
+				switch {
+				case ourEntities[key] == nil:
+					conflicts = append(conflicts, fmt.Sprintf("Conflict: %s was removed on our side and changed on theirs", label))
+				case theirEntities[key] == nil:
+					conflicts = append(conflicts, fmt.Sprintf("Conflict: %s was changed on our side and removed on theirs", label))
+				default:
+					conflicts = append(conflicts, fmt.Sprintf("Conflict: %s was changed differently on both sides", label))
+				}
+				continue
+			}
+			switch text {
+			case "":
+				// removed
+			case ourText:
+				mergedEntities[key] = ourEntities[key]
+			case theirText:
+				mergedEntities[key] = theirEntities[key]
+			default:
+				mergedEntities[key] = baseEntities[key]
+			}
+		}
+		merged.Entities[category.Name] = mergedEntities
+	}
+
+	// actors may only reference schedules that survived the merge
+	for _, actor := range merged.Entities["actors"] {
+		scheduleName := recordValue(actor, "StartSchedule")
+		if scheduleName != "" && merged.Entities["schedules"][scheduleName] == nil {
+			conflicts = append(conflicts, fmt.Sprintf("Conflict: actor %s starts the removed schedule %s", recordValue(actor, "Name"), scheduleName))
+		}
+	}
+	return merged, conflicts
+}
+
+func mergeValue[T comparable](base, ours, theirs T) (T, bool) {
+	switch {
+	case ours == theirs:
+		return ours, true
+	case ours == base:
+		return theirs, true
+	case theirs == base:
+		return ours, true
+	}
+	return base, false
+}
+
+func isGridUnchanged(a, b mapSnapshot) bool {
+	for i := range a.Tiles {
+		if a.Tiles[i] != b.Tiles[i] || a.ZoneOfCell[i] != b.ZoneOfCell[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func firstRecord(records ...rec_files.Record) rec_files.Record {
+	for _, record := range records {
+		if record != nil {
+			return record
+		}
+	}
+	return nil
+}
+
+func (g *ConsoleEngine) loadSnapshot(mapFolder string) (mapSnapshot, error) {
+	loadedMap, err := g.LoadMap(mapFolder)
+	if err != nil {
+		return mapSnapshot{}, fmt.Errorf("%s: %w", mapFolder, err)
+	}
+	serializer := MapSerializer{engine: g}
+	return serializer.snapshotOf(loadedMap), nil
+}
+
+// DiffMapFolders describes the changes from the map in oldFolder to the map in newFolder.
+func (g *ConsoleEngine) DiffMapFolders(oldFolder, newFolder string) ([]string, error) {
+	oldSnapshot, err := g.loadSnapshot(oldFolder)
+	if err != nil {
+		return nil, err
+	}
+	newSnapshot, err := g.loadSnapshot(newFolder)
+	if err != nil {
+		return nil, err
+	}
+	return diffSnapshots(oldSnapshot, newSnapshot), nil
+}
+
+// MergeMapFolders merges the changes made in oursFolder and theirsFolder since baseFolder
+// and saves the result to outputFolder. If there are conflicts, nothing is saved and
+// the conflicts are returned. Files that are not part of the map data (scripts,
+// dialogues, briefing, challenges..) are copied from oursFolder.
+func (g *ConsoleEngine) MergeMapFolders(baseFolder, oursFolder, theirsFolder, outputFolder string) ([]string, error) {
+	snapshots := make([]mapSnapshot, 3)
+	for i, folder := range []string{baseFolder, oursFolder, theirsFolder} {
+		snapshot, err := g.loadSnapshot(folder)
+		if err != nil {
+			return nil, err
+		}
+		snapshots[i] = snapshot
+	}
+	merged, conflicts := mergeSnapshots(snapshots[0], snapshots[1], snapshots[2])
+	if len(conflicts) > 0 {
+		return conflicts, nil
+	}
+	serializer := MapSerializer{engine: g}
+	mergedMap := serializer.mapFromSnapshot(merged)
+	mergedMap.MetaData.FileName = oursFolder
+	if err := os.MkdirAll(outputFolder, 0755); err != nil {
+		return nil, err
+	}
+	if err := g.SaveMap(mergedMap, outputFolder); err != nil {
+		return nil, err
+	}
+	println(fmt.Sprintf("Merged %s and %s into %s", oursFolder, theirsFolder, outputFolder))
+	return nil, nil
+}
@@ -0,0 +1,195 @@
+package main
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	rec_files "github.com/memmaker/terminal-assassin/rec-files"
+)
+
+var itemCategory = mapCategories[1]
+
+func testSnapshot(global rec_files.Record, tiles string, items ...rec_files.Record) mapSnapshot {
+	snapshot := mapSnapshot{
+		Global:     global,
+		Width:      len(tiles),
+		Height:     1,
+		Tiles:      []rune(tiles),
+		ZoneOfCell: make([]string, len(tiles)),
+		Entities:   map[string]map[string]rec_files.Record{"items": {}},
+	}
+	for _, item := range items {
+		snapshot.Entities["items"][itemCategory.entityKey(item)] = item
+	}
+	return snapshot
+}
+
+func TestMergeSnapshots(t *testing.T) {
+	knife := rec_files.Record{{Name: "Name", Value: "Knife"}, {Name: "ItemAt", Value: "1,0"}}
+	wire := rec_files.Record{{Name: "Name", Value: "Piano Wire"}, {Name: "ItemAt", Value: "2,0"}}
+	movedKnife := rec_files.Record{{Name: "Name", Value: "Knife"}, {Name: "ItemAt", Value: "2,0"}}
+	otherMovedKnife := rec_files.Record{{Name: "Name", Value: "Knife"}, {Name: "ItemAt", Value: "0,0"}}
+	editedKnife := rec_files.Record{{Name: "Name", Value: "Knife"}, {Name: "ItemAt", Value: "1,0"}, {Name: "Uses", Value: "3"}}
+	movedEditedKnife := rec_files.Record{{Name: "Name", Value: "Knife"}, {Name: "ItemAt", Value: "2,0"}, {Name: "Uses", Value: "3"}}
+	title := func(value string) rec_files.Field { return rec_files.Field{Name: "MissionTitle", Value: value} }
+	cue := func(value string) rec_files.Field { return rec_files.Field{Name: "AmbienceSoundCue", Value: value} }
+
+	tests := []struct {
+		name          string
+		base          mapSnapshot
+		ours          mapSnapshot
+		theirs        mapSnapshot
+		wantGlobal    rec_files.Record
+		wantTiles     string
+		wantItems     []string
+		wantConflicts []string
+	}{
+		{
+			name:       "changes on one side are taken",
+			base:       testSnapshot(rec_files.Record{title("Base")}, "###"),
+			ours:       testSnapshot(rec_files.Record{title("Ours")}, "###"),
+			theirs:     testSnapshot(rec_files.Record{title("Base")}, "#.#"),
+			wantGlobal: rec_files.Record{title("Ours")},
+			wantTiles:  "#.#",
+		},
+		{
+			name:       "global fields added on one side are kept",
+			base:       testSnapshot(rec_files.Record{title("Base")}, "###"),
+			ours:       testSnapshot(rec_files.Record{title("Base"), cue("rain")}, "###"),
+			theirs:     testSnapshot(rec_files.Record{title("Base")}, "###"),
+			wantGlobal: rec_files.Record{title("Base"), cue("rain")},
+			wantTiles:  "###",
+		},
+		{
+			name:       "global fields added on the other side are kept",
+			base:       testSnapshot(rec_files.Record{title("Base")}, "###"),
+			ours:       testSnapshot(rec_files.Record{title("Ours")}, "###"),
+			theirs:     testSnapshot(rec_files.Record{title("Base"), cue("wind")}, "###"),
+			wantGlobal: rec_files.Record{title("Ours"), cue("wind")},
+			wantTiles:  "###",
+		},
+		{
+			name:          "the same global field added twice conflicts",
+			base:          testSnapshot(rec_files.Record{title("Base")}, "###"),
+			ours:          testSnapshot(rec_files.Record{title("Base"), cue("rain")}, "###"),
+			theirs:        testSnapshot(rec_files.Record{title("Base"), cue("wind")}, "###"),
+			wantGlobal:    rec_files.Record{title("Base")},
+			wantTiles:     "###",
+			wantConflicts: []string{"Global: AmbienceSoundCue"},
+		},
+		{
+			name:          "both sides change the same cell",
+			base:          testSnapshot(rec_files.Record{title("Base")}, "###"),
+			ours:          testSnapshot(rec_files.Record{title("Base")}, "#.#"),
+			theirs:        testSnapshot(rec_files.Record{title("Base")}, "#~#"),
+			wantGlobal:    rec_files.Record{title("Base")},
+			wantTiles:     "###",
+			wantConflicts: []string{"Tiles: both sides changed"},
+		},
+		{
+			name:       "entities added on both sides",
+			base:       testSnapshot(rec_files.Record{title("Base")}, "###"),
+			ours:       testSnapshot(rec_files.Record{title("Base")}, "###", knife),
+			theirs:     testSnapshot(rec_files.Record{title("Base")}, "###", wire),
+			wantGlobal: rec_files.Record{title("Base")},
+			wantTiles:  "###",
+			wantItems:  []string{"Knife at 1,0", "Piano Wire at 2,0"},
+		},
+		{
+			name:       "entity removed on one side",
+			base:       testSnapshot(rec_files.Record{title("Base")}, "###", knife, wire),
+			ours:       testSnapshot(rec_files.Record{title("Base")}, "###", knife),
+			theirs:     testSnapshot(rec_files.Record{title("Base")}, "###", knife, wire),
+			wantGlobal: rec_files.Record{title("Base")},
+			wantTiles:  "###",
+			wantItems:  []string{"Knife at 1,0"},
+		},
+		{
+			name:       "entity moved on one side",
+			base:       testSnapshot(rec_files.Record{title("Base")}, "###", knife),
+			ours:       testSnapshot(rec_files.Record{title("Base")}, "###", movedKnife),
+			theirs:     testSnapshot(rec_files.Record{title("Base")}, "###", knife),
+			wantGlobal: rec_files.Record{title("Base")},
+			wantTiles:  "###",
+			wantItems:  []string{"Knife at 2,0"},
+		},
+		{
+			name:          "entity moved on one side and edited on the other",
+			base:          testSnapshot(rec_files.Record{title("Base")}, "###", knife),
+			ours:          testSnapshot(rec_files.Record{title("Base")}, "###", movedKnife),
+			theirs:        testSnapshot(rec_files.Record{title("Base")}, "###", editedKnife),
+			wantGlobal:    rec_files.Record{title("Base")},
+			wantTiles:     "###",
+			wantConflicts: []string{"Conflict: item Knife at 1,0 was changed differently"},
+		},
+		{
+			name:          "entity moved to different cells",
+			base:          testSnapshot(rec_files.Record{title("Base")}, "###", knife),
+			ours:          testSnapshot(rec_files.Record{title("Base")}, "###", movedKnife),
+			theirs:        testSnapshot(rec_files.Record{title("Base")}, "###", otherMovedKnife),
+			wantGlobal:    rec_files.Record{title("Base")},
+			wantTiles:     "###",
+			wantConflicts: []string{"Conflict: item Knife at 1,0 was changed differently"},
+		},
+		{
+			name:       "entity moved and edited on the same side",
+			base:       testSnapshot(rec_files.Record{title("Base")}, "###", knife, wire),
+			ours:       testSnapshot(rec_files.Record{title("Base")}, "###", movedEditedKnife, wire),
+			theirs:     testSnapshot(rec_files.Record{title("Base")}, "###", knife),
+			wantGlobal: rec_files.Record{title("Base")},
+			wantTiles:  "###",
+			wantItems:  []string{"Knife at 2,0"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			merged, conflicts := mergeSnapshots(test.base, test.ours, test.theirs)
+			if !slices.Equal(merged.Global, test.wantGlobal) {
+				t.Errorf("global is %v, want %v", merged.Global, test.wantGlobal)
+			}
+			if string(merged.Tiles) != test.wantTiles {
+				t.Errorf("tiles are %q, want %q", string(merged.Tiles), test.wantTiles)
+			}
+			items := make([]string, 0, len(merged.Entities["items"]))
+			for _, item := range merged.Entities["items"] {
+				items = append(items, recordValue(item, "Name")+" at "+recordValue(item, "ItemAt"))
+			}
+			slices.Sort(items)
+			if !slices.Equal(items, test.wantItems) {
+				t.Errorf("items are %v, want %v", items, test.wantItems)
+			}
+			if len(conflicts) != len(test.wantConflicts) {
+				t.Fatalf("got conflicts %v, want %v", conflicts, test.wantConflicts)
+			}
+			for index, conflict := range conflicts {
+				if !strings.HasPrefix(conflict, test.wantConflicts[index]) {
+					t.Errorf("conflict %q, want it to start with %q", conflict, test.wantConflicts[index])
+				}
+			}
+		})
+	}
+}
+
+func TestDiffSnapshotsReportsMoves(t *testing.T) {
+	knife := rec_files.Record{{Name: "Name", Value: "Knife"}, {Name: "ItemAt", Value: "1,0"}}
+	movedKnife := rec_files.Record{{Name: "Name", Value: "Knife"}, {Name: "ItemAt", Value: "2,0"}}
+	movedEditedKnife := rec_files.Record{{Name: "Name", Value: "Knife"}, {Name: "ItemAt", Value: "2,0"}, {Name: "Uses", Value: "3"}}
+
+	tests := []struct {
+		name  string
+		moved rec_files.Record
+		want  string
+	}{
+		{name: "moved", moved: movedKnife, want: "Moved item Knife at 1,0 to 2,0"},
+		{name: "moved and edited", moved: movedEditedKnife, want: "Changed item Knife at 1,0 (ItemAt: '1,0' -> '2,0', Uses: '' -> '3')"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			report := diffSnapshots(testSnapshot(nil, "###", knife), testSnapshot(nil, "###", test.moved))
+			if !slices.Equal(report, []string{test.want}) {
+				t.Errorf("report is %v, want %v", report, []string{test.want})
+			}
+		})
+	}
+}
@@ -89,17 +89,7 @@ func (g *MapSerializer) SaveItemLocations(currentMap *gridmap.GridMap[*core.Acto
 
     records := make([]rec_files.Record, 0)
     for _, itemAt := range currentMap.Items() {
-        record := []rec_files.Field{
-            {Name: "ItemAt", Value: itemAt.Pos().String()},
-            {Name: "Name", Value: itemAt.Name},
-        }
-        if itemAt.KeyString != "" {
-            record = append(record, rec_files.Field{Name: "Key", Value: itemAt.GetKey()})
-        }
-        if itemAt.Buried {
-            record = append(record, rec_files.Field{Name: "Buried", Value: "true"})
-        }
-        records = append(records, record)
+        records = append(records, itemLocationToRecord(itemAt))
     }
     sort.SliceStable(records, func(i, j int) bool {
         return records[i][0].Value < records[j][0].Value
@@ -116,25 +106,7 @@ func (g *MapSerializer) LoadItemLocations(files *Files, currentMap *gridmap.Grid
     itemCount := 0
     records := rec_files.Read(file)
     for _, record := range records {
-        var pos geometry.Point
-        var itemName string
-        var keyString string
-        var buried bool
-        for _, field := range record {
-            switch field.Name {
-            case "ItemAt":
-                pos, _ = geometry.NewPointFromString(field.Value)
-            case "Name":
-                itemName = field.Value
-            case "Key":
-                keyString = field.Value
-            case "Buried":
-                buried = field.Value == "true"
-            }
-        }
-        itemFactory := g.engine.GetItemFactory()
-        itemRef := itemFactory.ItemFromNameAndKey(itemName, keyString)
-        itemRef.Buried = buried
+        itemRef, pos := g.itemFromRecord(record)
         currentMap.AddItem(itemRef, pos)
         itemCount++
     }
@@ -143,6 +115,43 @@ func (g *MapSerializer) LoadItemLocations(files *Files, currentMap *gridmap.Grid
     return file.Close()
 }
 
+func itemLocationToRecord(itemAt *core.Item) rec_files.Record {
+    record := []rec_files.Field{
+        {Name: "ItemAt", Value: itemAt.Pos().String()},
+        {Name: "Name", Value: itemAt.Name},
+    }
+    if itemAt.KeyString != "" {
+        record = append(record, rec_files.Field{Name: "Key", Value: itemAt.GetKey()})
+    }
+    if itemAt.Buried {
+        record = append(record, rec_files.Field{Name: "Buried", Value: "true"})
+    }
+    return record
+}
+
+func (g *MapSerializer) itemFromRecord(record rec_files.Record) (*core.Item, geometry.Point) {
+    var pos geometry.Point
+    var itemName string
+    var keyString string
+    var buried bool
+    for _, field := range record {
+        switch field.Name {
+        case "ItemAt":
+            pos, _ = geometry.NewPointFromString(field.Value)
+        case "Name":
+            itemName = field.Value
+        case "Key":
+            keyString = field.Value
+        case "Buried":
+            buried = field.Value == "true"
+        }
+    }
+    itemFactory := g.engine.GetItemFactory()
+    itemRef := itemFactory.ItemFromNameAndKey(itemName, keyString)
+    itemRef.Buried = buried
+    return itemRef, pos
+}
+
 func (g *MapSerializer) SaveObjects(currentMap *gridmap.GridMap[*core.Actor, *core.Item, services.Object], filename string) error {
     file, err := os.Create(filename)
     if err != nil {
@@ -151,22 +160,7 @@ func (g *MapSerializer) SaveObjects(currentMap *gridmap.GridMap[*core.Actor, *co
     defer file.Close()
     records := make([]rec_files.Record, 0)
     for _, objectAt := range currentMap.Objects() {
-        record := []rec_files.Field{
-            {Name: "ObjectAt", Value: objectAt.Pos().String()},
-            {Name: "Name", Value: objectAt.EncodeAsString()},
-        }
-        if keyboundObject, ok := objectAt.(services.KeyBound); ok && keyboundObject.GetKey() != "" {
-            record = append(record, rec_files.Field{Name: "Key", Value: keyboundObject.GetKey()})
-        }
-        if diffHolder, ok := objectAt.(services.LockDifficultyHolder); ok {
-            record = append(record, rec_files.Field{Name: "Difficulty", Value: diffHolder.GetLockDifficulty().ToString()})
-        }
-        if contentHolder, ok := objectAt.(services.ContentHolder); ok {
-            for _, itemName := range contentHolder.GetContents() {
-                record = append(record, rec_files.Field{Name: "Content", Value: itemName})
-            }
-        }
-        records = append(records, record)
+        records = append(records, objectToRecord(objectAt))
     }
     sort.SliceStable(records, func(i, j int) bool {
         return records[i][0].Value < records[j][0].Value
@@ -180,44 +174,12 @@ func (g *MapSerializer) LoadObjects(files *Files, loadedMap *gridmap.GridMap[*co
     }
     defer file.Close()
     records := rec_files.Read(file)
-    factory := g.engine.ObjectFactory
 
     for _, record := range records {
-        var pos geometry.Point
-        var objectName string
-        var key string
-        var contents []string
-        var difficulty string
-        for _, field := range record {
-            switch field.Name {
-            case "ObjectAt":
-                pos, _ = geometry.NewPointFromString(field.Value)
-            case "Name":
-                objectName = field.Value
-            case "FgColor", "BgColor":
-                // legacy field — ignored, colors come from the theme now
-            case "Key":
-                key = field.Value
-            case "Difficulty":
-                difficulty = field.Value
-            case "Content":
-                contents = append(contents, field.Value)
-            }
-        }
-        object := factory.NewObjectFromName(objectName)
+        object, pos := g.objectFromRecord(record)
         if object == nil {
-            println(fmt.Sprintf("Error loading object '%s'", objectName))
             continue
         }
-        if keyboundObject, ok := object.(services.KeyBound); ok && key != "" {
-            keyboundObject.SetKey(key)
-        }
-        if diffHolder, ok := object.(services.LockDifficultyHolder); ok && difficulty != "" {
-            diffHolder.SetLockDifficulty(core.NewLockDifficultyFromString(difficulty))
-        }
-        if contentHolder, ok := object.(services.ContentHolder); ok && len(contents) > 0 {
-            contentHolder.SetContents(contents)
-        }
         loadedMap.AddObject(object, pos)
     }
 
@@ -225,6 +187,83 @@ func (g *MapSerializer) LoadObjects(files *Files, loadedMap *gridmap.GridMap[*co
     return nil
 }
 
+func objectToRecord(objectAt services.Object) rec_files.Record {
+    record := []rec_files.Field{
+        {Name: "ObjectAt", Value: objectAt.Pos().String()},
+        {Name: "Name", Value: objectAt.EncodeAsString()},
+    }
+    if keyboundObject, ok := objectAt.(services.KeyBound); ok && keyboundObject.GetKey() != "" {
+        record = append(record, rec_files.Field{Name: "Key", Value: keyboundObject.GetKey()})
+    }
+    if diffHolder, ok := objectAt.(services.LockDifficultyHolder); ok {
+        record = append(record, rec_files.Field{Name: "Difficulty", Value: diffHolder.GetLockDifficulty().ToString()})
+    }
+    if contentHolder, ok := objectAt.(services.ContentHolder); ok {
+        for _, itemName := range contentHolder.GetContents() {
+            record = append(record, rec_files.Field{Name: "Content", Value: itemName})
+        }
+    }
+    if wiredObject, ok := objectAt.(services.Wired); ok && wiredObject.GetCircuit() != "" {
+        record = append(record, rec_files.Field{Name: "Circuit", Value: wiredObject.GetCircuit()})
+    }
+    if failSafeLock, ok := objectAt.(services.FailSafeLock); ok && failSafeLock.IsFailOpen() {
+        record = append(record, rec_files.Field{Name: "FailOpen", Value: "true"})
+    }
+    return record
+}
+
+// objectFromRecord creates the object described by the record. It returns nil if the object is unknown.
+func (g *MapSerializer) objectFromRecord(record rec_files.Record) (services.Object, geometry.Point) {
+    var pos geometry.Point
+    var objectName string
+    var key string
+    var contents []string
+    var difficulty string
+    var circuit string
+    var failOpen bool
+    for _, field := range record {
+        switch field.Name {
+        case "ObjectAt":
+            pos, _ = geometry.NewPointFromString(field.Value)
+        case "Name":
+            objectName = field.Value
+        case "FgColor", "BgColor":
+            // legacy field — ignored, colors come from the theme now
+        case "Key":
+            key = field.Value
+        case "Difficulty":
+            difficulty = field.Value
+        case "Content":
+            contents = append(contents, field.Value)
+        case "Circuit":
+            circuit = field.Value
+        case "FailOpen":
+            failOpen = field.Value == "true"
+        }
+    }
+    object := g.engine.ObjectFactory.NewObjectFromName(objectName)
+    if object == nil {
+        println(fmt.Sprintf("Error loading object '%s'", objectName))
+        return nil, pos
+    }
+    if keyboundObject, ok := object.(services.KeyBound); ok && key != "" {
+        keyboundObject.SetKey(key)
+    }
+    if diffHolder, ok := object.(services.LockDifficultyHolder); ok && difficulty != "" {
+        diffHolder.SetLockDifficulty(core.NewLockDifficultyFromString(difficulty))
+    }
+    if contentHolder, ok := object.(services.ContentHolder); ok && len(contents) > 0 {
+        contentHolder.SetContents(contents)
+    }
+    if wiredObject, ok := object.(services.Wired); ok && circuit != "" {
+        wiredObject.SetCircuit(circuit)
+    }
+    if failSafeLock, ok := object.(services.FailSafeLock); ok && failOpen {
+        failSafeLock.SetFailOpen(true)
+    }
+    return object, pos
+}
+
 func (g *MapSerializer) SaveActors(currentMap *gridmap.GridMap[*core.Actor, *core.Item, services.Object], filename string) error {
     file, err := os.Create(filename)
     if err != nil {
@@ -254,7 +293,6 @@ func NewActorOnDiskFromActor(person *core.Actor) core.ActorOnDisk {
     }
 }
 func (g *MapSerializer) LoadActors(files *Files, loadedMap *gridmap.GridMap[*core.Actor, *core.Item, services.Object], filename string) error {
-    data := g.engine.ExternalData
     file, err := files.Open(filename)
     if err != nil {
         return err
@@ -264,19 +302,24 @@ func (g *MapSerializer) LoadActors(files *Files, loadedMap *gridmap.GridMap[*cor
 
     records := rec_files.Read(file)
     for _, record := range records {
-        onDiskActor := core.ActorOnDiskFromRecord(record)
-        newActor := data.NewActorFromDisk(g.engine.ItemFactory, onDiskActor)
-        if newActor.IsDowned() {
-            loadedMap.AddDownedActor(newActor, newActor.Pos())
-        } else {
-            loadedMap.AddActor(newActor, newActor.Pos())
-        }
+        g.addActorFromRecord(loadedMap, record)
         actorCounter++
     }
     println(fmt.Sprintf("Loaded %d actors", actorCounter))
     return nil
 }
 
+func (g *MapSerializer) addActorFromRecord(loadedMap *gridmap.GridMap[*core.Actor, *core.Item, services.Object], record rec_files.Record) *core.Actor {
+    onDiskActor := core.ActorOnDiskFromRecord(record)
+    newActor := g.engine.ExternalData.NewActorFromDisk(g.engine.ItemFactory, onDiskActor)
+    if newActor.IsDowned() {
+        loadedMap.AddDownedActor(newActor, newActor.Pos())
+    } else {
+        loadedMap.AddActor(newActor, newActor.Pos())
+    }
+    return newActor
+}
+
 func (g *MapSerializer) SaveSchedules(currentMap *gridmap.GridMap[*core.Actor, *core.Item, services.Object], mapFolder string) error {
     schedulesFile, err := os.Create(path.Join(mapFolder, "schedules.txt"))
     if err != nil {
@@ -304,7 +347,7 @@ func (g *MapSerializer) SaveSchedules(currentMap *gridmap.GridMap[*core.Actor, *
         }
     }
 
-    for _, sched := range g.engine.GetGame().GetMap().ListOfSchedules() {
+    for _, sched := range currentMap.ListOfSchedules() {
         schedulesAsRecords = append(schedulesAsRecords, sched.ToRecords()...)
     }
 
@@ -661,6 +704,35 @@ func (g *MapSerializer) LoadNamedLocations(files *Files, currentMap *gridmap.Gri
 }
 
 
+func (g *MapSerializer) SaveFloors(currentMap *gridmap.GridMap[*core.Actor, *core.Item, services.Object], filename string) error {
+    file, err := os.Create(filename)
+    if err != nil {
+        return err
+    }
+    defer file.Close()
+    records := make([]rec_files.Record, 0, len(currentMap.Floors))
+    for _, floor := range currentMap.Floors {
+        records = append(records, floor.ToRecord())
+    }
+    return rec_files.Write(file, records)
+}
+
+// LoadFloors reads the floors of a multi-floor map. Maps without a floors.txt have a single floor.
+func (g *MapSerializer) LoadFloors(files *Files, currentMap *gridmap.GridMap[*core.Actor, *core.Item, services.Object], filename string) error {
+    file, err := files.Open(filename)
+    if err != nil {
+        return nil
+    }
+    defer file.Close()
+    for _, record := range rec_files.Read(file) {
+        currentMap.AddFloor(gridmap.NewFloorFromRecord(record))
+    }
+    if currentMap.HasFloors() {
+        println(fmt.Sprintf("Loaded %d floors", len(currentMap.Floors)))
+    }
+    return nil
+}
+
 func NewGlobalDataFromMap(currentMap *gridmap.GridMap[*core.Actor, *core.Item, services.Object]) gridmap.GlobalMapDataOnDisk {
     return gridmap.GlobalMapDataOnDisk{
         Width:             currentMap.MapWidth,
@@ -733,6 +805,11 @@ func (g *ConsoleEngine) SaveMap(currentMap *gridmap.GridMap[*core.Actor, *core.I
 		return namedLocationsErr
 	}
 
+	floorsErr := serializer.SaveFloors(currentMap, path.Join(mapFolder, "floors.txt"))
+	if floorsErr != nil {
+		return floorsErr
+	}
+
 	return nil
 }
 
@@ -802,5 +879,10 @@ func (g *ConsoleEngine) LoadMap(mapFolder string) (*gridmap.GridMap[*core.Actor,
         println("Error loading named locations: " + namedLocationsErr.Error())
     }
 
+    floorsErr := serializer.LoadFloors(files, loadedMap, path.Join(mapFolder, "floors.txt"))
+    if floorsErr != nil {
+        println("Error loading floors: " + floorsErr.Error())
+    }
+
     return loadedMap, nil
 }
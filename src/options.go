@@ -25,6 +25,8 @@ type Options struct {
 	ShowHints      bool
 	ControllerMode string
 	TextFont       string
+	// Profile is the name of the career profile that was played last
+	Profile string
 }
 
 func LoadOptions(tileSize int) Options {
@@ -95,6 +97,8 @@ func LoadOptions(tileSize int) Options {
 			cfg.ControllerMode = field.Value
 		case "TextFont":
 			cfg.TextFont = field.Value
+		case "Profile":
+			cfg.Profile = field.Value
 		}
 	}
 
@@ -130,6 +134,7 @@ func SaveOptions(cfg Options) {
 			{Name: "ShowHints", Value: boolStr(cfg.ShowHints)},
 			{Name: "ControllerMode", Value: cfg.ControllerMode},
 			{Name: "TextFont", Value: cfg.TextFont},
+			{Name: "Profile", Value: cfg.Profile},
 		},
 	}); err != nil {
 		log.Printf("[Options] Error writing %s: %v", optionsFile, err)
@@ -0,0 +1,605 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/memmaker/terminal-assassin/game/core"
+	"github.com/memmaker/terminal-assassin/game/services"
+	"github.com/memmaker/terminal-assassin/geometry"
+	"github.com/memmaker/terminal-assassin/gridmap"
+	rec_files "github.com/memmaker/terminal-assassin/rec-files"
+)
+
+// Tiled JSON import & export
+//
+// Mapping between a Tiled map (https://doc.mapeditor.org/en/stable/reference/json-map-format/)
+// and the native map folder:
+//
+//	tile layer "tiles"             <-> tilemap.txt (tileset "glyphs", one tile per icon of the tile table)
+//	tile layer "zones"             <-> zone_map.txt (tileset "zones", one tile per zone, properties from zones.txt)
+//	object layer "actors"          <-> actors.txt + actor_schedules.txt (property StartSchedule)
+//	object layer "items"           <-> item_locations.txt
+//	object layer "objects"         <-> objects.txt
+//	object layer "baked_lights"    <-> baked_lights.txt
+//	object layer "dynamic_lights"  <-> dynamic_lights.txt
+//	object layer "named_locations" <-> named_locations.txt
+//	object layer "schedules"       <-> schedules.txt (one object per task, in task order)
+//	object layer "floors"          <-> floors.txt (one rectangle per floor, property Level)
+//	map properties                 <-> global.txt
+//
+// Floors are rectangle objects covering the cells of the floor, all other objects are point objects placed in the center of their cell. The remaining
+// fields of the native record become string properties of the object. Tiled only
+// allows unique property names, so repeated fields (eg. Inventory) are numbered: "Inventory", "Inventory#2", ...
+// The tilesets have no images, Tiled shows the icon in the "Icon" property of each tile.
+
+const (
+	tiledCellWidth  = 16
+	tiledCellHeight = 16
+	// the upper bits of a gid store the flip flags of the tile
+	tiledGIDMask = 0x0FFFFFFF
+)
+
+// tiledProperty values are always written as strings. Properties added in Tiled
+// can have other types, so the value is read as any.
+type tiledProperty struct {
+	Name  string `json:"name"`
+	Type  string `json:"type"`
+	Value any    `json:"value"`
+}
+
+func (p tiledProperty) String() string {
+	if p.Value == nil {
+		return ""
+	}
+	return fmt.Sprint(p.Value)
+}
+
+type tiledObject struct {
+	ID         int             `json:"id"`
+	Name       string          `json:"name"`
+	Type       string          `json:"type"`
+	X          float64         `json:"x"`
+	Y          float64         `json:"y"`
+	Width      float64         `json:"width"`
+	Height     float64         `json:"height"`
+	Rotation   float64         `json:"rotation"`
+	Point      bool            `json:"point"`
+	Visible    bool            `json:"visible"`
+	Properties []tiledProperty `json:"properties,omitempty"`
+}
+
+type tiledLayer struct {
+	ID        int           `json:"id"`
+	Name      string        `json:"name"`
+	Type      string        `json:"type"`
+	Width     int           `json:"width,omitempty"`
+	Height    int           `json:"height,omitempty"`
+	Data      []int         `json:"data,omitempty"`
+	Objects   []tiledObject `json:"objects,omitempty"`
+	DrawOrder string        `json:"draworder,omitempty"`
+	Opacity   float64       `json:"opacity"`
+	Visible   bool          `json:"visible"`
+	X         int           `json:"x"`
+	Y         int           `json:"y"`
+}
+
+type tiledTile struct {
+	ID         int             `json:"id"`
+	Properties []tiledProperty `json:"properties,omitempty"`
+}
+
+type tiledTileset struct {
+	FirstGID   int         `json:"firstgid"`
+	Name       string      `json:"name"`
+	TileWidth  int         `json:"tilewidth"`
+	TileHeight int         `json:"tileheight"`
+	TileCount  int         `json:"tilecount"`
+	Columns    int         `json:"columns"`
+	Margin     int         `json:"margin"`
+	Spacing    int         `json:"spacing"`
+	Tiles      []tiledTile `json:"tiles"`
+}
+
+type tiledMap struct {
+	Type         string          `json:"type"`
+	Version      string          `json:"version"`
+	TiledVersion string          `json:"tiledversion"`
+	Orientation  string          `json:"orientation"`
+	RenderOrder  string          `json:"renderorder"`
+	Infinite     bool            `json:"infinite"`
+	Width        int             `json:"width"`
+	Height       int             `json:"height"`
+	TileWidth    int             `json:"tilewidth"`
+	TileHeight   int             `json:"tileheight"`
+	NextLayerID  int             `json:"nextlayerid"`
+	NextObjectID int             `json:"nextobjectid"`
+	Layers       []tiledLayer    `json:"layers"`
+	Tilesets     []tiledTileset  `json:"tilesets"`
+	Properties   []tiledProperty `json:"properties,omitempty"`
+}
+
+func (m *tiledMap) layerByName(name string) *tiledLayer {
+	for i := range m.Layers {
+		if m.Layers[i].Name == name {
+			return &m.Layers[i]
+		}
+	}
+	return nil
+}
+
+func (m *tiledMap) tilesetByName(name string) *tiledTileset {
+	for i := range m.Tilesets {
+		if m.Tilesets[i].Name == name {
+			return &m.Tilesets[i]
+		}
+	}
+	return nil
+}
+
+func (m *tiledMap) addObjectLayer(name string, objectRecords []rec_files.Record, nameField, posField, objectType string) {
+	objects := make([]tiledObject, 0, len(objectRecords))
+	for _, record := range objectRecords {
+		objects = append(objects, m.newObjectFromRecord(record, nameField, posField, objectType))
+	}
+	m.Layers = append(m.Layers, tiledLayer{
+		ID:        m.NextLayerID,
+		Name:      name,
+		Type:      "objectgroup",
+		Objects:   objects,
+		DrawOrder: "index",
+		Opacity:   1,
+		Visible:   true,
+	})
+	m.NextLayerID++
+}
+
+func (m *tiledMap) newObjectFromRecord(record rec_files.Record, nameField, posField, objectType string) tiledObject {
+	object := tiledObject{
+		ID:      m.NextObjectID,
+		Type:    objectType,
+		Point:   true,
+		Visible: true,
+	}
+	m.NextObjectID++
+	otherFields := make(rec_files.Record, 0, len(record))
+	for _, field := range record {
+		switch field.Name {
+		case posField:
+			pos, _ := geometry.NewPointFromString(field.Value)
+			object.X = float64(pos.X*tiledCellWidth) + tiledCellWidth/2
+			object.Y = float64(pos.Y*tiledCellHeight) + tiledCellHeight/2
+		case nameField:
+			object.Name = field.Value
+		default:
+			otherFields = append(otherFields, field)
+		}
+	}
+	object.Properties = recordToProperties(otherFields)
+	return object
+}
+
+// objectToRecord turns a Tiled object back into a native record, the position
+// is taken from the location of the object on the map.
+func (m *tiledMap) objectToRecord(object tiledObject, nameField, posField string) rec_files.Record {
+	pos := geometry.Point{X: int(object.X) / m.TileWidth, Y: int(object.Y) / m.TileHeight}
+	record := rec_files.Record{
+		{Name: posField, Value: pos.String()},
+	}
+	if nameField != "" {
+		record = append(record, rec_files.Field{Name: nameField, Value: object.Name})
+	}
+	return append(record, propertiesToFields(object.Properties)...)
+}
+
+func recordToProperties(record rec_files.Record) []tiledProperty {
+	properties := make([]tiledProperty, 0, len(record))
+	fieldCount := make(map[string]int)
+	for _, field := range record {
+		fieldCount[field.Name]++
+		propertyName := field.Name
+		if fieldCount[field.Name] > 1 {
+			propertyName = fmt.Sprintf("%s#%d", field.Name, fieldCount[field.Name])
+		}
+		properties = append(properties, tiledProperty{Name: propertyName, Type: "string", Value: field.Value})
+	}
+	return properties
+}
+
+// propertiesToFields reverses the numbering of repeated fields. Tiled sorts
+// properties by name when saving, so the original order is restored from the numbers.
+func propertiesToFields(properties []tiledProperty) []rec_files.Field {
+	type numberedField struct {
+		field  rec_files.Field
+		number int
+	}
+	fields := make([]numberedField, 0, len(properties))
+	for _, property := range properties {
+		name, number := property.Name, 1
+		if hashIndex := strings.LastIndex(property.Name, "#"); hashIndex > 0 {
+			if parsedNumber, err := strconv.Atoi(property.Name[hashIndex+1:]); err == nil {
+				name, number = property.Name[:hashIndex], parsedNumber
+			}
+		}
+		fields = append(fields, numberedField{field: rec_files.Field{Name: name, Value: property.String()}, number: number})
+	}
+	sort.SliceStable(fields, func(i, j int) bool {
+		if fields[i].field.Name != fields[j].field.Name {
+			return fields[i].field.Name < fields[j].field.Name
+		}
+		return fields[i].number < fields[j].number
+	})
+	result := make([]rec_files.Field, len(fields))
+	for i, f := range fields {
+		result[i] = f.field
+	}
+	return result
+}
+
+func propertyValue(properties []tiledProperty, name string) string {
+	for _, property := range properties {
+		if property.Name == name {
+			return property.String()
+		}
+	}
+	return ""
+}
+
+// ExportTiledMap writes the map as a Tiled JSON map.
+func (g *ConsoleEngine) ExportTiledMap(currentMap *gridmap.GridMap[*core.Actor, *core.Item, services.Object], filename string) error {
+	exported := &tiledMap{
+		Type:         "map",
+		Version:      "1.10",
+		TiledVersion: "1.10.2",
+		Orientation:  "orthogonal",
+		RenderOrder:  "right-down",
+		Width:        currentMap.MapWidth,
+		Height:       currentMap.MapHeight,
+		TileWidth:    tiledCellWidth,
+		TileHeight:   tiledCellHeight,
+		NextLayerID:  1,
+		NextObjectID: 1,
+		Properties:   recordToProperties(NewGlobalDataFromMap(currentMap).ToRecord()),
+	}
+	cellCount := currentMap.MapWidth * currentMap.MapHeight
+
+	// glyphs: the tile table, plus every icon on the map that is not part of it
+	glyphs := &tiledTileset{FirstGID: 1, Name: "glyphs", TileWidth: tiledCellWidth, TileHeight: tiledCellHeight}
+	glyphIDs := make(map[rune]int)
+	addGlyph := func(tile gridmap.Tile) {
+		if _, known := glyphIDs[tile.DefinedIcon]; known {
+			return
+		}
+		glyphIDs[tile.DefinedIcon] = len(glyphs.Tiles)
+		glyphs.Tiles = append(glyphs.Tiles, tiledTile{
+			ID: len(glyphs.Tiles),
+			Properties: []tiledProperty{
+				{Name: "Icon", Type: "string", Value: string(tile.DefinedIcon)},
+				{Name: "Description", Type: "string", Value: tile.DefinedDescription},
+				{Name: "Special", Type: "string", Value: tile.Special.ToString()},
+			},
+		})
+	}
+	for _, tile := range g.ExternalData.Tiles() {
+		addGlyph(*tile)
+	}
+	tileData := make([]int, cellCount)
+	for y := 0; y < currentMap.MapHeight; y++ {
+		for x := 0; x < currentMap.MapWidth; x++ {
+			tile := currentMap.GetCell(geometry.Point{X: x, Y: y}).TileType
+			addGlyph(tile)
+			tileData[y*currentMap.MapWidth+x] = glyphs.FirstGID + glyphIDs[tile.DefinedIcon]
+		}
+	}
+	glyphs.TileCount = len(glyphs.Tiles)
+
+	// zones: tile id = index in the list of zones, 0 is the public space
+	zones := &tiledTileset{FirstGID: glyphs.FirstGID + glyphs.TileCount, Name: "zones", TileWidth: tiledCellWidth, TileHeight: tiledCellHeight}
+	for zoneIndex, zone := range currentMap.ListOfZones {
+		zones.Tiles = append(zones.Tiles, tiledTile{ID: zoneIndex, Properties: recordToProperties(zone.ToRecord())})
+	}
+	zones.TileCount = len(zones.Tiles)
+	zoneData := make([]int, cellCount)
+	for y := 0; y < currentMap.MapHeight; y++ {
+		for x := 0; x < currentMap.MapWidth; x++ {
+			zoneAt := currentMap.ZoneAt(geometry.Point{X: x, Y: y})
+			zoneData[y*currentMap.MapWidth+x] = zones.FirstGID + indexOf(zoneAt, currentMap.ListOfZones)
+		}
+	}
+	exported.Tilesets = []tiledTileset{*glyphs, *zones}
+
+	for _, layer := range []struct {
+		name string
+		data []int
+	}{{"tiles", tileData}, {"zones", zoneData}} {
+		exported.Layers = append(exported.Layers, tiledLayer{
+			ID:      exported.NextLayerID,
+			Name:    layer.name,
+			Type:    "tilelayer",
+			Width:   currentMap.MapWidth,
+			Height:  currentMap.MapHeight,
+			Data:    layer.data,
+			Opacity: 1,
+			Visible: true,
+		})
+		exported.NextLayerID++
+	}
+
+	actors := append(append([]*core.Actor{}, currentMap.Actors()...), currentMap.DownedActors()...)
+	sort.SliceStable(actors, func(i, j int) bool { return actors[i].Name < actors[j].Name })
+	actorRecords := make([]rec_files.Record, 0, len(actors))
+	for _, actor := range actors {
+		record := rec_files.Record(NewActorOnDiskFromActor(actor).ToRecord())
+		if actor.AI != nil && actor.AI.Schedule != "" {
+			record = append(record, rec_files.Field{Name: "StartSchedule", Value: actor.AI.Schedule})
+		}
+		actorRecords = append(actorRecords, record)
+	}
+	exported.addObjectLayer("actors", actorRecords, "Name", "Position", "actor")
+
+	itemRecords := make([]rec_files.Record, 0)
+	for _, item := range currentMap.Items() {
+		itemRecords = append(itemRecords, itemLocationToRecord(item))
+	}
+	exported.addObjectLayer("items", itemRecords, "Name", "ItemAt", "item")
+
+	objectRecords := make([]rec_files.Record, 0)
+	for _, object := range currentMap.Objects() {
+		objectRecords = append(objectRecords, objectToRecord(object))
+	}
+	exported.addObjectLayer("objects", objectRecords, "Name", "ObjectAt", "object")
+
+	exported.addObjectLayer("baked_lights", lightsToRecords(currentMap.BakedLights), "", "Pos", "light")
+	exported.addObjectLayer("dynamic_lights", lightsToRecords(currentMap.DynamicLights), "", "Pos", "light")
+
+	locationRecords := make([]rec_files.Record, 0, len(currentMap.NamedLocations))
+	for name, location := range currentMap.NamedLocations {
+		locationRecords = append(locationRecords, rec_files.Record{
+			{Name: "Name", Value: name},
+			{Name: "Location", Value: location.String()},
+		})
+	}
+	sort.SliceStable(locationRecords, func(i, j int) bool { return locationRecords[i][0].Value < locationRecords[j][0].Value })
+	exported.addObjectLayer("named_locations", locationRecords, "Name", "Location", "location")
+
+	taskRecords := make([]rec_files.Record, 0)
+	for _, schedule := range currentMap.ListOfSchedules() {
+		taskRecords = append(taskRecords, schedule.ToRecords()...)
+	}
+	exported.addObjectLayer("schedules", taskRecords, "TaskForSchedule", "Location", "task")
+	exported.addFloorLayer(currentMap.Floors)
+
+	file, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	encoder := json.NewEncoder(file)
+	encoder.SetIndent("", " ")
+	return encoder.Encode(exported)
+}
+
+// addFloorLayer adds the floors as rectangles, so they can be moved and resized in Tiled.
+func (m *tiledMap) addFloorLayer(floors []*gridmap.Floor) {
+	objects := make([]tiledObject, 0, len(floors))
+	for _, floor := range floors {
+		size := floor.Bounds.Size()
+		objects = append(objects, tiledObject{
+			ID:         m.NextObjectID,
+			Name:       floor.Name,
+			Type:       "floor",
+			X:          float64(floor.Bounds.Min.X * tiledCellWidth),
+			Y:          float64(floor.Bounds.Min.Y * tiledCellHeight),
+			Width:      float64(size.X * tiledCellWidth),
+			Height:     float64(size.Y * tiledCellHeight),
+			Visible:    true,
+			Properties: []tiledProperty{{Name: "Level", Type: "string", Value: strconv.Itoa(floor.Level)}},
+		})
+		m.NextObjectID++
+	}
+	m.Layers = append(m.Layers, tiledLayer{
+		ID:        m.NextLayerID,
+		Name:      "floors",
+		Type:      "objectgroup",
+		Objects:   objects,
+		DrawOrder: "index",
+		Opacity:   1,
+		Visible:   true,
+	})
+	m.NextLayerID++
+}
+
+// objectToFloor turns a rectangle of the floors layer back into a floor, the bounds
+// are rounded to whole cells.
+func (m *tiledMap) objectToFloor(object tiledObject) *gridmap.Floor {
+	toCell := func(value float64, cellSize int) int {
+		return int(math.Round(value / float64(cellSize)))
+	}
+	level, _ := strconv.Atoi(strings.TrimSpace(propertyValue(object.Properties, "Level")))
+	return &gridmap.Floor{
+		Name:  object.Name,
+		Level: level,
+		Bounds: geometry.NewRect(
+			toCell(object.X, m.TileWidth), toCell(object.Y, m.TileHeight),
+			toCell(object.X+object.Width, m.TileWidth), toCell(object.Y+object.Height, m.TileHeight),
+		),
+	}
+}
+
+func lightsToRecords(lights map[geometry.Point]*gridmap.LightSource) []rec_files.Record {
+	records := make([]rec_files.Record, 0, len(lights))
+	for _, light := range lights {
+		records = append(records, light.ToRecord())
+	}
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i][0].Value < records[j][0].Value
+	})
+	return records
+}
+
+// ImportTiledMap reads a Tiled JSON map written by ExportTiledMap (or edited in Tiled since).
+// The returned map has no map folder yet, it must be saved to become a native map.
+func (g *ConsoleEngine) ImportTiledMap(filename string) (*gridmap.GridMap[*core.Actor, *core.Item, services.Object], error) {
+	file, err := g.Files.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	content, err := io.ReadAll(file)
+	if err != nil {
+		return nil, err
+	}
+	var imported tiledMap
+	if err = json.Unmarshal(content, &imported); err != nil {
+		return nil, err
+	}
+	if imported.Orientation != "orthogonal" || imported.Infinite {
+		return nil, fmt.Errorf("only finite, orthogonal Tiled maps are supported")
+	}
+	if imported.TileWidth <= 0 || imported.TileHeight <= 0 {
+		return nil, fmt.Errorf("invalid tile size %dx%d", imported.TileWidth, imported.TileHeight)
+	}
+
+	serializer := MapSerializer{engine: g}
+	globalData := gridmap.NewGlobalMapFromRecord(propertiesToFields(imported.Properties))
+	globalData.Width = imported.Width
+	globalData.Height = imported.Height
+	if globalData.MaxVisionRange == 0 {
+		globalData.MaxVisionRange = g.Config.MaxVisionRange
+	}
+	loadedMap := gridmap.NewEmptyMap[*core.Actor, *core.Item, services.Object](globalData.Width, globalData.Height, globalData.MaxVisionRange)
+	hash := sha256.Sum256([]byte(filename))
+	loadedMap.MetaData.HashAsHex = hex.EncodeToString(hash[:])
+	serializer.ApplyGlobalMapData(loadedMap, globalData)
+
+	if err = g.importTiledTiles(&imported, loadedMap); err != nil {
+		return nil, err
+	}
+	g.importTiledZones(&imported, loadedMap)
+
+	objectsOf := func(layerName string) []tiledObject {
+		if layer := imported.layerByName(layerName); layer != nil {
+			return layer.Objects
+		}
+		return nil
+	}
+
+	actorsByName := make(map[string]*core.Actor)
+	for _, object := range objectsOf("actors") {
+		actor := serializer.addActorFromRecord(loadedMap, imported.objectToRecord(object, "Name", "Position"))
+		if actor.AI != nil {
+			actor.AI.Schedule = propertyValue(object.Properties, "StartSchedule")
+			actorsByName[actor.Name] = actor
+		}
+	}
+	for _, object := range objectsOf("items") {
+		item, pos := serializer.itemFromRecord(imported.objectToRecord(object, "Name", "ItemAt"))
+		loadedMap.AddItem(item, pos)
+	}
+	for _, object := range objectsOf("objects") {
+		mapObject, pos := serializer.objectFromRecord(imported.objectToRecord(object, "Name", "ObjectAt"))
+		if mapObject != nil {
+			loadedMap.AddObject(mapObject, pos)
+		}
+	}
+	for _, object := range objectsOf("baked_lights") {
+		light := gridmap.NewLightSourceFromRecord(imported.objectToRecord(object, "", "Pos"))
+		loadedMap.AddBakedLightSource(light.Pos, light)
+	}
+	for _, object := range objectsOf("dynamic_lights") {
+		light := gridmap.NewLightSourceFromRecord(imported.objectToRecord(object, "", "Pos"))
+		loadedMap.AddDynamicLightSource(light.Pos, light)
+	}
+	for _, object := range objectsOf("named_locations") {
+		loadedMap.NamedLocations[object.Name] = geometry.Point{X: int(object.X) / imported.TileWidth, Y: int(object.Y) / imported.TileHeight}
+	}
+	taskRecords := make([]rec_files.Record, 0)
+	for _, object := range objectsOf("schedules") {
+		taskRecords = append(taskRecords, imported.objectToRecord(object, "TaskForSchedule", "Location"))
+	}
+	for _, schedule := range gridmap.SchedulesFromTaskRecords(taskRecords) {
+		loadedMap.AddSchedule(schedule)
+	}
+	for _, object := range objectsOf("floors") {
+		loadedMap.AddFloor(imported.objectToFloor(object))
+	}
+	for _, actor := range actorsByName {
+		if actor.AI.Schedule != "" && loadedMap.AllSchedules[actor.AI.Schedule] == nil {
+			println(fmt.Sprintf("Error importing actor schedule: no schedule named '%s' for actor '%s'", actor.AI.Schedule, actor.Name))
+			actor.AI.Schedule = ""
+		}
+	}
+
+	println(fmt.Sprintf("Imported Tiled map %s (%dx%d, %d actors, %d items, %d objects)", filename, loadedMap.MapWidth, loadedMap.MapHeight, len(loadedMap.AllActors), len(loadedMap.Items()), len(loadedMap.Objects())))
+	return loadedMap, nil
+}
+
+func (g *ConsoleEngine) importTiledTiles(imported *tiledMap, loadedMap *gridmap.GridMap[*core.Actor, *core.Item, services.Object]) error {
+	layer := imported.layerByName("tiles")
+	if layer == nil || layer.Type != "tilelayer" {
+		return fmt.Errorf("tile layer 'tiles' is missing")
+	}
+	glyphs := imported.tilesetByName("glyphs")
+	if glyphs == nil {
+		return fmt.Errorf("tileset 'glyphs' is missing (external tilesets are not supported)")
+	}
+	tilesByGID := make(map[int]gridmap.Tile)
+	for _, tile := range glyphs.Tiles {
+		icon := []rune(propertyValue(tile.Properties, "Icon"))
+		if len(icon) == 0 {
+			continue
+		}
+		tilesByGID[glyphs.FirstGID+tile.ID] = g.ExternalData.TileFromIcon(icon[0])
+	}
+	for index, gid := range layer.Data {
+		if index >= loadedMap.MapWidth*loadedMap.MapHeight {
+			break
+		}
+		pos := geometry.Point{X: index % loadedMap.MapWidth, Y: index / loadedMap.MapWidth}
+		if tile, known := tilesByGID[gid&tiledGIDMask]; known {
+			loadedMap.SetTile(pos, tile)
+		} else {
+			loadedMap.SetTile(pos, g.ExternalData.GroundTile())
+		}
+	}
+	return nil
+}
+
+func (g *ConsoleEngine) importTiledZones(imported *tiledMap, loadedMap *gridmap.GridMap[*core.Actor, *core.Item, services.Object]) {
+	zones := imported.tilesetByName("zones")
+	if zones == nil {
+		return
+	}
+	zoneTiles := append([]tiledTile{}, zones.Tiles...)
+	sort.SliceStable(zoneTiles, func(i, j int) bool { return zoneTiles[i].ID < zoneTiles[j].ID })
+	zonesByGID := map[int]*gridmap.ZoneInfo{0: loadedMap.ListOfZones[0]}
+	for _, tile := range zoneTiles {
+		if tile.ID == 0 {
+			zonesByGID[zones.FirstGID] = loadedMap.ListOfZones[0]
+			continue
+		}
+		zone := gridmap.NewZoneFromRecord(propertiesToFields(tile.Properties))
+		loadedMap.AddZone(zone)
+		zonesByGID[zones.FirstGID+tile.ID] = zone
+	}
+	layer := imported.layerByName("zones")
+	if layer == nil {
+		return
+	}
+	for index, gid := range layer.Data {
+		if index >= loadedMap.MapWidth*loadedMap.MapHeight {
+			break
+		}
+		if zone, known := zonesByGID[gid&tiledGIDMask]; known {
+			loadedMap.SetZone(geometry.Point{X: index % loadedMap.MapWidth, Y: index / loadedMap.MapWidth}, zone)
+		}
+	}
+}
@@ -596,7 +596,7 @@ func (m *Manager) ShowPager(title string, lines []core.StyledText, onQuit func()
     m.pushModal(pager)
 }
 
-func (m *Manager) OpenItemRingMenu(currentItem *core.Item, listOfItems []*core.Item, selectedFunc func(*core.Item), cancelFunc func(), dropFunc func(*core.Item)) {
+func (m *Manager) OpenItemRingMenu(currentItem *core.Item, listOfItems []*core.Item, selectedFunc func(*core.Item), cancelFunc func(), dropFunc func(*core.Item), combineFunc func(*core.Item)) {
     if listOfItems == nil || len(listOfItems) == 0 {
         return
     }
@@ -618,9 +618,18 @@ func (m *Manager) OpenItemRingMenu(currentItem *core.Item, listOfItems []*core.I
             dropFunc(item)
         }
     }
+    var onCombine func(*core.Item)
+    if combineFunc != nil {
+        onCombine = func(item *core.Item) {
+            m.PopModal()
+            if item != nil {
+                combineFunc(item)
+            }
+        }
+    }
     gridWidth := m.engine.ScreenGridWidth()
     labelYOffset := m.engine.ScreenGridHeight() / 2
-    ringMenu := NewRingMenu(currentItem, listOfItems, onSelection, onCancel, onDrop, labelYOffset, gridWidth)
+    ringMenu := NewRingMenu(currentItem, listOfItems, onSelection, onCancel, onDrop, onCombine, labelYOffset, gridWidth)
     m.pushModal(ringMenu)
 }
 
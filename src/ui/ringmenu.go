@@ -15,6 +15,7 @@ type RingMenu struct {
 	currentIndex      int
 	selected          func(*core.Item)
 	drop              func(*core.Item)
+	combine           func(*core.Item)
 	ringSlotCount     int
 	isDirty           bool
 	itemLabel         core.StyledText
@@ -26,7 +27,7 @@ type RingMenu struct {
 	maxLabelChars     int // maximum half-width chars that fit in the label row
 }
 
-func NewRingMenu(currentItem *core.Item, listOfItems []*core.Item, onSelected func(*core.Item), onCancel func(), dropFunc func(*core.Item), yOffset, gridWidth int) *RingMenu {
+func NewRingMenu(currentItem *core.Item, listOfItems []*core.Item, onSelected func(*core.Item), onCancel func(), dropFunc func(*core.Item), combineFunc func(*core.Item), yOffset, gridWidth int) *RingMenu {
 	// Compute a border distance that guarantees the arrows (at ±(loopRange+1)*3
 	// from centre) stay inside the box.  Fall back to gridWidth/4 when there is
 	// plenty of room.
@@ -65,6 +66,7 @@ func NewRingMenu(currentItem *core.Item, listOfItems []*core.Item, onSelected fu
 		currentIndex:      RingIndexOf(currentItem, listOfItems),
 		selected:          onSelected,
 		drop:              dropFunc,
+		combine:           combineFunc,
 		cancel:            onCancel,
 		ringSlotCount:     slotCount,
 		itemLabel:         core.NewStyledText(itemName, common.DefaultStyle),
@@ -122,6 +124,10 @@ func (r *RingMenu) handleGameCommand(cmd core.GameCommand) {
 		if r.drop != nil {
 			r.drop(r.listOfItems[r.currentIndex])
 		}
+	case core.MenuUp:
+		if r.combine != nil {
+			r.combine(r.listOfItems[r.currentIndex])
+		}
 	case core.MenuCancel:
 		if r.cancel != nil {
 			r.cancel()